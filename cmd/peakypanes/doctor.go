@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+const doctorHelpText = `Check that peakypanes can work with tmux and its config.
+
+Usage:
+  peakypanes doctor
+
+Checks that tmux is installed, that the config file loads, that the config
+directory is writable, and creates and kills a temporary session. Each
+check prints ✓ or ✗; the exit status is 1 when any check fails.
+
+Options:
+  -h, --help           Show this help
+`
+
+// checkResult is the outcome of one doctor check.
+type checkResult struct {
+	Name   string
+	OK     bool
+	Detail string
+}
+
+func (r checkResult) String() string {
+	mark := "✓"
+	if !r.OK {
+		mark = "✗"
+	}
+	return fmt.Sprintf("%s %s: %s", mark, r.Name, r.Detail)
+}
+
+func passed(name, format string, args ...interface{}) checkResult {
+	return checkResult{Name: name, OK: true, Detail: fmt.Sprintf(format, args...)}
+}
+
+func failed(name, format string, args ...interface{}) checkResult {
+	return checkResult{Name: name, Detail: fmt.Sprintf(format, args...)}
+}
+
+// checkTmux reports the tmux version. A nil client means tmux was not found.
+func checkTmux(ctx context.Context, client *tmuxctl.Client) checkResult {
+	if client == nil {
+		return failed("tmux", "not found in PATH")
+	}
+	v, err := client.Version(ctx)
+	if err != nil {
+		return failed("tmux", "%v", err)
+	}
+	if !v.AtLeast(tmuxctl.PopupMinVersion) {
+		return passed("tmux", "version %s (open_mode popup needs %s)", v, tmuxctl.PopupMinVersion)
+	}
+	return passed("tmux", "version %s", v)
+}
+
+// checkConfig loads the config file at path. A missing file is fine: the
+// defaults are used until `peakypanes init` creates one.
+func checkConfig(path string) checkResult {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return passed("config", "%s not created yet, using defaults", path)
+	}
+	cfg, err := layout.LoadConfig(path)
+	if err != nil {
+		return failed("config", "%v", err)
+	}
+	return passed("config", "%s (%d projects)", path, len(cfg.Projects))
+}
+
+// checkConfigDir checks that dir takes new files, as saving the project
+// order or adding projects from the TUI needs.
+func checkConfigDir(dir string) checkResult {
+	info, err := os.Stat(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return failed("config dir", "%s does not exist (run peakypanes init)", dir)
+	}
+	if err != nil {
+		return failed("config dir", "%v", err)
+	}
+	if !info.IsDir() {
+		return failed("config dir", "%s is not a directory", dir)
+	}
+	f, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return failed("config dir", "%s is not writable: %v", dir, err)
+	}
+	name := f.Name()
+	f.Close()
+	os.Remove(name)
+	return passed("config dir", "%s is writable", dir)
+}
+
+// checkSession creates session detached and kills it again, checking that
+// it was listed in between.
+func checkSession(ctx context.Context, client *tmuxctl.Client, session string) checkResult {
+	if client == nil {
+		return failed("session", "skipped without tmux")
+	}
+	if _, err := client.NewSessionWithCmd(ctx, session, os.TempDir(), "", ""); err != nil {
+		return failed("session", "create %s: %v", session, err)
+	}
+	sessions, err := client.ListSessions(ctx)
+	listed := false
+	for _, s := range sessions {
+		listed = listed || s == session
+	}
+	killErr := client.KillSession(ctx, session)
+	switch {
+	case err != nil:
+		return failed("session", "list sessions: %v", err)
+	case !listed:
+		return failed("session", "%s was created but not listed", session)
+	case killErr != nil:
+		return failed("session", "kill %s: %v", session, killErr)
+	}
+	return passed("session", "created and killed %s", session)
+}
+
+// doctorChecks runs every check in order.
+func doctorChecks(ctx context.Context, client *tmuxctl.Client, configPath string) []checkResult {
+	session := fmt.Sprintf("peakypanes-doctor-%d", os.Getpid())
+	return []checkResult{
+		checkTmux(ctx, client),
+		checkConfig(configPath),
+		checkConfigDir(filepath.Dir(configPath)),
+		checkSession(ctx, client, session),
+	}
+}
+
+// printChecks writes one line per result and reports whether all passed.
+func printChecks(w io.Writer, results []checkResult) bool {
+	ok := true
+	for _, r := range results {
+		fmt.Fprintln(w, r)
+		ok = ok && r.OK
+	}
+	return ok
+}
+
+func runDoctor(args []string) {
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Print(doctorHelpText)
+			return
+		}
+	}
+
+	configPath, err := layout.DefaultConfigPath()
+	if err != nil {
+		fatal("cannot determine config path: %v", err)
+	}
+	client, err := tmuxctl.NewClient("")
+	if err != nil {
+		client = nil // reported by the tmux check
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if !printChecks(os.Stdout, doctorChecks(ctx, client, configPath)) {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// stubTmux returns a client whose tmux calls are answered by respond.
+// respond returns the output and whether the call succeeds.
+func stubTmux(t *testing.T, respond func(args []string) (string, bool)) *tmuxctl.Client {
+	t.Helper()
+	client, err := tmuxctl.NewClient("tmux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		out, ok := respond(args)
+		if !ok {
+			return exec.CommandContext(ctx, "sh", "-c", "echo failed >&2; exit 1")
+		}
+		return exec.CommandContext(ctx, "printf", "%s", out)
+	})
+	return client
+}
+
+// TestCheckTmux tests the tmux version check
+func TestCheckTmux(t *testing.T) {
+	ctx := context.Background()
+	client := stubTmux(t, func([]string) (string, bool) { return "tmux 3.4", true })
+	if r := checkTmux(ctx, client); !r.OK || !strings.Contains(r.Detail, "3.4") {
+		t.Errorf("checkTmux() = %+v, want a pass naming 3.4", r)
+	}
+	broken := stubTmux(t, func([]string) (string, bool) { return "", false })
+	if r := checkTmux(ctx, broken); r.OK {
+		t.Errorf("checkTmux() = %+v, want a failure", r)
+	}
+	if r := checkTmux(ctx, nil); r.OK || !strings.Contains(r.String(), "✗ tmux") {
+		t.Errorf("checkTmux(nil) = %q, want a failure", r)
+	}
+}
+
+// TestCheckSession tests the create-then-kill round trip
+func TestCheckSession(t *testing.T) {
+	ctx := context.Background()
+	var killed []string
+	client := stubTmux(t, func(args []string) (string, bool) {
+		switch args[0] {
+		case "new-session":
+			return "%1", true
+		case "list-sessions":
+			return "work\nprobe\n", true
+		case "kill-session":
+			killed = append(killed, args[len(args)-1])
+		}
+		return "", true
+	})
+	if r := checkSession(ctx, client, "probe"); !r.OK {
+		t.Errorf("checkSession() = %+v, want a pass", r)
+	}
+	if len(killed) != 1 || killed[0] != "probe" {
+		t.Errorf("killed = %q, want the probe session", killed)
+	}
+
+	failing := stubTmux(t, func(args []string) (string, bool) { return "", args[0] != "new-session" })
+	if r := checkSession(ctx, failing, "probe"); r.OK || !strings.Contains(r.Detail, "create probe") {
+		t.Errorf("checkSession() = %+v, want the create failure", r)
+	}
+
+	unlisted := stubTmux(t, func(args []string) (string, bool) { return "work\n", true })
+	if r := checkSession(ctx, unlisted, "probe"); r.OK {
+		t.Errorf("checkSession() = %+v, want a failure for an unlisted session", r)
+	}
+}
+
+// TestCheckConfig tests loading, missing and broken config files and the
+// writability check of their directory
+func TestCheckConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yml")
+	if r := checkConfig(path); !r.OK {
+		t.Errorf("checkConfig(missing) = %+v, want a pass", r)
+	}
+	if err := os.WriteFile(path, []byte("projects:\n  - name: api\n    path: /srv/api\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if r := checkConfig(path); !r.OK || !strings.Contains(r.Detail, "1 projects") {
+		t.Errorf("checkConfig() = %+v, want a pass with one project", r)
+	}
+	if err := os.WriteFile(path, []byte("projects: [\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if r := checkConfig(path); r.OK {
+		t.Errorf("checkConfig(broken) = %+v, want a failure", r)
+	}
+
+	if r := checkConfigDir(dir); !r.OK {
+		t.Errorf("checkConfigDir() = %+v, want a pass", r)
+	}
+	if r := checkConfigDir(filepath.Join(dir, "missing")); r.OK {
+		t.Errorf("checkConfigDir(missing) = %+v, want a failure", r)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("config dir holds %d entries, want the probe file removed", len(entries))
+	}
+}
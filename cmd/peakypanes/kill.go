@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+	"github.com/kregenrek/tmuxman/internal/tui/peakypanes"
+)
+
+// errKillNotConfirmed is returned when a kill cannot be confirmed because
+// nobody is there to answer.
+var errKillNotConfirmed = errors.New("not killing without confirmation: stdin is not a terminal, pass --yes to kill anyway")
+
+// resolveKillSession returns the session to kill for query: the session of
+// the project find matches by name, session or alias, or query itself when
+// no project matches, so unconfigured sessions can be killed too.
+func resolveKillSession(query string, find func(string) (peakypanes.Project, error)) (string, error) {
+	p, err := find(query)
+	switch {
+	case err == nil:
+		return p.Session, nil
+	case errors.Is(err, peakypanes.ErrNoProjectMatch):
+		return query, nil
+	}
+	return "", err
+}
+
+// confirmKill asks on out whether to kill session and reads the answer
+// from in. Without a terminal to ask on it refuses with
+// errKillNotConfirmed.
+func confirmKill(session string, in *os.File, out io.Writer) (bool, error) {
+	if !isTerminal(in) {
+		return false, errKillNotConfirmed
+	}
+	fmt.Fprintf(out, "Kill session '%s'? [y/N] ", session)
+	line, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+func runKill(args []string) {
+	query := ""
+	yes := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-h", "--help":
+			fmt.Print(killHelpText)
+			return
+		case "-y", "--yes":
+			yes = true
+		default:
+			if !strings.HasPrefix(args[i], "-") && query == "" {
+				query = args[i]
+			}
+		}
+	}
+
+	// Default session name to current directory name
+	if query == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatal("cannot determine current directory: %v", err)
+		}
+		query = defaultSessionName(cwd)
+	}
+
+	// Create tmux client
+	client, err := tmuxctl.NewClient("")
+	if err != nil {
+		fatal("tmux not found: %v", err)
+	}
+	model, err := peakypanes.NewModel(client, peakypanes.Options{})
+	if err != nil {
+		fatal("failed to initialize: %v", err)
+	}
+
+	sessionName, err := resolveKillSession(query, model.FindProject)
+	var ambiguous *peakypanes.AmbiguousProjectError
+	if errors.As(err, &ambiguous) {
+		fmt.Fprintf(os.Stderr, "peakypanes: %q matches several projects:\n", query)
+		for _, c := range ambiguous.Candidates {
+			fmt.Fprintf(os.Stderr, "   • %s (session %s, %s)\n", c.Name, c.Session, c.Path)
+		}
+		os.Exit(1)
+	}
+	if err != nil {
+		fatal("%v", err)
+	}
+
+	// Check if session exists
+	ctx, cancel := context.WithTimeout(context.Background(), killTimeout)
+	sessions, err := client.ListSessions(ctx)
+	cancel()
+	if err != nil {
+		fatal("failed to list sessions: %v", err)
+	}
+
+	found := false
+	for _, s := range sessions {
+		if s == sessionName {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		fmt.Printf("❌ Session '%s' not found\n", sessionName)
+		if len(sessions) > 0 {
+			fmt.Printf("\n   Running sessions:\n")
+			for _, s := range sessions {
+				fmt.Printf("   • %s\n", s)
+			}
+		}
+		os.Exit(1)
+	}
+
+	confirm := func() (bool, error) { return confirmKill(sessionName, os.Stdin, os.Stdout) }
+	if yes {
+		confirm = nil
+	}
+	killed, err := killConfirmed(client, sessionName, confirm)
+	if err != nil {
+		fatal("%v", err)
+	}
+	if !killed {
+		fmt.Println("Cancelled")
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Killed session '%s'\n", sessionName)
+}
+
+// killTimeout bounds each tmux call of the kill command.
+var killTimeout = 5 * time.Second
+
+// killConfirmed kills session once confirm agrees; a nil confirm kills
+// right away. The kill gets its own timeout, so time spent answering the
+// prompt does not count against it.
+func killConfirmed(client *tmuxctl.Client, session string, confirm func() (bool, error)) (bool, error) {
+	if confirm != nil {
+		ok, err := confirm()
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), killTimeout)
+	defer cancel()
+	if err := client.KillSession(ctx, session); err != nil {
+		return false, fmt.Errorf("failed to kill session: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+	"github.com/kregenrek/tmuxman/internal/tui/peakypanes"
+)
+
+// TestResolveKillSession tests resolving projects by name, session or
+// alias and falling back to the query as a session name
+func TestResolveKillSession(t *testing.T) {
+	projects := map[string]peakypanes.Project{
+		"api":     {Name: "api", Session: "pp-api"},
+		"pp-api":  {Name: "api", Session: "pp-api"},
+		"backend": {Name: "api", Session: "pp-api"},
+	}
+	ambiguous := &peakypanes.AmbiguousProjectError{Query: "web"}
+	find := func(q string) (peakypanes.Project, error) {
+		if q == "web" {
+			return peakypanes.Project{}, ambiguous
+		}
+		if p, ok := projects[q]; ok {
+			return p, nil
+		}
+		return peakypanes.Project{}, peakypanes.ErrNoProjectMatch
+	}
+
+	for _, q := range []string{"api", "pp-api", "backend"} {
+		if got, err := resolveKillSession(q, find); err != nil || got != "pp-api" {
+			t.Errorf("resolveKillSession(%q) = %q, %v; want pp-api", q, got, err)
+		}
+	}
+	if got, err := resolveKillSession("scratch-2", find); err != nil || got != "scratch-2" {
+		t.Errorf("resolveKillSession(unconfigured) = %q, %v; want the query", got, err)
+	}
+	if _, err := resolveKillSession("web", find); !errors.Is(err, ambiguous) {
+		t.Errorf("resolveKillSession(ambiguous) error = %v, want the ambiguity", err)
+	}
+}
+
+// TestConfirmKill tests the prompt answers and the refusal without a
+// terminal
+func TestConfirmKill(t *testing.T) {
+	orig := isTerminal
+	defer func() { isTerminal = orig }()
+
+	answer := func(t *testing.T, input string) *os.File {
+		t.Helper()
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { r.Close() })
+		if _, err := w.WriteString(input); err != nil {
+			t.Fatal(err)
+		}
+		w.Close()
+		return r
+	}
+
+	isTerminal = func(*os.File) bool { return false }
+	var out bytes.Buffer
+	ok, err := confirmKill("api", answer(t, "y\n"), &out)
+	if ok || !errors.Is(err, errKillNotConfirmed) {
+		t.Errorf("confirmKill() without a tty = %v, %v; want a refusal", ok, err)
+	}
+	if !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("refusal %q should point at --yes", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("refusal prompted anyway: %q", out.String())
+	}
+
+	isTerminal = func(*os.File) bool { return true }
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		out.Reset()
+		ok, err := confirmKill("api", answer(t, tt.input), &out)
+		if err != nil || ok != tt.want {
+			t.Errorf("confirmKill(%q) = %v, %v; want %v", tt.input, ok, err, tt.want)
+		}
+		if !strings.Contains(out.String(), "Kill session 'api'?") {
+			t.Errorf("prompt = %q", out.String())
+		}
+	}
+}
+
+// TestKillConfirmedSlowAnswer tests that a confirmation answered after the
+// kill timeout still kills the session
+func TestKillConfirmedSlowAnswer(t *testing.T) {
+	old := killTimeout
+	killTimeout = 20 * time.Millisecond
+	t.Cleanup(func() { killTimeout = old })
+	mock := tmuxctl.NewMock()
+	client := tmuxctl.NewMockClient(mock)
+	for _, name := range []string{"api", "web"} {
+		if _, err := client.NewSessionWithCmd(context.Background(), name, "", "", ""); err != nil {
+			t.Fatalf("NewSessionWithCmd(%s) error: %v", name, err)
+		}
+	}
+
+	slow := func() (bool, error) {
+		time.Sleep(2 * killTimeout)
+		return true, nil
+	}
+	if killed, err := killConfirmed(client, "api", slow); !killed || err != nil {
+		t.Fatalf("killConfirmed(slow yes) = %v, %v, want killed", killed, err)
+	}
+	no := func() (bool, error) { return false, nil }
+	if killed, err := killConfirmed(client, "web", no); killed || err != nil {
+		t.Errorf("killConfirmed(no) = %v, %v, want cancelled", killed, err)
+	}
+	if want := []string{"web"}; !reflect.DeepEqual(mock.Sessions(), want) {
+		t.Errorf("sessions = %v, want %v", mock.Sessions(), want)
+	}
+	if _, err := killConfirmed(client, "missing", nil); err == nil {
+		t.Error("killConfirmed(missing) succeeded")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -13,8 +14,10 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/kregenrek/tmuxman/internal/layout"
+	"github.com/kregenrek/tmuxman/internal/state"
 	"github.com/kregenrek/tmuxman/internal/tmuxctl"
 	"github.com/kregenrek/tmuxman/internal/tui/peakypanes"
+	"github.com/mattn/go-isatty"
 )
 
 const version = "0.1.0"
@@ -24,27 +27,45 @@ const helpText = `🎩 Peaky Panes - Tmux Layout Manager
 Usage:
   peakypanes [command] [options]
 
+Options:
+  --reopen-last    Attach to the last opened session if it is still running
+  --glyphs <set>   Status icons: symbol, ascii or emoji (default: config)
+  --theme <name>   Colors: default, dracula, gruvbox or nord (default: config)
+  --debug          Show how long the last status refresh took
+  --exec           Replace peakypanes with the session opened from the list
+  --running        List only running sessions (S toggles the filter)
+  --no-tmux        Simulate sessions in memory instead of using tmux (demos)
+
 Commands:
-  (no command)     Open interactive project manager
+  (no command)     Open interactive project manager (lists projects when
+                   not run in a terminal)
+  list             Print configured projects and their status
   open             Start/attach session in current directory
   start            Start/attach session (same as open)
   kill             Kill a tmux session
+  status           Print a project's session status for scripts
   init             Initialize configuration
   layouts          List and manage layouts
   clone            Clone from GitHub and open
+  prune            Remove saved state for sessions that no longer exist
+  doctor           Check tmux, the config and creating sessions
   version          Show version
 
 Examples:
   peakypanes                          # Open project manager TUI
   peakypanes open                     # Start/attach session in current directory
+  peakypanes open api                 # Open the project named or aliased 'api'
   peakypanes open --layout dev-3      # Start with specific layout
   peakypanes kill                     # Kill session for current directory
   peakypanes kill myapp               # Kill specific session
+  peakypanes status api || peakypanes open api
+  peakypanes list | grep running      # Projects with a live session
   peakypanes init                     # Create global config
   peakypanes init --local             # Create .peakypanes.yml in current dir
   peakypanes layouts                  # List available layouts
   peakypanes layouts export dev-3     # Export layout YAML to stdout
   peakypanes clone user/repo          # Clone from GitHub and start session
+  peakypanes prune                    # Clean up stale saved layouts and history
 
 Run 'peakypanes <command> --help' for more information.
 `
@@ -61,7 +82,7 @@ Options:
   -h, --help           Show this help
 
 Examples:
-  peakypanes init                     # Create ~/.config/peakypanes/
+  peakypanes init                     # Create global config directory
   peakypanes init --local             # Create .peakypanes.yml here
   peakypanes init --local --layout tauri-debug
 `
@@ -74,6 +95,8 @@ Usage:
 Subcommands:
   (none)               List all available layouts
   export <name>        Print layout YAML to stdout
+  schema               Print the JSON schema for .json layouts
+  validate <file>      Check a layout file and report failing fields
   
 Options:
   -h, --help           Show this help
@@ -82,65 +105,159 @@ Examples:
   peakypanes layouts                  # List all layouts
   peakypanes layouts export dev-3     # Print dev-3 layout YAML
   peakypanes layouts export dev-3 > .peakypanes.yml
+  peakypanes layouts validate generated.json
 `
 
+// helpConfigPath returns the global config path for help texts.
+func helpConfigPath() string {
+	if path, err := layout.DefaultConfigPath(); err == nil {
+		return path
+	}
+	return "the global config.yml"
+}
+
 const startHelpText = `Start or attach to a tmux session.
 
 Usage:
-  peakypanes start [options]
+  peakypanes start [project] [options]
+
+Arguments:
+  project              Configured project to open, matched case-insensitively
+                       against its name, session and aliases. Anything that
+                       is not a project is treated as a layout name.
 
 Options:
   --layout <name>      Use specific layout (default: auto-detect)
-  --session <name>     Override session name (default: directory name)
+  --session <name>     Override session name (default: directory name,
+                       after session_prefix)
   --path <dir>         Project directory (default: current directory)
+  --run <command>      Run a command once after the session opens; a new
+                       session gets it in the first pane, an existing one in
+                       its active pane
+  -d, --detach         Create the session in the background without
+                       attaching or switching to it
+  -g, --group <name>   Join the session group of a running session, sharing
+                       its windows with an independent view (default: the
+                       project's group)
+  --dry-run            Print the tmux commands that would create the
+                       session instead of running them
   -h, --help           Show this help
 
 Layout Detection (in order):
   1. --layout flag
   2. .peakypanes.yml in project directory
-  3. Project entry in ~/.config/peakypanes/config.yml
+  3. Project entry in %s
   4. Builtin 'dev-3' layout
 
 Examples:
   peakypanes start                    # Auto-detect layout
+  peakypanes start api                # Open project 'API Gateway' via its alias
   peakypanes start --layout fullstack
   peakypanes start --session myapp --layout go-dev
+  peakypanes start --run "npm test"
+  peakypanes start api --detach       # Pre-warm 'api' in the background
+`
+
+const pruneHelpText = `Remove saved state for sessions that no longer exist.
+
+Saved window layouts and recently opened sessions are kept in the state
+file. Entries for sessions that are neither configured as a project nor
+currently running are removed.
+
+Usage:
+  peakypanes prune
+
+Options:
+  -h, --help           Show this help
 `
 
 const killHelpText = `Kill a tmux session.
 
 Usage:
-  peakypanes kill [session-name]
+  peakypanes kill [name] [--yes]
 
 Arguments:
-  session-name         Session to kill (default: current directory name)
+  name                 Project name, session or alias, or a session name
+                       (default: current directory name, after
+                       session_prefix)
 
 Options:
+  -y, --yes            Kill without asking; required without a terminal
   -h, --help           Show this help
 
+Exits with status 1 when the session is not running or the kill is not
+confirmed.
+
 Examples:
   peakypanes kill                     # Kill session for current directory
-  peakypanes kill myapp               # Kill session named 'myapp'
+  peakypanes kill myapp               # Kill the myapp project's session
+  peakypanes kill myapp --yes         # Kill without asking, e.g. in scripts
+`
+
+const statusHelpText = `Print the status of a configured project.
+
+Usage:
+  peakypanes status <project>
+
+Arguments:
+  project              Project to check, matched case-insensitively against
+                       its name, session and aliases
+
+Prints one of current, running, stopped or missing and exits with:
+  0                    Session is running (or current)
+  1                    Session is stopped
+  2                    Session is stopped and the project path is missing
+  3                    No single project matches
+  4                    Status could not be determined
+
+Options:
+  -h, --help           Show this help
+
+Examples:
+  peakypanes status api || peakypanes open api
+`
+
+const listHelpText = `Print configured projects and their session status.
+
+Usage:
+  peakypanes list [--no-tmux]
+
+This is also what plain 'peakypanes' prints when stdin or stdout is not a
+terminal, e.g. 'peakypanes | cat'.
+
+Options:
+  --no-tmux            List against simulated sessions instead of tmux
+  -h, --help           Show this help
 `
 
 func main() {
 	if len(os.Args) < 2 {
 		// Default: open project manager
-		runMenu()
+		runMenu(nil)
 		return
 	}
 
 	switch os.Args[1] {
-	case "open", "o", "start":
+	case "--reopen-last", "--glyphs", "--theme", "--debug", "--exec", "--running", "--no-tmux":
+		runMenu(os.Args[1:])
+	case "open", "o", "start", "--open":
 		runStart(os.Args[2:])
 	case "kill", "k":
 		runKill(os.Args[2:])
+	case "status":
+		runStatus(os.Args[2:])
+	case "list", "ls":
+		runList(os.Args[2:])
 	case "init":
 		runInit(os.Args[2:])
 	case "layouts":
 		runLayouts(os.Args[2:])
 	case "clone", "c":
 		runClone(os.Args[2:])
+	case "prune":
+		runPrune(os.Args[2:])
+	case "doctor":
+		runDoctor(os.Args[2:])
 	case "version", "-v", "--version":
 		fmt.Printf("peakypanes %s\n", version)
 	case "help", "-h", "--help":
@@ -155,21 +272,139 @@ func main() {
 	}
 }
 
-func runMenu() {
+func runMenu(args []string) {
+	var opts peakypanes.Options
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--reopen-last":
+			opts.ReopenLast = true
+		case "--debug":
+			opts.Debug = true
+		case "--exec":
+			opts.Exec = true
+		case "--running":
+			opts.Running = true
+		case "--no-tmux":
+			opts.NoTmux = true
+		case "--glyphs":
+			if i+1 < len(args) {
+				glyphs, err := peakypanes.ParseGlyphSet(args[i+1])
+				if err != nil {
+					fatal("%v", err)
+				}
+				opts.Glyphs = glyphs
+				i++
+			}
+		case "--theme":
+			if i+1 < len(args) {
+				opts.Theme = args[i+1]
+				i++
+			}
+		}
+	}
+
+	// Without a terminal there is no screen to take over
+	if !interactive(os.Stdin, os.Stdout) {
+		fmt.Fprintln(os.Stderr, "peakypanes: not a terminal; listing projects instead")
+		var listArgs []string
+		if opts.NoTmux {
+			listArgs = append(listArgs, "--no-tmux")
+		}
+		runList(listArgs)
+		return
+	}
+
+	client := newClient(opts.NoTmux)
+	model, err := peakypanes.NewModel(client, opts)
+	if err != nil {
+		fatal("failed to initialize: %v", err)
+	}
+
+	if session, ok := model.ReopenSession(); ok {
+		attachToSession(client, session)
+		return
+	}
+
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		fatal("TUI error: %v", err)
+	}
+	// In exec mode the chosen tmux client takes over this process
+	if m, ok := final.(peakypanes.Model); ok && len(m.ExecArgv()) > 0 {
+		argv := m.ExecArgv()
+		if err := peakypanes.Exec(argv); err != nil {
+			fatal("exec %s: %v", strings.Join(argv, " "), err)
+		}
+	}
+}
+
+// isTerminal reports whether f is a terminal. Tests replace it.
+var isTerminal = func(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// interactive reports whether the TUI can run: it reads keys from stdin
+// and draws on stdout, so both must be terminals.
+func interactive(stdin, stdout *os.File) bool {
+	return isTerminal(stdin) && isTerminal(stdout)
+}
+
+// runList prints the configured projects with their session status, one
+// per line.
+func runList(args []string) {
+	noTmux := false
+	for _, arg := range args {
+		switch arg {
+		case "-h", "--help":
+			fmt.Print(listHelpText)
+			return
+		case "--no-tmux":
+			noTmux = true
+		}
+	}
+
+	client := newClient(noTmux)
+	model, err := peakypanes.NewModel(client, peakypanes.Options{NoTmux: noTmux})
+	if err != nil {
+		fatal("failed to initialize: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSESSION\tSTATUS\tPATH")
+	for _, p := range model.Projects() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Name, p.Session, p.Status, p.Path)
+	}
+	w.Flush()
+}
+
+func runPrune(args []string) {
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			fmt.Print(pruneHelpText)
+			return
+		}
+	}
+
 	client, err := tmuxctl.NewClient("")
 	if err != nil {
 		fatal("tmux not found: %v", err)
 	}
 
-	model, err := peakypanes.NewModel(client)
+	model, err := peakypanes.NewModel(client, peakypanes.Options{})
 	if err != nil {
 		fatal("failed to initialize: %v", err)
 	}
 
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		fatal("TUI error: %v", err)
+	removed, err := model.PruneState()
+	if err != nil {
+		fatal("failed to prune state: %v", err)
+	}
+	if removed == 0 {
+		fmt.Println("✨ State is clean, nothing to prune")
+		return
 	}
+	fmt.Printf("🧹 Removed %d stale state entries\n", removed)
 }
 
 func runClone(args []string) {
@@ -357,47 +592,7 @@ func initGlobal(layoutName string, force bool) {
 		}
 	}
 
-	configContent := `# Peaky Panes - Global Configuration
-# https://github.com/kregenrek/peakypanes
-
-tmux:
-  config: ~/.config/tmux/tmux.conf
-
-ghostty:
-  config: ~/.config/ghostty/config
-
-# Load additional layouts from this directory
-layout_dirs:
-  - ~/.config/peakypanes/layouts
-
-# Define projects for quick access
-# projects:
-#   - name: my-project
-#     session: myproj
-#     path: ~/projects/my-project
-#     layout: dev-3
-#     vars:
-#       CUSTOM_VAR: value
-
-# Define custom layouts inline (or put in layouts/ directory)
-# layouts:
-#   my-custom:
-#     windows:
-#       - name: dev
-#         panes:
-#           - title: editor
-#             cmd: "${EDITOR:-}"
-#           - title: shell
-#             cmd: ""
-
-tools:
-  cursor_agent:
-    window_name: cursor
-    cmd: ""
-  codex_new:
-    window_name: codex
-    cmd: ""
-`
+	configContent := layout.ConfigTemplate(layoutsDir)
 
 	if err := os.WriteFile(configPath, []byte(configContent), 0o644); err != nil {
 		fatal("failed to write config: %v", err)
@@ -421,6 +616,15 @@ func runLayouts(args []string) {
 			}
 			exportLayout(args[1])
 			return
+		case "schema":
+			os.Stdout.Write(layout.LayoutSchema())
+			return
+		case "validate":
+			if len(args) < 2 {
+				fatal("usage: peakypanes layouts validate <file>")
+			}
+			validateLayout(args[1])
+			return
 		case "-h", "--help":
 			fmt.Print(layoutsHelpText)
 			return
@@ -500,79 +704,91 @@ func exportLayout(name string) {
 	fmt.Print(yaml)
 }
 
-func runKill(args []string) {
-	sessionName := ""
+func validateLayout(path string) {
+	l, err := layout.LoadLayoutFile(path)
+	var verr layout.ValidationError
+	if errors.As(err, &verr) {
+		for _, fe := range verr {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", path, fe.Error())
+		}
+		os.Exit(1)
+	}
+	if err != nil {
+		fatal("%v", err)
+	}
+	fmt.Printf("%s: ok (%s, %d windows)\n", path, l.Name, len(l.Windows))
+}
 
-	for i := 0; i < len(args); i++ {
-		switch args[i] {
+func runStatus(args []string) {
+	query := ""
+	for _, arg := range args {
+		switch arg {
 		case "-h", "--help":
-			fmt.Print(killHelpText)
+			fmt.Print(statusHelpText)
 			return
 		default:
-			if !strings.HasPrefix(args[i], "-") && sessionName == "" {
-				sessionName = args[i]
+			if !strings.HasPrefix(arg, "-") && query == "" {
+				query = arg
 			}
 		}
 	}
-
-	// Default session name to current directory name
-	if sessionName == "" {
-		cwd, err := os.Getwd()
-		if err != nil {
-			fatal("cannot determine current directory: %v", err)
-		}
-		sessionName = sanitizeSessionName(filepath.Base(cwd))
+	if query == "" {
+		fmt.Fprintln(os.Stderr, "usage: peakypanes status <project>")
+		os.Exit(peakypanes.ExitUnknownProject)
 	}
 
-	// Create tmux client
 	client, err := tmuxctl.NewClient("")
 	if err != nil {
-		fatal("tmux not found: %v", err)
+		fmt.Fprintf(os.Stderr, "peakypanes: tmux not found: %v\n", err)
+		os.Exit(peakypanes.ExitStatusError)
 	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	// Check if session exists
-	sessions, err := client.ListSessions(ctx)
+	model, err := peakypanes.NewModel(client, peakypanes.Options{})
 	if err != nil {
-		fatal("failed to list sessions: %v", err)
-	}
-
-	found := false
-	for _, s := range sessions {
-		if s == sessionName {
-			found = true
-			break
-		}
+		fmt.Fprintf(os.Stderr, "peakypanes: failed to initialize: %v\n", err)
+		os.Exit(peakypanes.ExitStatusError)
 	}
 
-	if !found {
-		fmt.Printf("❌ Session '%s' not found\n", sessionName)
-		if len(sessions) > 0 {
-			fmt.Printf("\n   Running sessions:\n")
-			for _, s := range sessions {
-				fmt.Printf("   • %s\n", s)
-			}
-		}
-		return
+	status, err := model.ProjectStatus(query)
+	if errors.Is(err, peakypanes.ErrNoProjectMatch) {
+		fmt.Fprintf(os.Stderr, "peakypanes: no project matches %q\n", query)
+		os.Exit(peakypanes.ExitUnknownProject)
 	}
-
-	// Kill the session
-	if err := client.KillSession(ctx, sessionName); err != nil {
-		fatal("failed to kill session: %v", err)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "peakypanes: %v\n", err)
+		os.Exit(peakypanes.ExitUnknownProject)
 	}
-
-	fmt.Printf("✅ Killed session '%s'\n", sessionName)
+	fmt.Println(status)
+	os.Exit(status.ExitCode())
 }
 
 func runStart(args []string) {
 	layoutName := ""
 	sessionName := ""
 	projectPath := ""
+	runCommand := ""
+	target := ""
+	group := ""
+	logFile := ""
+	var options map[string]string
+	dryRun := false
+	detach := false
 
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
+		case "--run":
+			if i+1 < len(args) {
+				runCommand = args[i+1]
+				i++
+			}
+		case "--detach", "-d":
+			detach = true
+		case "--dry-run":
+			dryRun = true
+		case "--group", "-g":
+			if i+1 < len(args) {
+				group = args[i+1]
+				i++
+			}
 		case "--layout", "-l":
 			if i+1 < len(args) {
 				layoutName = args[i+1]
@@ -589,16 +805,38 @@ func runStart(args []string) {
 				i++
 			}
 		case "-h", "--help":
-			fmt.Print(startHelpText)
+			fmt.Printf(startHelpText, helpConfigPath())
 			return
 		default:
-			// Treat as layout name shortcut if not a flag
-			if !strings.HasPrefix(args[i], "-") && layoutName == "" {
-				layoutName = args[i]
+			// A project name, alias or layout name shortcut
+			if !strings.HasPrefix(args[i], "-") && target == "" {
+				target = args[i]
 			}
 		}
 	}
 
+	if target != "" {
+		p, found := findProject(target)
+		if found {
+			if projectPath == "" {
+				projectPath = p.Path
+			}
+			if sessionName == "" {
+				sessionName = p.Session
+			}
+			if layoutName == "" {
+				layoutName = p.Layout
+			}
+			if group == "" {
+				group = p.Group
+			}
+			logFile = p.LogFile
+			options = p.Options
+		} else if layoutName == "" {
+			layoutName = target
+		}
+	}
+
 	// Default to current directory
 	if projectPath == "" {
 		var err error
@@ -610,7 +848,7 @@ func runStart(args []string) {
 
 	// Default session name to directory name
 	if sessionName == "" {
-		sessionName = sanitizeSessionName(filepath.Base(projectPath))
+		sessionName = defaultSessionName(projectPath)
 	}
 
 	// Load layouts
@@ -625,30 +863,18 @@ func runStart(args []string) {
 	}
 
 	// Determine which layout to use
-	var selectedLayout *layout.LayoutConfig
-	var source string
-
-	if layoutName != "" {
-		// Explicit layout requested
-		selectedLayout, source, err = loader.GetLayout(layoutName)
-		if err != nil {
+	selectedLayout, source, err := loader.ResolveLayout(layoutName)
+	if err != nil {
+		if layoutName != "" {
 			fatal("layout %q not found. Run 'peakypanes layouts' to see available layouts.", layoutName)
 		}
-	} else if loader.HasProjectConfig() {
-		// Use project-local config
-		selectedLayout = loader.GetProjectLayout()
-		source = "project"
-		if selectedLayout == nil {
-			// Project config exists but no layout defined, use default
-			selectedLayout, source, _ = loader.GetLayout("dev-3")
-		}
-	} else {
-		// Fall back to default
-		selectedLayout, source, _ = loader.GetLayout("dev-3")
+		fatal("no layout found")
 	}
 
-	if selectedLayout == nil {
-		fatal("no layout found")
+	if dryRun {
+		planned := layout.SessionLayout(selectedLayout, projectPath, logFile)
+		fmt.Print(tmuxctl.Script(tmuxctl.CreatePlan(sessionName, projectPath, planned, loader.DefaultPaneCommand(), options)))
+		return
 	}
 
 	// Create tmux client
@@ -684,110 +910,172 @@ func runStart(args []string) {
 	projectName := filepath.Base(projectPath)
 	expandedLayout := layout.ExpandLayoutVars(selectedLayout, nil, projectPath, projectName)
 
+	if sessionExists && detach {
+		fmt.Printf("   Session already running\n")
+		runOnce(ctx, client, tmuxctl.ActivePaneTarget(sessionName), runCommand)
+		return
+	}
 	if sessionExists {
 		fmt.Printf("   Session already exists, attaching...\n\n")
+		runOnce(ctx, client, tmuxctl.ActivePaneTarget(sessionName), runCommand)
 		attachToSession(client, sessionName)
 		return
 	}
 
+	// Join a running session group; its windows are shared, so no layout
+	// is created
+	if group != "" && group != sessionName {
+		groups, _ := client.SessionGroups(ctx)
+		if tmuxctl.GroupExists(group, groups, sessions) {
+			if err := client.NewGroupedSession(ctx, sessionName, group); err != nil {
+				fatal("failed to join group %s: %v", group, err)
+			}
+			fmt.Printf("   ✅ Joined session group %s\n\n", group)
+			runOnce(ctx, client, tmuxctl.ActivePaneTarget(sessionName), runCommand)
+			if detach {
+				fmt.Printf("   Running in background. Attach with: peakypanes open %s\n", sessionName)
+				return
+			}
+			attachToSession(client, sessionName)
+			return
+		}
+		fmt.Printf("   Group %s is not running; creating a new session\n\n", group)
+	}
+
+	if logFile != "" {
+		expandedLayout = withLogPane(expandedLayout, layout.LogFilePath(logFile, projectPath))
+	}
+
 	// Create the session with layout
 	fmt.Println("   Creating windows:")
-	if err := createSessionWithLayout(ctx, client, sessionName, projectPath, expandedLayout); err != nil {
+	if err := createSessionWithLayout(ctx, client, sessionName, projectPath, expandedLayout, loader.DefaultPaneCommand()); err != nil {
 		fatal("failed to create session: %v", err)
 	}
+	setProjectOptions(ctx, client, sessionName, options)
+	restoreSavedLayouts(ctx, client, sessionName)
+	runOnce(ctx, client, tmuxctl.FirstPaneTarget(sessionName), runCommand)
 
 	fmt.Println()
 	fmt.Printf("   ✅ Session created!\n\n")
 
+	if detach {
+		fmt.Printf("   Running in background. Attach with: peakypanes open %s\n", sessionName)
+		return
+	}
+
 	// Attach to session
 	attachToSession(client, sessionName)
 }
 
-func createSessionWithLayout(ctx context.Context, client *tmuxctl.Client, session, projectPath string, layoutCfg *layout.LayoutConfig) error {
-	if len(layoutCfg.Windows) == 0 {
-		return fmt.Errorf("layout has no windows defined")
+// findProject looks up a configured project by name, session or alias.
+// Ambiguous queries list the candidates and exit.
+func findProject(query string) (peakypanes.Project, bool) {
+	client, err := tmuxctl.NewClient("")
+	if err != nil {
+		fatal("tmux not found: %v", err)
+	}
+	model, err := peakypanes.NewModel(client, peakypanes.Options{})
+	if err != nil {
+		fatal("failed to initialize: %v", err)
 	}
 
-	// Create first window with session
-	firstWindow := layoutCfg.Windows[0]
-	firstCmd := ""
-	if len(firstWindow.Panes) > 0 && firstWindow.Panes[0].Cmd != "" {
-		firstCmd = firstWindow.Panes[0].Cmd
+	p, err := model.FindProject(query)
+	var ambiguous *peakypanes.AmbiguousProjectError
+	switch {
+	case err == nil:
+		return p, true
+	case errors.As(err, &ambiguous):
+		fmt.Fprintf(os.Stderr, "peakypanes: %q matches several projects:\n", query)
+		for _, c := range ambiguous.Candidates {
+			fmt.Fprintf(os.Stderr, "   • %s (session %s, %s)\n", c.Name, c.Session, c.Path)
+		}
+		os.Exit(1)
 	}
+	return peakypanes.Project{}, false
+}
 
-	firstPaneID, err := client.NewSessionWithCmd(ctx, session, projectPath, firstWindow.Name, firstCmd)
-	if err != nil {
-		return fmt.Errorf("create session: %w", err)
+// withLogPane adds a pane following logFile to the first window of l. A
+// missing file is only a warning: the app may create it once it runs.
+func withLogPane(l *layout.LayoutConfig, logFile string) *layout.LayoutConfig {
+	_, err := os.Stat(logFile)
+	exists := err == nil
+	if !exists {
+		fmt.Printf("   ⚠ Log file %s does not exist yet; tailing it anyway\n", logFile)
 	}
+	return l.WithLogPane(layout.TailCommand(logFile, exists))
+}
 
-	// Apply peakypanes default tmux options (session-scoped, not global)
-	// remain-on-exit: off lets panes close normally when commands exit
-	_ = client.SetOption(ctx, session, "remain-on-exit", "off")
+// setProjectOptions applies a project's tmux options to its new session,
+// after the layout's so the project wins. Unknown option names are skipped
+// with a warning.
+func setProjectOptions(ctx context.Context, client *tmuxctl.Client, session string, options map[string]string) {
+	for _, name := range tmuxctl.UnknownOptions(options) {
+		fmt.Printf("   ⚠ Unknown tmux option %q; skipped\n", name)
+	}
+	if err := client.SetOptions(ctx, session, options); err != nil {
+		fmt.Printf("   ⚠ %v\n", err)
+	}
+}
 
-	// Apply custom tmux options from layout config
-	for option, value := range layoutCfg.Settings.TmuxOptions {
-		_ = client.SetOption(ctx, session, option, value)
+// runOnce types command into the pane at target. A failure is reported but
+// does not prevent attaching.
+func runOnce(ctx context.Context, client *tmuxctl.Client, target, command string) {
+	if command == "" {
+		return
 	}
+	if err := client.RunOnce(ctx, target, command); err != nil {
+		fmt.Printf("   ⚠ Run %q: %v\n", command, err)
+	}
+}
 
-	// Set title for first pane
-	if len(firstWindow.Panes) > 0 && firstWindow.Panes[0].Title != "" {
-		_ = client.SelectPane(ctx, firstPaneID, firstWindow.Panes[0].Title)
+func createSessionWithLayout(ctx context.Context, client *tmuxctl.Client, session, projectPath string, layoutCfg *layout.LayoutConfig, defaultPaneCmd string) error {
+	if len(layoutCfg.Windows) == 0 {
+		return fmt.Errorf("layout has no windows defined")
+	}
+	stepDelay, err := layoutCfg.Settings.StepDelayDuration()
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("   • %s ", firstWindow.Name)
+	// Panes are created empty; their commands are typed in afterwards as
+	// ordered steps so they cannot race each other. paneIDs[w][p] is the
+	// tmux pane ID of layoutCfg.Windows[w].Panes[p].
+	paneIDs := make([][]string, len(layoutCfg.Windows))
 
-	// Create additional panes in first window
-	currentPaneID := firstPaneID
-	for i := 1; i < len(firstWindow.Panes); i++ {
-		pane := firstWindow.Panes[i]
-		vertical := pane.Split == "vertical" || pane.Split == "v"
-		
-		// Parse size percentage
-		percent := 0
-		if pane.Size != "" {
-			sizeStr := strings.TrimSuffix(pane.Size, "%")
-			if p, err := strconv.Atoi(sizeStr); err == nil {
-				percent = p
+	// Warn when percentage splits leave panes too small for this client.
+	// The size is only known when running inside tmux.
+	if size, err := client.ClientSize(ctx); err == nil {
+		for _, win := range layoutCfg.Windows {
+			for _, warning := range tmuxctl.PaneSizeWarnings(win, size) {
+				fmt.Printf("   ⚠ %s\n", warning)
 			}
 		}
-
-		newPaneID, err := client.SplitWindowWithCmd(ctx, currentPaneID, projectPath, vertical, percent, pane.Cmd)
-		if err != nil {
-			return fmt.Errorf("split pane: %w", err)
-		}
-
-		if pane.Title != "" {
-			_ = client.SelectPane(ctx, newPaneID, pane.Title)
-		}
-
-		currentPaneID = newPaneID
 	}
 
-	fmt.Printf("(%d panes)\n", len(firstWindow.Panes))
-
-	// Apply layout if specified (after all panes are created)
-	if firstWindow.Layout != "" {
-		windowTarget := fmt.Sprintf("%s:%s", session, firstWindow.Name)
-		if err := client.SelectLayout(ctx, windowTarget, firstWindow.Layout); err != nil {
-			fmt.Printf("   ⚠ Layout %s: %v\n", firstWindow.Layout, err)
-		}
+	// Create first window with session
+	firstWindow := layoutCfg.Windows[0]
+	firstPaneID, err := client.NewSessionWithCmd(ctx, session, projectPath, firstWindow.Name, "")
+	if err != nil {
+		return fmt.Errorf("create session: %w", err)
 	}
 
-	// Create additional windows
-	for _, win := range layoutCfg.Windows[1:] {
-		firstCmd := ""
-		if len(win.Panes) > 0 && win.Panes[0].Cmd != "" {
-			firstCmd = win.Panes[0].Cmd
-		}
+	// Apply peakypanes default tmux options (session-scoped, not global)
+	// remain-on-exit: off lets panes close normally when commands exit
+	_ = client.SetOption(ctx, session, "remain-on-exit", "off")
 
-		firstPaneID, err := client.NewWindowWithCmd(ctx, session, win.Name, projectPath, firstCmd)
-		if err != nil {
-			return fmt.Errorf("create window %s: %w", win.Name, err)
-		}
+	// Apply custom tmux options from layout config
+	for option, value := range layoutCfg.Settings.TmuxOptions {
+		_ = client.SetOption(ctx, session, option, value)
+	}
 
-		if len(win.Panes) > 0 && win.Panes[0].Title != "" {
-			_ = client.SelectPane(ctx, firstPaneID, win.Panes[0].Title)
+	for wi, win := range layoutCfg.Windows {
+		if wi > 0 {
+			firstPaneID, err = client.NewWindowWithCmd(ctx, session, win.Name, projectPath, "")
+			if err != nil {
+				return fmt.Errorf("create window %s: %w", win.Name, err)
+			}
 		}
+		paneIDs[wi] = append(paneIDs[wi], firstPaneID)
 
 		fmt.Printf("   • %s ", win.Name)
 
@@ -797,6 +1085,7 @@ func createSessionWithLayout(ctx context.Context, client *tmuxctl.Client, sessio
 			pane := win.Panes[i]
 			vertical := pane.Split == "vertical" || pane.Split == "v"
 
+			// Parse size percentage
 			percent := 0
 			if pane.Size != "" {
 				sizeStr := strings.TrimSuffix(pane.Size, "%")
@@ -805,40 +1094,84 @@ func createSessionWithLayout(ctx context.Context, client *tmuxctl.Client, sessio
 				}
 			}
 
-			newPaneID, err := client.SplitWindowWithCmd(ctx, currentPaneID, projectPath, vertical, percent, pane.Cmd)
+			newPaneID, err := client.SplitWindowWithCmd(ctx, currentPaneID, projectPath, vertical, percent, "")
 			if err != nil {
 				return fmt.Errorf("split pane in %s: %w", win.Name, err)
 			}
-
-			if pane.Title != "" {
-				_ = client.SelectPane(ctx, newPaneID, pane.Title)
-			}
+			paneIDs[wi] = append(paneIDs[wi], newPaneID)
 
 			currentPaneID = newPaneID
 		}
 
-		// Apply layout if specified
+		fmt.Printf("(%d panes)\n", len(win.Panes))
+
+		// Apply layout if specified (after all panes are created)
 		if win.Layout != "" {
 			windowTarget := fmt.Sprintf("%s:%s", session, win.Name)
-			_ = client.SelectLayout(ctx, windowTarget, win.Layout)
+			if err := client.SelectLayout(ctx, windowTarget, win.Layout); err != nil {
+				fmt.Printf("   ⚠ Layout %s: %v\n", win.Layout, err)
+			}
+		}
+	}
+
+	// Label panes now that all of them exist
+	for _, title := range tmuxctl.PaneTitles(layoutCfg, paneIDs) {
+		if err := client.SelectPane(ctx, title.Target, title.Title); err != nil {
+			fmt.Printf("   ⚠ Pane title %s: %v\n", title.Title, err)
 		}
+	}
 
-		fmt.Printf("(%d panes)\n", len(win.Panes))
+	// Run pane commands in order, after the default pane command
+	var steps []tmuxctl.SendStep
+	for _, step := range layoutCfg.StepsWithDefault(defaultPaneCmd) {
+		steps = append(steps, tmuxctl.SendStep{Target: paneIDs[step.Window][step.Pane], Command: step.Cmd})
+	}
+	if err := client.RunSteps(ctx, steps, stepDelay); err != nil {
+		fmt.Printf("   ⚠ Commands: %v\n", err)
 	}
 
 	// Select first window and first pane
-	if len(layoutCfg.Windows) > 0 {
-		windowTarget := fmt.Sprintf("%s:%s", session, layoutCfg.Windows[0].Name)
-		// Select window
-		_ = exec.CommandContext(ctx, "tmux", "select-window", "-t", windowTarget).Run()
-		// Select first pane
-		_ = exec.CommandContext(ctx, "tmux", "select-pane", "-t", windowTarget+".0").Run()
-	}
+	windowTarget := fmt.Sprintf("%s:%s", session, firstWindow.Name)
+	_ = exec.CommandContext(ctx, "tmux", "select-window", "-t", windowTarget).Run()
+	_ = exec.CommandContext(ctx, "tmux", "select-pane", "-t", paneIDs[0][0]).Run()
 
 	return nil
 }
 
+// recordRecent marks session as most recently opened for --reopen-last.
+func recordRecent(session string) {
+	statePath, err := state.DefaultPath()
+	if err != nil {
+		return
+	}
+	_ = state.Update(statePath, func(s *state.State) { s.Touch(session) })
+}
+
+// restoreSavedLayouts replays window layouts captured when the session was
+// last killed, if the project opted into layout snapshots.
+func restoreSavedLayouts(ctx context.Context, client *tmuxctl.Client, session string) {
+	statePath, err := state.DefaultPath()
+	if err != nil {
+		return
+	}
+	st, err := state.Load(statePath)
+	if err != nil || len(st.Layouts[session]) == 0 {
+		return
+	}
+	var layouts []tmuxctl.WindowLayout
+	for _, l := range st.Layouts[session] {
+		layouts = append(layouts, tmuxctl.WindowLayout{Name: l.Name, Layout: l.Layout})
+	}
+	if err := client.RestoreWindowLayouts(ctx, session, layouts); err != nil {
+		fmt.Printf("   ⚠ %v\n", err)
+		return
+	}
+	fmt.Printf("   • restored saved layout\n")
+}
+
 func attachToSession(client *tmuxctl.Client, session string) {
+	recordRecent(session)
+
 	// Check if we're inside tmux
 	if os.Getenv("TMUX") != "" {
 		// Switch client
@@ -861,33 +1194,27 @@ func attachToSession(client *tmuxctl.Client, session string) {
 	}
 }
 
-func sanitizeSessionName(name string) string {
-	name = strings.ToLower(strings.TrimSpace(name))
-	if name == "" {
-		return "session"
-	}
-	var b strings.Builder
-	lastDash := false
-	for _, r := range name {
-		switch {
-		case r >= 'a' && r <= 'z':
-			b.WriteRune(r)
-			lastDash = false
-		case r >= '0' && r <= '9':
-			b.WriteRune(r)
-			lastDash = false
-		case r == '-' || r == '_' || r == ' ':
-			if !lastDash {
-				b.WriteRune('-')
-				lastDash = true
-			}
-		}
+// newClient returns the tmux client, or one backed by an in-memory mock
+// with --no-tmux.
+func newClient(noTmux bool) *tmuxctl.Client {
+	if noTmux {
+		return tmuxctl.NewMockClient(tmuxctl.NewMock())
 	}
-	result := strings.Trim(b.String(), "-")
-	if result == "" {
-		return "session"
+	client, err := tmuxctl.NewClient("")
+	if err != nil {
+		fatal("tmux not found: %v", err)
+	}
+	return client
+}
+
+// defaultSessionName names the session of an unconfigured project in dir
+// like the TUI does, with the configured session_prefix.
+func defaultSessionName(dir string) string {
+	configPath, err := layout.DefaultConfigPath()
+	if err != nil {
+		configPath = ""
 	}
-	return result
+	return peakypanes.DefaultSessionName(configPath, dir)
 }
 
 func fatal(format string, args ...interface{}) {
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+// TestInteractive tests that the TUI only runs when both stdin and stdout
+// are terminals
+func TestInteractive(t *testing.T) {
+	in, out, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	defer out.Close()
+	if interactive(in, out) {
+		t.Error("interactive() = true for pipes, want the non-interactive list")
+	}
+
+	orig := isTerminal
+	defer func() { isTerminal = orig }()
+	tests := []struct {
+		name      string
+		terminals map[*os.File]bool
+		want      bool
+	}{
+		{"terminal", map[*os.File]bool{in: true, out: true}, true},
+		{"stdout piped", map[*os.File]bool{in: true}, false},
+		{"stdin piped", map[*os.File]bool{out: true}, false},
+	}
+	for _, tt := range tests {
+		isTerminal = func(f *os.File) bool { return tt.terminals[f] }
+		if got := interactive(in, out); got != tt.want {
+			t.Errorf("%s: interactive() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestDefaultSessionName tests that sessions of unconfigured directories
+// get the configured session_prefix, like in the TUI
+func TestDefaultSessionName(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := filepath.Join(t.TempDir(), "My App")
+	if got := defaultSessionName(dir); got != "my-app" {
+		t.Errorf("defaultSessionName() without config = %q, want my-app", got)
+	}
+
+	configPath, err := layout.DefaultConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{config: "session_prefix: pp-\n", want: "pp-my-app"},
+		{config: "session_prefix: \"pp:\"\n", want: "my-app"},
+	}
+	for _, tt := range tests {
+		if err := os.WriteFile(configPath, []byte(tt.config), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if got := defaultSessionName(dir); got != tt.want {
+			t.Errorf("defaultSessionName() with %q = %q, want %q", tt.config, got, tt.want)
+		}
+	}
+}
+
+// TestRunListNoTmux tests that list --no-tmux, also used by a piped
+// peakypanes --no-tmux, runs without tmux
+func TestRunListNoTmux(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	configPath, err := layout.DefaultConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	config := "projects:\n  - name: api\n    path: " + t.TempDir() + "\n"
+	if err := os.WriteFile(configPath, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", "") // tmux must not be looked up or run
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	runList([]string{"--no-tmux"})
+	os.Stdout = stdout
+	w.Close()
+	out, _ := io.ReadAll(r)
+	if !strings.Contains(string(out), "api") || !strings.Contains(string(out), "stopped") {
+		t.Errorf("list --no-tmux printed:\n%s", out)
+	}
+}
+
+// TestHelpConfigPath tests that help texts name the resolved config path
+func TestHelpConfigPath(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	want, err := layout.DefaultConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := helpConfigPath(); got != want {
+		t.Errorf("helpConfigPath() = %q, want %q", got, want)
+	}
+	if !strings.Contains(fmt.Sprintf(startHelpText, helpConfigPath()), "Project entry in "+want+"\n") {
+		t.Errorf("start help does not name %s", want)
+	}
+}
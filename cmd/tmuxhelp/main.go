@@ -1,8 +1,10 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -10,7 +12,15 @@ import (
 )
 
 func main() {
-	m := ghosttyhelp.NewModel()
+	term := flag.String("term", "", "terminal shortcut table to show ("+strings.Join(ghosttyhelp.Terminals(), ", ")+"); defaults to $PEAKYPANES_TERM, then ghostty")
+	flag.Parse()
+
+	terminal, err := ghosttyhelp.ResolveTerminal(*term, os.Getenv("PEAKYPANES_TERM"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tmuxhelp: %v; showing %s\n", err, terminal)
+	}
+
+	m := ghosttyhelp.NewModelFor(terminal)
 	p := tea.NewProgram(m,
 		tea.WithAltScreen(),
 		tea.WithoutBracketedPaste(), // Reduces initial setup overhead
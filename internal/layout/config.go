@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -12,42 +13,44 @@ import (
 
 // PaneDef defines a single pane within a window.
 type PaneDef struct {
-	Title   string   `yaml:"title,omitempty"`
-	Cmd     string   `yaml:"cmd,omitempty"`
-	Size    string   `yaml:"size,omitempty"`    // e.g., "50%", "30"
-	Split   string   `yaml:"split,omitempty"`   // "horizontal" or "vertical"
-	Setup   []string `yaml:"setup,omitempty"`   // commands to run before main cmd
-	Enabled string   `yaml:"enabled,omitempty"` // expression like "${VAR:-true}"
+	Title   string   `yaml:"title,omitempty" json:"title,omitempty"`
+	Cmd     string   `yaml:"cmd,omitempty" json:"cmd,omitempty"`
+	Size    string   `yaml:"size,omitempty" json:"size,omitempty"`       // e.g., "50%", "30"
+	Split   string   `yaml:"split,omitempty" json:"split,omitempty"`     // "horizontal" or "vertical"
+	Setup   []string `yaml:"setup,omitempty" json:"setup,omitempty"`     // commands to run before main cmd
+	Enabled string   `yaml:"enabled,omitempty" json:"enabled,omitempty"` // expression like "${VAR:-true}"
+	Order   int      `yaml:"order,omitempty" json:"order,omitempty"`     // run commands before panes with a higher order
 }
 
 // WindowDef defines a window (tab) with its panes.
 type WindowDef struct {
-	Name   string    `yaml:"name"`
-	Layout string    `yaml:"layout,omitempty"` // tiled, even-horizontal, even-vertical, main-horizontal, main-vertical
-	Panes  []PaneDef `yaml:"panes"`
+	Name   string    `yaml:"name" json:"name"`
+	Layout string    `yaml:"layout,omitempty" json:"layout,omitempty"` // tiled, even-horizontal, even-vertical, main-horizontal, main-vertical
+	Panes  []PaneDef `yaml:"panes" json:"panes"`
 }
 
 // LayoutSettings contains optional layout configuration.
 type LayoutSettings struct {
-	Width       int               `yaml:"width,omitempty"`
-	Height      int               `yaml:"height,omitempty"`
-	BindKeys    []KeyBind         `yaml:"bind_keys,omitempty"`
-	TmuxOptions map[string]string `yaml:"tmux_options,omitempty"` // session-scoped tmux options
+	Width       int               `yaml:"width,omitempty" json:"width,omitempty"`
+	Height      int               `yaml:"height,omitempty" json:"height,omitempty"`
+	BindKeys    []KeyBind         `yaml:"bind_keys,omitempty" json:"bind_keys,omitempty"`
+	TmuxOptions map[string]string `yaml:"tmux_options,omitempty" json:"tmux_options,omitempty"` // session-scoped tmux options
+	StepDelay   string            `yaml:"step_delay,omitempty" json:"step_delay,omitempty"`     // pause between pane commands, e.g. "300ms"
 }
 
 // KeyBind defines a tmux key binding.
 type KeyBind struct {
-	Key    string `yaml:"key"`
-	Action string `yaml:"action"`
+	Key    string `yaml:"key" json:"key"`
+	Action string `yaml:"action" json:"action"`
 }
 
 // LayoutConfig represents a complete layout definition.
 type LayoutConfig struct {
-	Name        string            `yaml:"name,omitempty"`
-	Description string            `yaml:"description,omitempty"`
-	Vars        map[string]string `yaml:"vars,omitempty"`
-	Settings    LayoutSettings    `yaml:"settings,omitempty"`
-	Windows     []WindowDef       `yaml:"windows"`
+	Name        string            `yaml:"name,omitempty" json:"name,omitempty"`
+	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Vars        map[string]string `yaml:"vars,omitempty" json:"vars,omitempty"`
+	Settings    LayoutSettings    `yaml:"settings,omitempty" json:"settings,omitempty"`
+	Windows     []WindowDef       `yaml:"windows" json:"windows"`
 }
 
 // ProjectConfig represents a project entry in the config file.
@@ -91,6 +94,9 @@ type Config struct {
 	Layouts    map[string]*LayoutConfig `yaml:"layouts,omitempty"`
 	Projects   []ProjectConfig          `yaml:"projects,omitempty"`
 	Tools      ToolsConfig              `yaml:"tools,omitempty"`
+	// DefaultPaneCommand is typed into every pane of every layout before
+	// the pane's own commands, e.g. "clear".
+	DefaultPaneCommand string `yaml:"default_pane_command,omitempty"`
 }
 
 // ProjectLocalConfig is the schema for .peakypanes.yml in project directories.
@@ -149,12 +155,20 @@ func LoadProjectLocal(dir string) (*ProjectLocalConfig, error) {
 	return &cfg, nil
 }
 
-// LoadLayoutFile reads a standalone layout YAML file.
+// LoadLayoutFile reads a standalone layout file. Files ending in .json are
+// parsed with ParseLayoutJSON, anything else as YAML.
 func LoadLayoutFile(path string) (*LayoutConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("read layout %q: %w", path, err)
 	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		layout, err := ParseLayoutJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse layout %q: %w", path, err)
+		}
+		return layout, nil
+	}
 	var layout LayoutConfig
 	if err := yaml.Unmarshal(data, &layout); err != nil {
 		return nil, fmt.Errorf("parse layout %q: %w", path, err)
@@ -241,6 +255,7 @@ func ExpandLayoutVars(layout *LayoutConfig, extraVars map[string]string, project
 				Size:    pane.Size,
 				Split:   pane.Split,
 				Enabled: pane.Enabled,
+				Order:   pane.Order,
 			}
 			for _, setup := range pane.Setup {
 				expandedPane.Setup = append(expandedPane.Setup, ExpandVars(setup, vars, projectPath, projectName))
@@ -262,20 +277,74 @@ func (l *LayoutConfig) ToYAML() (string, error) {
 	return string(data), nil
 }
 
+// goos reports the operating system used for config dir resolution.
+// Tests override it to exercise other platforms.
+var goos = runtime.GOOS
+
+// ConfigDir returns the Peaky Panes configuration directory for the current
+// platform:
+//   - Linux/BSD: $XDG_CONFIG_HOME/peakypanes
+//   - macOS:     ~/Library/Application Support/peakypanes
+//   - Windows:   %AppData%\peakypanes
+//
+// An existing ~/.config/peakypanes is always preferred so configs created by
+// earlier versions keep working, and it is also the fallback when the
+// platform location cannot be determined.
+func ConfigDir() (string, error) {
+	return configDirFor(goos, os.Getenv, os.UserHomeDir)
+}
+
+func configDirFor(goos string, getenv func(string) string, userHome func() (string, error)) (string, error) {
+	home, homeErr := userHome()
+	legacy := ""
+	if homeErr == nil {
+		legacy = filepath.Join(home, ".config", "peakypanes")
+	}
+
+	var platformDir string
+	switch goos {
+	case "windows":
+		if appData := getenv("AppData"); appData != "" {
+			platformDir = filepath.Join(appData, "peakypanes")
+		}
+	case "darwin":
+		if homeErr == nil {
+			platformDir = filepath.Join(home, "Library", "Application Support", "peakypanes")
+		}
+	default:
+		if xdg := getenv("XDG_CONFIG_HOME"); xdg != "" {
+			platformDir = filepath.Join(xdg, "peakypanes")
+		}
+	}
+
+	if legacy != "" && platformDir != legacy {
+		if info, err := os.Stat(legacy); err == nil && info.IsDir() {
+			return legacy, nil
+		}
+	}
+	if platformDir != "" {
+		return platformDir, nil
+	}
+	if homeErr != nil {
+		return "", homeErr
+	}
+	return legacy, nil
+}
+
 // DefaultConfigPath returns the default global config path.
 func DefaultConfigPath() (string, error) {
-	home, err := os.UserHomeDir()
+	dir, err := ConfigDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".config", "peakypanes", "config.yml"), nil
+	return filepath.Join(dir, "config.yml"), nil
 }
 
 // DefaultLayoutsDir returns the default layouts directory.
 func DefaultLayoutsDir() (string, error) {
-	home, err := os.UserHomeDir()
+	dir, err := ConfigDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".config", "peakypanes", "layouts"), nil
+	return filepath.Join(dir, "layouts"), nil
 }
@@ -0,0 +1,93 @@
+package layout
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigDirFor(t *testing.T) {
+	home := t.TempDir()
+	userHome := func() (string, error) { return home, nil }
+
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+		want string
+	}{
+		{name: "linux xdg", goos: "linux", env: map[string]string{"XDG_CONFIG_HOME": "/xdg"}, want: filepath.Join("/xdg", "peakypanes")},
+		{name: "linux fallback", goos: "linux", want: filepath.Join(home, ".config", "peakypanes")},
+		{name: "darwin", goos: "darwin", want: filepath.Join(home, "Library", "Application Support", "peakypanes")},
+		{name: "darwin ignores xdg", goos: "darwin", env: map[string]string{"XDG_CONFIG_HOME": "/xdg"}, want: filepath.Join(home, "Library", "Application Support", "peakypanes")},
+		{name: "windows appdata", goos: "windows", env: map[string]string{"AppData": "/appdata"}, want: filepath.Join("/appdata", "peakypanes")},
+		{name: "windows fallback", goos: "windows", want: filepath.Join(home, ".config", "peakypanes")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(k string) string { return tt.env[k] }
+			got, err := configDirFor(tt.goos, getenv, userHome)
+			if err != nil {
+				t.Fatalf("configDirFor() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("configDirFor(%q) = %q, want %q", tt.goos, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigDirForLegacyDir(t *testing.T) {
+	home := t.TempDir()
+	legacy := filepath.Join(home, ".config", "peakypanes")
+	if err := os.MkdirAll(legacy, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	getenv := func(string) string { return "" }
+
+	got, err := configDirFor("darwin", getenv, func() (string, error) { return home, nil })
+	if err != nil {
+		t.Fatalf("configDirFor() error: %v", err)
+	}
+	if got != legacy {
+		t.Errorf("configDirFor() = %q, want existing %q", got, legacy)
+	}
+}
+
+func TestConfigDirForNoHome(t *testing.T) {
+	noHome := func() (string, error) { return "", errors.New("no home") }
+
+	if _, err := configDirFor("linux", func(string) string { return "" }, noHome); err == nil {
+		t.Error("configDirFor() without home or XDG should fail")
+	}
+
+	got, err := configDirFor("linux", func(k string) string {
+		if k == "XDG_CONFIG_HOME" {
+			return "/xdg"
+		}
+		return ""
+	}, noHome)
+	if err != nil || got != filepath.Join("/xdg", "peakypanes") {
+		t.Errorf("configDirFor() = %q, %v", got, err)
+	}
+}
+
+func TestDefaultPathsUseConfigDir(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Setenv("HOME", t.TempDir())
+	orig := goos
+	goos = "linux"
+	defer func() { goos = orig }()
+
+	cfg, err := DefaultConfigPath()
+	if err != nil || cfg != filepath.Join(xdg, "peakypanes", "config.yml") {
+		t.Errorf("DefaultConfigPath() = %q, %v", cfg, err)
+	}
+	layouts, err := DefaultLayoutsDir()
+	if err != nil || layouts != filepath.Join(xdg, "peakypanes", "layouts") {
+		t.Errorf("DefaultLayoutsDir() = %q, %v", layouts, err)
+	}
+}
@@ -0,0 +1,298 @@
+package layout
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed schema/layout.schema.json
+var layoutSchema []byte
+
+// LayoutSchema returns the JSON schema of the JSON layout format.
+func LayoutSchema() []byte {
+	return layoutSchema
+}
+
+// FieldError is a problem with one field of a JSON layout. Path points at
+// the field, e.g. "windows[0].panes[1].split".
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationError lists every problem found in a JSON layout.
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+var sizePattern = regexp.MustCompile(`^[0-9]+%?$`)
+
+// ParseLayoutJSON parses a layout in the JSON format described by
+// LayoutSchema. A layout that does not match the schema is rejected with a
+// ValidationError naming each failing field.
+func ParseLayoutJSON(data []byte) (*LayoutConfig, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, jsonSyntaxError(data, err)
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after the layout object")
+	}
+
+	var c schemaChecker
+	c.layout(doc)
+	if len(c.errs) > 0 {
+		return nil, c.errs
+	}
+
+	var layout LayoutConfig
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+// jsonSyntaxError adds the line and column to a JSON syntax error. The
+// offset of a syntax error is just past the offending byte.
+func jsonSyntaxError(data []byte, err error) error {
+	var syn *json.SyntaxError
+	if !errors.As(err, &syn) {
+		return err
+	}
+	before := data[:syn.Offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len(before) - bytes.LastIndexByte(before, '\n') - 1
+	return fmt.Errorf("line %d, column %d: %w", line, col, err)
+}
+
+// fieldCheck validates the value at path.
+type fieldCheck func(c *schemaChecker, path string, v interface{})
+
+// schemaChecker walks a decoded JSON layout and collects the fields that
+// break the schema.
+type schemaChecker struct {
+	errs ValidationError
+}
+
+func (c *schemaChecker) fail(path, format string, args ...interface{}) {
+	c.errs = append(c.errs, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *schemaChecker) layout(v interface{}) {
+	if _, ok := v.(map[string]interface{}); !ok {
+		c.fail("", "layout must be a JSON object")
+		return
+	}
+	c.object("", v, []string{"name", "windows"}, map[string]fieldCheck{
+		"name":        isNonEmpty,
+		"description": isString,
+		"vars":        isStringMap,
+		"settings":    checkSettings,
+		"windows":     nonEmptyArrayOf(checkWindow),
+	})
+}
+
+func checkSettings(c *schemaChecker, path string, v interface{}) {
+	c.object(path, v, nil, map[string]fieldCheck{
+		"width":        isNonNegative,
+		"height":       isNonNegative,
+		"bind_keys":    arrayOf(checkKeyBind),
+		"tmux_options": isStringMap,
+		"step_delay":   isDuration,
+	})
+}
+
+func checkKeyBind(c *schemaChecker, path string, v interface{}) {
+	c.object(path, v, []string{"key", "action"}, map[string]fieldCheck{
+		"key":    isNonEmpty,
+		"action": isNonEmpty,
+	})
+}
+
+func checkWindow(c *schemaChecker, path string, v interface{}) {
+	c.object(path, v, []string{"name", "panes"}, map[string]fieldCheck{
+		"name":   isNonEmpty,
+		"layout": isString,
+		"panes":  nonEmptyArrayOf(checkPane),
+	})
+}
+
+func checkPane(c *schemaChecker, path string, v interface{}) {
+	c.object(path, v, []string{"cmd"}, map[string]fieldCheck{
+		"title":   isString,
+		"cmd":     isString,
+		"size":    isSize,
+		"split":   oneOf("horizontal", "vertical", "h", "v"),
+		"setup":   arrayOf(isString),
+		"enabled": isString,
+		"order":   isInteger,
+	})
+}
+
+// object checks that v is an object holding the required keys and no keys
+// other than those in fields, then checks each field.
+func (c *schemaChecker) object(path string, v interface{}, required []string, fields map[string]fieldCheck) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		c.fail(path, "must be an object")
+		return
+	}
+	for _, key := range required {
+		if _, ok := obj[key]; !ok {
+			c.fail(fieldPath(path, key), "is required")
+		}
+	}
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		check, ok := fields[key]
+		if !ok {
+			c.fail(fieldPath(path, key), "unknown field")
+			continue
+		}
+		check(c, fieldPath(path, key), obj[key])
+	}
+}
+
+// arrayOf checks that the value is an array and checks each item.
+func arrayOf(item fieldCheck) fieldCheck {
+	return func(c *schemaChecker, path string, v interface{}) {
+		items, ok := v.([]interface{})
+		if !ok {
+			c.fail(path, "must be an array")
+			return
+		}
+		for i, it := range items {
+			item(c, path+"["+strconv.Itoa(i)+"]", it)
+		}
+	}
+}
+
+// nonEmptyArrayOf is arrayOf for arrays that need at least one item.
+func nonEmptyArrayOf(item fieldCheck) fieldCheck {
+	check := arrayOf(item)
+	return func(c *schemaChecker, path string, v interface{}) {
+		if items, ok := v.([]interface{}); ok && len(items) == 0 {
+			c.fail(path, "must not be empty")
+			return
+		}
+		check(c, path, v)
+	}
+}
+
+func isString(c *schemaChecker, path string, v interface{}) {
+	if _, ok := v.(string); !ok {
+		c.fail(path, "must be a string")
+	}
+}
+
+func isNonEmpty(c *schemaChecker, path string, v interface{}) {
+	s, ok := v.(string)
+	switch {
+	case !ok:
+		c.fail(path, "must be a string")
+	case strings.TrimSpace(s) == "":
+		c.fail(path, "must not be empty")
+	}
+}
+
+func isStringMap(c *schemaChecker, path string, v interface{}) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		c.fail(path, "must be an object")
+		return
+	}
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		isString(c, fieldPath(path, key), obj[key])
+	}
+}
+
+func isInteger(c *schemaChecker, path string, v interface{}) {
+	n, ok := v.(json.Number)
+	if !ok {
+		c.fail(path, "must be an integer")
+		return
+	}
+	if _, err := n.Int64(); err != nil {
+		c.fail(path, "must be an integer")
+	}
+}
+
+func isNonNegative(c *schemaChecker, path string, v interface{}) {
+	n, ok := v.(json.Number)
+	if !ok {
+		c.fail(path, "must be an integer")
+		return
+	}
+	if i, err := n.Int64(); err != nil || i < 0 {
+		c.fail(path, "must be a non-negative integer")
+	}
+}
+
+func isSize(c *schemaChecker, path string, v interface{}) {
+	s, ok := v.(string)
+	if !ok || !sizePattern.MatchString(s) {
+		c.fail(path, `must be a number of cells or a percentage like "30%%"`)
+	}
+}
+
+func isDuration(c *schemaChecker, path string, v interface{}) {
+	s, ok := v.(string)
+	if !ok {
+		c.fail(path, "must be a string")
+		return
+	}
+	if _, err := time.ParseDuration(s); err != nil {
+		c.fail(path, `must be a duration like "300ms"`)
+	}
+}
+
+func oneOf(values ...string) fieldCheck {
+	return func(c *schemaChecker, path string, v interface{}) {
+		s, _ := v.(string)
+		for _, want := range values {
+			if s == want {
+				return
+			}
+		}
+		c.fail(path, "must be one of %s", strings.Join(values, ", "))
+	}
+}
+
+func fieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
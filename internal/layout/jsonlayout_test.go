@@ -0,0 +1,152 @@
+package layout
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const validJSONLayout = `{
+  "name": "generated",
+  "description": "made by a tool",
+  "vars": {"port": "3000"},
+  "settings": {"step_delay": "200ms", "bind_keys": [{"key": "r", "action": "resize-pane -Z"}]},
+  "windows": [
+    {
+      "name": "dev",
+      "layout": "main-vertical",
+      "panes": [
+        {"title": "server", "cmd": "npm run dev -- --port ${port}", "setup": ["nvm use"]},
+        {"title": "shell", "cmd": "", "split": "vertical", "size": "30%", "order": 1}
+      ]
+    }
+  ]
+}`
+
+func TestParseLayoutJSON(t *testing.T) {
+	l, err := ParseLayoutJSON([]byte(validJSONLayout))
+	if err != nil {
+		t.Fatalf("ParseLayoutJSON() error: %v", err)
+	}
+	if l.Name != "generated" || l.Vars["port"] != "3000" || l.Settings.StepDelay != "200ms" {
+		t.Errorf("layout = %+v", l)
+	}
+	if len(l.Settings.BindKeys) != 1 || l.Settings.BindKeys[0].Action != "resize-pane -Z" {
+		t.Errorf("bind keys = %+v", l.Settings.BindKeys)
+	}
+	if len(l.Windows) != 1 || len(l.Windows[0].Panes) != 2 {
+		t.Fatalf("windows = %+v", l.Windows)
+	}
+	p := l.Windows[0].Panes[1]
+	if p.Split != "vertical" || p.Size != "30%" || p.Order != 1 || p.Cmd != "" {
+		t.Errorf("pane = %+v", p)
+	}
+}
+
+func TestParseLayoutJSONInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want []string
+	}{
+		{
+			name: "missing command",
+			json: `{"name": "x", "windows": [{"name": "w", "panes": [{"cmd": "a"}, {"title": "b"}]}]}`,
+			want: []string{"windows[0].panes[1].cmd: is required"},
+		},
+		{
+			name: "bad split direction",
+			json: `{"name": "x", "windows": [{"name": "w", "panes": [{"cmd": "a", "split": "diagonal"}]}]}`,
+			want: []string{"windows[0].panes[0].split: must be one of horizontal, vertical, h, v"},
+		},
+		{
+			name: "missing name and windows",
+			json: `{"description": "d"}`,
+			want: []string{"name: is required", "windows: is required"},
+		},
+		{
+			name: "empty panes",
+			json: `{"name": "x", "windows": [{"name": "w", "panes": []}]}`,
+			want: []string{"windows[0].panes: must not be empty"},
+		},
+		{
+			name: "wrong types",
+			json: `{"name": "x", "settings": {"width": "wide", "step_delay": "soon"}, "windows": [{"name": "w", "panes": [{"cmd": 5, "order": 1.5}]}]}`,
+			want: []string{
+				"settings.step_delay: must be a duration like \"300ms\"",
+				"settings.width: must be an integer",
+				"windows[0].panes[0].cmd: must be a string",
+				"windows[0].panes[0].order: must be an integer",
+			},
+		},
+		{
+			name: "unknown field",
+			json: `{"name": "x", "windows": [{"name": "w", "panes": [{"command": "a", "cmd": "a"}]}]}`,
+			want: []string{"windows[0].panes[0].command: unknown field"},
+		},
+		{
+			name: "bad size",
+			json: `{"name": "x", "windows": [{"name": "w", "panes": [{"cmd": "a", "size": "half"}]}]}`,
+			want: []string{`windows[0].panes[0].size: must be a number of cells or a percentage like "30%"`},
+		},
+		{
+			name: "not an object",
+			json: `["name"]`,
+			want: []string{"layout must be a JSON object"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseLayoutJSON([]byte(tt.json))
+			var verr ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("ParseLayoutJSON() error = %v, want a ValidationError", err)
+			}
+			var got []string
+			for _, fe := range verr {
+				got = append(got, fe.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("errors = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLayoutJSONSyntaxError(t *testing.T) {
+	_, err := ParseLayoutJSON([]byte("{\n  \"name\": \"x\",\n  \"windows\": [}\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 3, column 15") {
+		t.Errorf("error = %v, want the line and column", err)
+	}
+}
+
+func TestLayoutSchemaIsJSON(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(LayoutSchema(), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+}
+
+func TestLoadGlobalLayoutsJSON(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "gen.json"), []byte(validJSONLayout), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"name": "broken"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	l := &Loader{globalLayoutsDir: dir, globalLayouts: make(map[string]*LayoutConfig)}
+	if err := l.LoadGlobalLayouts(); err != nil {
+		t.Fatalf("LoadGlobalLayouts() error: %v", err)
+	}
+	if _, ok := l.globalLayouts["generated"]; !ok {
+		t.Errorf("layouts = %v, want generated", l.globalLayouts)
+	}
+	if _, ok := l.globalLayouts["broken"]; ok {
+		t.Error("invalid JSON layout was loaded")
+	}
+}
@@ -32,6 +32,9 @@ type Loader struct {
 	builtinLayouts map[string]*LayoutConfig
 	globalLayouts  map[string]*LayoutConfig
 	projectLayout  *LayoutConfig
+
+	// defaultPaneCommand comes from the global config file.
+	defaultPaneCommand string
 }
 
 // NewLoader creates a loader with default paths.
@@ -117,7 +120,8 @@ func (l *Loader) LoadGlobalLayouts() error {
 					continue
 				}
 				name := entry.Name()
-				if !strings.HasSuffix(name, ".yml") && !strings.HasSuffix(name, ".yaml") {
+				ext := filepath.Ext(name)
+				if ext != ".yml" && ext != ".yaml" && ext != ".json" {
 					continue
 				}
 
@@ -130,7 +134,7 @@ func (l *Loader) LoadGlobalLayouts() error {
 
 				key := layout.Name
 				if key == "" {
-					key = strings.TrimSuffix(strings.TrimSuffix(name, ".yml"), ".yaml")
+					key = strings.TrimSuffix(name, ext)
 					layout.Name = key
 				}
 				l.globalLayouts[key] = layout
@@ -141,6 +145,9 @@ func (l *Loader) LoadGlobalLayouts() error {
 	// Also load inline layouts from config file
 	if l.globalConfigPath != "" {
 		cfg, err := LoadConfig(l.globalConfigPath)
+		if err == nil {
+			l.defaultPaneCommand = cfg.DefaultPaneCommand
+		}
 		if err == nil && cfg.Layouts != nil {
 			for name, layout := range cfg.Layouts {
 				if layout.Name == "" {
@@ -154,6 +161,12 @@ func (l *Loader) LoadGlobalLayouts() error {
 	return nil
 }
 
+// DefaultPaneCommand returns the command configured to run in every new
+// pane, or "" when none is set. It is known after LoadGlobalLayouts.
+func (l *Loader) DefaultPaneCommand() string {
+	return l.defaultPaneCommand
+}
+
 // LoadProjectLayout loads the .peakypanes.yml from the project directory.
 func (l *Loader) LoadProjectLayout() error {
 	if l.projectDir == "" {
@@ -221,6 +234,21 @@ func (l *Loader) GetProjectLayout() *LayoutConfig {
 	return l.projectLayout
 }
 
+// ResolveLayout picks the layout a session starts with: the named layout,
+// else the layout of the project's .peakypanes.yml, else dev-3. The source
+// tells where the layout came from.
+func (l *Loader) ResolveLayout(name string) (*LayoutConfig, string, error) {
+	if name != "" {
+		return l.GetLayout(name)
+	}
+	if l.HasProjectConfig() {
+		if project := l.GetProjectLayout(); project != nil {
+			return project, "project", nil
+		}
+	}
+	return l.GetLayout("dev-3")
+}
+
 // ListLayouts returns info about all available layouts.
 func (l *Loader) ListLayouts() []LayoutInfo {
 	seen := make(map[string]bool)
@@ -0,0 +1,69 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LogPaneTitle is the title of the pane that follows a project's log file.
+const LogPaneTitle = "log"
+
+// logPaneSize is the share of the first window given to the log pane.
+const logPaneSize = "30%"
+
+// LogFilePath resolves a project's log_file: relative paths are taken from
+// the project directory.
+func LogFilePath(logFile, projectPath string) string {
+	if logFile == "" || filepath.IsAbs(logFile) {
+		return logFile
+	}
+	return filepath.Join(projectPath, logFile)
+}
+
+// TailCommand returns the command that follows the log file at path. A file
+// that does not exist yet is followed by name, so tail keeps retrying and
+// picks it up once it is created.
+func TailCommand(path string, exists bool) string {
+	flag := "-f"
+	if !exists {
+		flag = "-F"
+	}
+	return "tail " + flag + " " + shellQuote(path)
+}
+
+// WithLogPane returns a copy of l whose first window gets an extra pane at
+// the bottom running cmd. l itself is not modified.
+func (l *LayoutConfig) WithLogPane(cmd string) *LayoutConfig {
+	if len(l.Windows) == 0 {
+		return l
+	}
+	out := *l
+	out.Windows = append([]WindowDef(nil), l.Windows...)
+	first := out.Windows[0]
+	first.Panes = append(append([]PaneDef(nil), first.Panes...), PaneDef{
+		Title: LogPaneTitle,
+		Cmd:   cmd,
+		Split: "vertical",
+		Size:  logPaneSize,
+	})
+	out.Windows[0] = first
+	return &out
+}
+
+// shellQuote quotes s for use as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// SessionLayout returns l the way a session in projectPath is created with
+// it: variables expanded and, when logFile is set, a pane following it.
+func SessionLayout(l *LayoutConfig, projectPath, logFile string) *LayoutConfig {
+	out := ExpandLayoutVars(l, nil, projectPath, filepath.Base(projectPath))
+	if logFile == "" {
+		return out
+	}
+	path := LogFilePath(logFile, projectPath)
+	_, err := os.Stat(path)
+	return out.WithLogPane(TailCommand(path, err == nil))
+}
@@ -0,0 +1,60 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTailCommand(t *testing.T) {
+	tests := []struct {
+		path   string
+		exists bool
+		want   string
+	}{
+		{"/var/log/app.log", true, "tail -f '/var/log/app.log'"},
+		{"/tmp/not yet.log", false, "tail -F '/tmp/not yet.log'"},
+		{"/tmp/it's.log", true, `tail -f '/tmp/it'\''s.log'`},
+	}
+	for _, tt := range tests {
+		if got := TailCommand(tt.path, tt.exists); got != tt.want {
+			t.Errorf("TailCommand(%q, %v) = %q, want %q", tt.path, tt.exists, got, tt.want)
+		}
+	}
+}
+
+func TestLogFilePath(t *testing.T) {
+	tests := []struct {
+		logFile, project, want string
+	}{
+		{"", "/src/app", ""},
+		{"/var/log/app.log", "/src/app", "/var/log/app.log"},
+		{"log/dev.log", "/src/app", "/src/app/log/dev.log"},
+	}
+	for _, tt := range tests {
+		if got := LogFilePath(tt.logFile, tt.project); got != tt.want {
+			t.Errorf("LogFilePath(%q, %q) = %q, want %q", tt.logFile, tt.project, got, tt.want)
+		}
+	}
+}
+
+func TestWithLogPane(t *testing.T) {
+	l := &LayoutConfig{Windows: []WindowDef{
+		{Name: "dev", Panes: []PaneDef{{Cmd: "npm run dev"}}},
+		{Name: "git", Panes: []PaneDef{{Cmd: "lazygit"}}},
+	}}
+
+	got := l.WithLogPane("tail -f 'app.log'")
+	want := []PaneDef{
+		{Cmd: "npm run dev"},
+		{Title: LogPaneTitle, Cmd: "tail -f 'app.log'", Split: "vertical", Size: logPaneSize},
+	}
+	if !reflect.DeepEqual(got.Windows[0].Panes, want) {
+		t.Errorf("first window panes = %+v, want %+v", got.Windows[0].Panes, want)
+	}
+	if len(got.Windows[1].Panes) != 1 {
+		t.Errorf("other windows changed: %+v", got.Windows[1].Panes)
+	}
+	if len(l.Windows[0].Panes) != 1 {
+		t.Errorf("WithLogPane modified the original layout: %+v", l.Windows[0].Panes)
+	}
+}
@@ -0,0 +1,86 @@
+package layout
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Step is a single command typed into a pane after the layout's windows and
+// panes exist. Window and Pane index into LayoutConfig.Windows and
+// WindowDef.Panes.
+type Step struct {
+	Window int
+	Pane   int
+	Cmd    string
+}
+
+// Steps returns the pane commands of the layout in the order they must run.
+// By default this is definition order: windows first to last, panes first to
+// last, each pane's setup commands before its cmd. Panes with an explicit
+// order run sorted by it (lower first); panes without one keep their place
+// relative to each other after all ordered panes.
+func (l *LayoutConfig) Steps() []Step {
+	type paneRef struct {
+		window, pane, order int
+	}
+	var panes []paneRef
+	for wi, win := range l.Windows {
+		for pi, pane := range win.Panes {
+			panes = append(panes, paneRef{window: wi, pane: pi, order: pane.Order})
+		}
+	}
+	sort.SliceStable(panes, func(i, j int) bool {
+		a, b := panes[i].order, panes[j].order
+		if (a == 0) != (b == 0) {
+			return a != 0
+		}
+		return a < b
+	})
+
+	var steps []Step
+	for _, ref := range panes {
+		pane := l.Windows[ref.window].Panes[ref.pane]
+		for _, setup := range pane.Setup {
+			if setup != "" {
+				steps = append(steps, Step{Window: ref.window, Pane: ref.pane, Cmd: setup})
+			}
+		}
+		if pane.Cmd != "" {
+			steps = append(steps, Step{Window: ref.window, Pane: ref.pane, Cmd: pane.Cmd})
+		}
+	}
+	return steps
+}
+
+// StepsWithDefault returns Steps preceded by defaultCmd typed into every
+// pane, in definition order, so each pane's own commands run after it. An
+// empty defaultCmd adds nothing.
+func (l *LayoutConfig) StepsWithDefault(defaultCmd string) []Step {
+	if defaultCmd == "" {
+		return l.Steps()
+	}
+	var steps []Step
+	for wi, win := range l.Windows {
+		for pi := range win.Panes {
+			steps = append(steps, Step{Window: wi, Pane: pi, Cmd: defaultCmd})
+		}
+	}
+	return append(steps, l.Steps()...)
+}
+
+// StepDelayDuration parses the configured delay between steps. An empty
+// value means no delay.
+func (s LayoutSettings) StepDelayDuration() (time.Duration, error) {
+	if s.StepDelay == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s.StepDelay)
+	if err != nil {
+		return 0, fmt.Errorf("invalid step_delay %q: %w", s.StepDelay, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid step_delay %q: must not be negative", s.StepDelay)
+	}
+	return d, nil
+}
@@ -0,0 +1,106 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSteps(t *testing.T) {
+	l := &LayoutConfig{Windows: []WindowDef{
+		{Name: "dev", Panes: []PaneDef{
+			{Title: "logs", Cmd: "tail -f server.log", Order: 2},
+			{Title: "server", Setup: []string{"nvm use"}, Cmd: "npm run dev", Order: 1},
+			{Title: "shell"},
+		}},
+		{Name: "tools", Panes: []PaneDef{
+			{Cmd: "lazygit"},
+			{Setup: []string{"", "source .env"}, Cmd: "htop"},
+		}},
+	}}
+
+	want := []Step{
+		{Window: 0, Pane: 1, Cmd: "nvm use"},
+		{Window: 0, Pane: 1, Cmd: "npm run dev"},
+		{Window: 0, Pane: 0, Cmd: "tail -f server.log"},
+		{Window: 1, Pane: 0, Cmd: "lazygit"},
+		{Window: 1, Pane: 1, Cmd: "source .env"},
+		{Window: 1, Pane: 1, Cmd: "htop"},
+	}
+	if got := l.Steps(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Steps() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestStepsDefinitionOrder(t *testing.T) {
+	l := &LayoutConfig{Windows: []WindowDef{{Panes: []PaneDef{{Cmd: "a"}, {Cmd: "b"}, {Cmd: "c"}}}}}
+	var cmds []string
+	for _, s := range l.Steps() {
+		cmds = append(cmds, s.Cmd)
+	}
+	if !reflect.DeepEqual(cmds, []string{"a", "b", "c"}) {
+		t.Errorf("Steps() without order = %v, want definition order", cmds)
+	}
+}
+
+func TestStepsWithDefault(t *testing.T) {
+	l := &LayoutConfig{Windows: []WindowDef{
+		{Name: "dev", Panes: []PaneDef{
+			{Setup: []string{"nvm use"}, Cmd: "npm run dev"},
+			{Title: "shell"},
+		}},
+		{Name: "git", Panes: []PaneDef{{Cmd: "lazygit", Order: 1}}},
+	}}
+
+	want := []Step{
+		{Window: 0, Pane: 0, Cmd: "clear"},
+		{Window: 0, Pane: 1, Cmd: "clear"},
+		{Window: 1, Pane: 0, Cmd: "clear"},
+		{Window: 1, Pane: 0, Cmd: "lazygit"},
+		{Window: 0, Pane: 0, Cmd: "nvm use"},
+		{Window: 0, Pane: 0, Cmd: "npm run dev"},
+	}
+	if got := l.StepsWithDefault("clear"); !reflect.DeepEqual(got, want) {
+		t.Errorf("StepsWithDefault() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := l.StepsWithDefault(""); !reflect.DeepEqual(got, l.Steps()) {
+		t.Errorf("StepsWithDefault(\"\") = %+v, want Steps()", got)
+	}
+}
+
+func TestLoaderDefaultPaneCommand(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yml")
+	if err := os.WriteFile(configPath, []byte("default_pane_command: clear\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	l := NewLoaderWithPaths(configPath, filepath.Join(dir, "layouts"), "")
+	if err := l.LoadGlobalLayouts(); err != nil {
+		t.Fatalf("LoadGlobalLayouts() error: %v", err)
+	}
+	if got := l.DefaultPaneCommand(); got != "clear" {
+		t.Errorf("DefaultPaneCommand() = %q, want clear", got)
+	}
+}
+
+func TestStepDelayDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"300ms", 300 * time.Millisecond, false},
+		{"1s", time.Second, false},
+		{"soon", 0, true},
+		{"-1s", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := LayoutSettings{StepDelay: tt.in}.StepDelayDuration()
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("StepDelayDuration(%q) = %v, %v; want %v, err %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package layout
+
+import "fmt"
+
+// ConfigTemplate returns the commented global config written by
+// `peakypanes init` and by the TUI when the config does not exist yet.
+// layoutsDir is listed as an extra layout directory.
+func ConfigTemplate(layoutsDir string) string {
+	return fmt.Sprintf(configTemplate, layoutsDir)
+}
+
+const configTemplate = `# Peaky Panes - Global Configuration
+# https://github.com/kregenrek/peakypanes
+
+tmux:
+  config: ~/.config/tmux/tmux.conf
+
+ghostty:
+  config: ~/.config/ghostty/config
+
+# Load additional layouts from this directory
+layout_dirs:
+  - %s
+
+# Define projects for quick access
+# projects:
+#   - name: my-project
+#     session: myproj
+#     path: ~/projects/my-project
+#     layout: dev-3
+#     snapshot_layout: true   # restore window layouts after a kill
+#     healthcheck: curl -sf localhost:3000   # health dot while running
+#     no_auto_refresh: true   # run the healthcheck only on 'r'
+#     aliases: [mp, proj]     # extra names for 'peakypanes open <name>'
+#     log_file: log/dev.log   # followed in an extra pane
+#     order: 1                # default list position; unordered ones follow by name
+#     color: "#ff8800"        # tints the status icon (#rgb, #rrggbb or 0-255)
+#     options:                # tmux session options for this project
+#       mouse: "off"
+#       status-position: top
+#     vars:
+#       CUSTOM_VAR: value
+
+# Attach to the last opened session on startup if it is still running
+# reopen_last: true
+
+# Color palette: default, dracula, gruvbox or nord (or peakypanes --theme nord)
+# theme:
+#   preset: nord
+
+# Put this in front of session names derived from project names so they do
+# not clash with other tools' sessions; the list shows them without it
+# session_prefix: pp-
+
+# Also show a project as running when a session of another name (e.g. made by
+# another tool) was started in the project's path
+# match_by_path: true
+
+# Replace peakypanes with the opened session instead of returning to the list
+# (same as peakypanes --exec)
+# exec_attach: true
+
+# Open sessions with this command instead of attaching, e.g. in a new terminal
+# window. {session}, {path} and {name} are filled in; new sessions are started
+# in the background first. Takes precedence over exec_attach.
+# open_command: ghostty -e tmux attach -t {session}
+
+# Hide the logo header (it also collapses on short terminals)
+# show_logo: false
+
+# Inside tmux, open running sessions in a popup instead of switching (tmux 3.2+)
+# open_mode: popup
+
+# Auto-cancel the kill confirmation after this many idle seconds (0 = off)
+# confirm_timeout: 10
+
+# Reword confirmations: kill, kill_busy, adopt, create_dir, clear_scrollback.
+# Templates may use {{.Session}}, {{.Project}}, {{.Path}} and {{.Windows}};
+# anything left out keeps the English default.
+# confirm_prompts:
+#   kill:
+#     title: "Sitzung {{.Session}} beenden?"
+#     note: "{{.Windows}} Fenster werden geschlossen"
+#     action: Beenden
+
+# Quit the TUI after this many seconds without a key press (0 = off), e.g.
+# when peakypanes is a transient launcher
+# idle_timeout: 300
+
+# Load extra projects from one YAML file per project; set the override flag
+# to let those files replace same-named projects listed above
+# projects_dir: ~/.config/peakypanes/projects.d
+# projects_dir_override: false
+
+# Text shown when no projects are configured ({config} = this file's path)
+# empty_message: "Add projects to {config}"
+
+# Show a diff and ask before the TUI rewrites this file (e.g. reordering)
+# confirm_save: true
+
+# Opening a project whose path is missing: never (warn), ask or always mkdir -p
+# create_missing_dirs: ask
+
+# List projects sharing a parent directory under a foldable header (z/Z)
+# group_by_path: true
+
+# Also type clear into every pane when clearing a session's scrollback (C)
+# clear_screen: true
+
+# Lines of a project's README shown by the README panel (m)
+# readme_lines: 20
+
+# Seconds a peek (v) stays attached before detaching, unless you type
+# peek_seconds: 10
+
+# Always show a long-lived scratch session at the top of the list
+# scratch:
+#   enabled: true
+#   session: scratch
+#   path: ~
+#   layout: simple
+
+# Define custom layouts inline (or put in layouts/ directory)
+# layouts:
+#   my-custom:
+#     windows:
+#       - name: dev
+#         panes:
+#           - title: editor
+#             cmd: "${EDITOR:-}"
+#           - title: shell
+#             cmd: ""
+
+tools:
+  cursor_agent:
+    window_name: cursor
+    cmd: ""
+  codex_new:
+    window_name: codex
+    cmd: ""
+`
@@ -0,0 +1,203 @@
+// Package state persists runtime data that Peaky Panes remembers between
+// runs, as opposed to user-edited configuration.
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+// WindowLayout is a saved tmux window arrangement.
+type WindowLayout struct {
+	Name   string `yaml:"name"`
+	Layout string `yaml:"layout"`
+}
+
+// maxRecent caps the number of sessions kept in the MRU list.
+const maxRecent = 20
+
+// maxHistory caps the number of commands remembered per session.
+const maxHistory = 50
+
+// State is the root structure of the state file.
+type State struct {
+	// Layouts maps session names to the window layouts captured on kill.
+	Layouts map[string][]WindowLayout `yaml:"layouts,omitempty"`
+	// Recent lists opened sessions, most recent first.
+	Recent []string `yaml:"recent,omitempty"`
+	// RunHistory maps session names to the commands run once in them, most
+	// recent first.
+	RunHistory map[string][]string `yaml:"run_history,omitempty"`
+	// ConsoleHistory maps session names to the tmux commands typed in the
+	// console for them, most recent first.
+	ConsoleHistory map[string][]string `yaml:"console_history,omitempty"`
+	// CollapsedGroups lists the path groups folded in the project list.
+	CollapsedGroups []string `yaml:"collapsed_groups,omitempty"`
+	// Preferences are the project list settings restored on startup.
+	Preferences Preferences `yaml:"preferences,omitempty"`
+}
+
+// Preferences are the project list view settings last chosen in the TUI.
+type Preferences struct {
+	// Sort names the sort mode, e.g. "name"; empty is the default.
+	Sort string `yaml:"sort,omitempty"`
+	// TreeView lists projects under their directory tree.
+	TreeView bool `yaml:"tree_view,omitempty"`
+	// ShowArchived lists archived projects too.
+	ShowArchived bool `yaml:"show_archived,omitempty"`
+	// HideStopped lists only projects with a running session.
+	HideStopped bool `yaml:"hide_stopped,omitempty"`
+}
+
+// DefaultPath returns the default state file path.
+func DefaultPath() (string, error) {
+	dir, err := layout.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "state.yml"), nil
+}
+
+// Load reads the state file. A missing file yields an empty state.
+func Load(path string) (*State, error) {
+	s := &State{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("read state %q: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parse state %q: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the state file, creating its directory if needed.
+func (s *State) Save(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshal state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create state dir: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write state %q: %w", path, err)
+	}
+	return nil
+}
+
+// SetLayouts stores the window layouts for a session.
+func (s *State) SetLayouts(session string, layouts []WindowLayout) {
+	if s.Layouts == nil {
+		s.Layouts = make(map[string][]WindowLayout)
+	}
+	s.Layouts[session] = layouts
+}
+
+// Touch moves session to the front of the MRU list.
+func (s *State) Touch(session string) {
+	if session == "" {
+		return
+	}
+	recent := []string{session}
+	for _, r := range s.Recent {
+		if r != session && len(recent) < maxRecent {
+			recent = append(recent, r)
+		}
+	}
+	s.Recent = recent
+}
+
+// SetCollapsed records whether the path group is folded in the project
+// list.
+func (s *State) SetCollapsed(group string, collapsed bool) {
+	var groups []string
+	for _, g := range s.CollapsedGroups {
+		if g != group {
+			groups = append(groups, g)
+		}
+	}
+	if collapsed && group != "" {
+		groups = append(groups, group)
+	}
+	s.CollapsedGroups = groups
+}
+
+// AddRunHistory remembers command as the latest run-once command of
+// session.
+func (s *State) AddRunHistory(session, command string) {
+	s.RunHistory = addHistory(s.RunHistory, session, command)
+}
+
+// AddConsoleHistory remembers command as the latest console command of
+// session.
+func (s *State) AddConsoleHistory(session, command string) {
+	s.ConsoleHistory = addHistory(s.ConsoleHistory, session, command)
+}
+
+// addHistory moves command to the front of session's history, dropping an
+// earlier copy and anything past maxHistory.
+func addHistory(history map[string][]string, session, command string) map[string][]string {
+	if session == "" || command == "" {
+		return history
+	}
+	if history == nil {
+		history = make(map[string][]string)
+	}
+	entries := []string{command}
+	for _, c := range history[session] {
+		if c != command && len(entries) < maxHistory {
+			entries = append(entries, c)
+		}
+	}
+	history[session] = entries
+	return history
+}
+
+// Prune removes layouts, MRU entries and command histories for sessions
+// keep does not report as live, returning how many entries were removed.
+func (s *State) Prune(keep func(session string) bool) int {
+	removed := 0
+	for session := range s.Layouts {
+		if !keep(session) {
+			delete(s.Layouts, session)
+			removed++
+		}
+	}
+	var recent []string
+	for _, session := range s.Recent {
+		if keep(session) {
+			recent = append(recent, session)
+		} else {
+			removed++
+		}
+	}
+	s.Recent = recent
+	for _, history := range []map[string][]string{s.RunHistory, s.ConsoleHistory} {
+		for session := range history {
+			if !keep(session) {
+				delete(history, session)
+				removed++
+			}
+		}
+	}
+	return removed
+}
+
+// Update loads the state at path, applies fn and saves it.
+func Update(path string, fn func(*State)) error {
+	s, err := Load(path)
+	if err != nil {
+		return err
+	}
+	fn(s)
+	return s.Save(path)
+}
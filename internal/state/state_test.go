@@ -0,0 +1,169 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadMissing(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "missing.yml"))
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(s.Layouts) != 0 {
+		t.Errorf("Load() of missing file = %+v, want empty", s)
+	}
+}
+
+func TestSaveLoadLayouts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.yml")
+	layouts := []WindowLayout{{Name: "editor", Layout: "b25d,204x50,0,0{102x50,0,0,1,101x50,103,0,2}"}}
+
+	s := &State{}
+	s.SetLayouts("proj", layouts)
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Layouts["proj"], layouts) {
+		t.Errorf("Layouts[proj] = %+v, want %+v", loaded.Layouts["proj"], layouts)
+	}
+}
+
+func TestTouch(t *testing.T) {
+	s := &State{}
+	s.Touch("a")
+	s.Touch("b")
+	s.Touch("a")
+	s.Touch("")
+	if !reflect.DeepEqual(s.Recent, []string{"a", "b"}) {
+		t.Errorf("Recent = %v, want [a b]", s.Recent)
+	}
+
+	for i := 0; i < maxRecent+5; i++ {
+		s.Touch(string(rune('a' + i)))
+	}
+	if len(s.Recent) != maxRecent {
+		t.Errorf("len(Recent) = %d, want %d", len(s.Recent), maxRecent)
+	}
+}
+
+func TestAddRunHistory(t *testing.T) {
+	s := &State{}
+	s.AddRunHistory("api", "npm test")
+	s.AddRunHistory("api", "make lint")
+	s.AddRunHistory("api", "npm test")
+	s.AddRunHistory("api", "")
+	s.AddRunHistory("", "ls")
+	if want := []string{"npm test", "make lint"}; !reflect.DeepEqual(s.RunHistory["api"], want) {
+		t.Errorf("RunHistory[api] = %v, want %v", s.RunHistory["api"], want)
+	}
+	if len(s.RunHistory) != 1 {
+		t.Errorf("RunHistory = %v, want only api", s.RunHistory)
+	}
+
+	for i := 0; i < maxHistory+5; i++ {
+		s.AddRunHistory("api", fmt.Sprintf("echo %d", i))
+	}
+	if got := s.RunHistory["api"]; len(got) != maxHistory || got[0] != fmt.Sprintf("echo %d", maxHistory+4) {
+		t.Errorf("capped history = %d entries starting %q", len(got), got[0])
+	}
+	if s.ConsoleHistory != nil {
+		t.Errorf("ConsoleHistory = %v, want untouched", s.ConsoleHistory)
+	}
+}
+
+func TestSetCollapsed(t *testing.T) {
+	s := &State{}
+	s.SetCollapsed("/work", true)
+	s.SetCollapsed("/oss", true)
+	s.SetCollapsed("/work", true)
+	s.SetCollapsed("", true)
+	if want := []string{"/oss", "/work"}; !reflect.DeepEqual(s.CollapsedGroups, want) {
+		t.Errorf("CollapsedGroups = %v, want %v", s.CollapsedGroups, want)
+	}
+	s.SetCollapsed("/oss", false)
+	if want := []string{"/work"}; !reflect.DeepEqual(s.CollapsedGroups, want) {
+		t.Errorf("CollapsedGroups after expanding = %v, want %v", s.CollapsedGroups, want)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.yml")
+	if err := Update(path, func(s *State) { s.Touch("proj") }); err != nil {
+		t.Fatalf("Update() error: %v", err)
+	}
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s.Recent, []string{"proj"}) {
+		t.Errorf("Recent = %v, want [proj]", s.Recent)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	s := &State{
+		Layouts: map[string][]WindowLayout{
+			"api":  {{Name: "main", Layout: "abcd"}},
+			"gone": {{Name: "main", Layout: "ef01"}},
+		},
+		Recent:         []string{"web", "old", "api", "gone"},
+		RunHistory:     map[string][]string{"api": {"npm test"}, "gone": {"ls"}},
+		ConsoleHistory: map[string][]string{"old": {"list-windows"}},
+	}
+	live := map[string]bool{"api": true, "web": true}
+
+	if removed := s.Prune(func(session string) bool { return live[session] }); removed != 5 {
+		t.Errorf("Prune() removed %d entries, want 5", removed)
+	}
+	if len(s.RunHistory) != 1 || len(s.ConsoleHistory) != 0 {
+		t.Errorf("histories after prune = %v, %v", s.RunHistory, s.ConsoleHistory)
+	}
+	if _, ok := s.Layouts["gone"]; ok || len(s.Layouts) != 1 {
+		t.Errorf("Layouts after prune = %v", s.Layouts)
+	}
+	if !reflect.DeepEqual(s.Recent, []string{"web", "api"}) {
+		t.Errorf("Recent after prune = %v, want [web api]", s.Recent)
+	}
+
+	if removed := s.Prune(func(session string) bool { return live[session] }); removed != 0 {
+		t.Errorf("second Prune() removed %d entries, want 0", removed)
+	}
+}
+
+func TestSaveLoadPreferences(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.yml")
+	prefs := Preferences{Sort: "name", TreeView: true, ShowArchived: true, HideStopped: true}
+
+	s := &State{Preferences: prefs}
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if loaded.Preferences != prefs {
+		t.Errorf("Preferences = %+v, want %+v", loaded.Preferences, prefs)
+	}
+
+	if err := (&State{}).Save(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "preferences") {
+		t.Errorf("default preferences should not be written:\n%s", data)
+	}
+}
@@ -0,0 +1,41 @@
+package tmuxctl
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// SessionsAttached returns how many clients are attached to each session,
+// keyed by session name. When no server is running the map is empty and
+// the error is nil.
+func (c *Client) SessionsAttached(ctx context.Context) (map[string]int, error) {
+	cmd := c.run(ctx, c.bin, "list-sessions", "-F", "#{session_name}\t#{session_attached}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		msg := strings.ToLower(sanitizeOutput(out))
+		if strings.Contains(msg, "no server") || strings.Contains(msg, "failed to connect") {
+			return map[string]int{}, nil
+		}
+		return nil, wrapTmuxErr("list-sessions", err, out)
+	}
+	return parseSessionsAttached(sanitizeOutput(out)), nil
+}
+
+// parseSessionsAttached reads "name<TAB>count" lines, skipping lines
+// without a valid count.
+func parseSessionsAttached(out string) map[string]int {
+	attached := make(map[string]int)
+	for _, line := range strings.Split(out, "\n") {
+		session, count, ok := strings.Cut(strings.TrimRight(line, "\r"), "\t")
+		if !ok || session == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || n < 0 {
+			continue
+		}
+		attached[session] = n
+	}
+	return attached
+}
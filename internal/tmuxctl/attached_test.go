@@ -0,0 +1,31 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseSessionsAttached(t *testing.T) {
+	out := "api\t2\nweb\t0\n\nbroken\nbad\tmany\nneg\t-1\n"
+	got := parseSessionsAttached(out)
+	want := map[string]int{"api": 2, "web": 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSessionsAttached() = %v, want %v", got, want)
+	}
+}
+
+func TestSessionsAttached(t *testing.T) {
+	c, calls := fakeClient("api\t1\n")
+	got, err := c.SessionsAttached(context.Background())
+	if err != nil {
+		t.Fatalf("SessionsAttached() error: %v", err)
+	}
+	if got["api"] != 1 {
+		t.Errorf("SessionsAttached() = %v", got)
+	}
+	want := [][]string{{"list-sessions", "-F", "#{session_name}\t#{session_attached}"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
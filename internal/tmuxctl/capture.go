@@ -0,0 +1,20 @@
+package tmuxctl
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// CapturePane returns the visible text of the pane at target, with trailing
+// blank lines removed.
+func (c *Client) CapturePane(ctx context.Context, target string) (string, error) {
+	if strings.TrimSpace(target) == "" {
+		return "", errors.New("capture target cannot be empty")
+	}
+	out, err := c.run(ctx, c.bin, "capture-pane", "-p", "-t", target).Output()
+	if err != nil {
+		return "", wrapTmuxErr("capture-pane", err, nil)
+	}
+	return strings.TrimRight(sanitizeOutput(out), "\n "), nil
+}
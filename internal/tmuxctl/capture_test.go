@@ -0,0 +1,26 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCapturePane(t *testing.T) {
+	c, calls := fakeClient("$ npm run dev\nready on :3000\n\n\n")
+	got, err := c.CapturePane(context.Background(), "api:")
+	if err != nil {
+		t.Fatalf("CapturePane() error: %v", err)
+	}
+	if got != "$ npm run dev\nready on :3000" {
+		t.Errorf("CapturePane() = %q", got)
+	}
+	want := [][]string{{"capture-pane", "-p", "-t", "api:"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+
+	if _, err := c.CapturePane(context.Background(), " "); err == nil {
+		t.Error("CapturePane() with empty target should fail")
+	}
+}
@@ -15,8 +15,22 @@ import (
 
 // Client coordinates tmux operations to create deterministic pane grids.
 type Client struct {
-	bin string
-	run func(ctx context.Context, name string, args ...string) *exec.Cmd
+	bin   string
+	run   func(ctx context.Context, name string, args ...string) command
+	sleep func(time.Duration) // nil means time.Sleep; replaced in tests
+}
+
+// command is a prepared tmux invocation: an *exec.Cmd, or the answer of a
+// Mock, which runs no process.
+type command interface {
+	Output() ([]byte, error)
+	CombinedOutput() ([]byte, error)
+	Run() error
+}
+
+// execCommand runs tmux as a process.
+func execCommand(ctx context.Context, name string, args ...string) command {
+	return exec.CommandContext(ctx, name, args...)
 }
 
 // Options configures how a session should be created.
@@ -43,12 +57,14 @@ func NewClient(tmuxPath string) (*Client, error) {
 			return nil, fmt.Errorf("tmux not found in PATH: %w", err)
 		}
 	}
-	return &Client{bin: tmuxPath, run: exec.CommandContext}, nil
+	return &Client{bin: tmuxPath, run: execCommand}, nil
 }
 
 // WithExec allows tests to override the exec implementation.
 func (c *Client) WithExec(fn func(context.Context, string, ...string) *exec.Cmd) {
-	c.run = fn
+	c.run = func(ctx context.Context, name string, args ...string) command {
+		return fn(ctx, name, args...)
+	}
 }
 
 // EnsureSession creates the session if missing and optionally attaches.
@@ -115,7 +131,7 @@ func (c *Client) ListSessions(ctx context.Context) ([]string, error) {
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		// Check tmux output and the error message for benign "no server" cases.
-		msg := strings.ToLower(strings.TrimSpace(string(out)))
+		msg := strings.ToLower(strings.TrimSpace(sanitizeOutput(out)))
 		if msg == "" {
 			msg = strings.ToLower(strings.TrimSpace(err.Error()))
 		}
@@ -127,15 +143,19 @@ func (c *Client) ListSessions(ctx context.Context) ([]string, error) {
 		}
 		return nil, wrapTmuxErr("list-sessions", err, out)
 	}
-	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	return parseSessionNames(sanitizeOutput(out)), nil
+}
+
+// parseSessionNames reads one session name per line, skipping blank lines.
+func parseSessionNames(out string) []string {
 	var sessions []string
-	for _, line := range lines {
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
 		name := strings.TrimSpace(line)
 		if name != "" {
 			sessions = append(sessions, name)
 		}
 	}
-	return sessions, nil
+	return sessions
 }
 
 // SourceFile loads tmux commands from the provided file path.
@@ -172,15 +192,15 @@ func (c *Client) CurrentSession(ctx context.Context) (string, error) {
 	cmd := c.run(ctx, c.bin, "display-message", "-p", "#S")
 	out, err := cmd.Output()
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
-			msg := strings.ToLower(strings.TrimSpace(string(exitErr.Stderr)))
+		if code, stderr, ok := exitStatus(err); ok && code == 1 {
+			msg := strings.ToLower(strings.TrimSpace(string(stderr)))
 			if strings.Contains(msg, "no server") || strings.Contains(msg, "failed to connect") {
 				return "", nil
 			}
 		}
 		return "", wrapTmuxErr("display-message", err, nil)
 	}
-	return strings.TrimSpace(string(out)), nil
+	return strings.TrimSpace(sanitizeOutput(out)), nil
 }
 
 // KillSession terminates a tmux session by name.
@@ -195,6 +215,18 @@ func (c *Client) KillSession(ctx context.Context, session string) error {
 	return nil
 }
 
+// RenameSession renames the session old to session.
+func (c *Client) RenameSession(ctx context.Context, old, session string) error {
+	if old == "" || session == "" {
+		return errors.New("old and new session names are required")
+	}
+	cmd := c.run(ctx, c.bin, "rename-session", "-t", old, session)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return wrapTmuxErr("rename-session", err, out)
+	}
+	return nil
+}
+
 // NewWindow creates a new tmux window in the given session. If windowName is
 // non-empty, the window will be renamed accordingly. If startDir is non-empty,
 // tmux will start the window in that directory. command, when non-empty, is
@@ -234,8 +266,8 @@ func (c *Client) KillWindow(ctx context.Context, session, windowName string) err
 	cmd := c.run(ctx, c.bin, "kill-window", "-t", target)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
-			msg := strings.ToLower(strings.TrimSpace(string(out)))
+		if code, _, ok := exitStatus(err); ok && code == 1 {
+			msg := strings.ToLower(strings.TrimSpace(sanitizeOutput(out)))
 			if strings.Contains(msg, "can't find window") {
 				return nil
 			}
@@ -274,7 +306,7 @@ func (c *Client) sessionExists(ctx context.Context, session string) (bool, error
 	cmd := c.run(ctx, c.bin, "has-session", "-t", session)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+		if code, _, ok := exitStatus(err); ok && code == 1 {
 			return false, nil
 		}
 		return false, wrapTmuxErr("has-session", err, out)
@@ -321,7 +353,7 @@ func (c *Client) newSession(ctx context.Context, session, startDir string) (stri
 	if err != nil {
 		return "", wrapTmuxErr("new-session", err, nil)
 	}
-	pane := strings.TrimSpace(string(out))
+	pane := strings.TrimSpace(sanitizeOutput(out))
 	if pane == "" {
 		return "", errors.New("tmux new-session returned empty pane id")
 	}
@@ -346,7 +378,7 @@ func (c *Client) splitPane(ctx context.Context, target, startDir, orientation st
 	if err != nil {
 		return "", wrapTmuxErr(fmt.Sprintf("split-window %s", orientation), err, nil)
 	}
-	pane := strings.TrimSpace(string(out))
+	pane := strings.TrimSpace(sanitizeOutput(out))
 	if pane == "" {
 		return "", errors.New("tmux split-window returned empty pane id")
 	}
@@ -380,9 +412,7 @@ func (c *Client) attach(ctx context.Context, session string) error {
 
 func (c *Client) attachSession(ctx context.Context, session string) error {
 	cmd := c.run(ctx, c.bin, "attach-session", "-t", session)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
+	useTerminal(cmd)
 	if err := cmd.Run(); err != nil {
 		return wrapTmuxErr("attach-session", err, nil)
 	}
@@ -391,9 +421,7 @@ func (c *Client) attachSession(ctx context.Context, session string) error {
 
 func (c *Client) switchClient(ctx context.Context, session string) error {
 	cmd := c.run(ctx, c.bin, "switch-client", "-t", session)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
+	useTerminal(cmd)
 	if err := cmd.Run(); err != nil {
 		return wrapTmuxErr("switch-client", err, nil)
 	}
@@ -408,10 +436,10 @@ func insideTmux() bool {
 }
 
 func wrapTmuxErr(subcmd string, err error, combined []byte) error {
-	msg := strings.TrimSpace(string(combined))
+	msg := strings.TrimSpace(sanitizeOutput(combined))
 	if msg == "" {
-		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
-			msg = strings.TrimSpace(string(exitErr.Stderr))
+		if _, stderr, ok := exitStatus(err); ok && len(stderr) > 0 {
+			msg = strings.TrimSpace(sanitizeOutput(stderr))
 		}
 	}
 	if msg == "" {
@@ -420,6 +448,28 @@ func wrapTmuxErr(subcmd string, err error, combined []byte) error {
 	return fmt.Errorf("tmux %s: %s", subcmd, msg)
 }
 
+// useTerminal connects a process-backed cmd to this process's terminal.
+func useTerminal(cmd command) {
+	if ec, ok := cmd.(*exec.Cmd); ok {
+		ec.Stdout = os.Stdout
+		ec.Stderr = os.Stderr
+		ec.Stdin = os.Stdin
+	}
+}
+
+// exitStatus returns the exit code and standard error of a tmux command
+// that ran and failed, by a process or the mock. ok is false for other
+// errors, e.g. when tmux could not be started.
+func exitStatus(err error) (code int, stderr []byte, ok bool) {
+	switch e := err.(type) {
+	case *exec.ExitError:
+		return e.ExitCode(), e.Stderr, true
+	case *mockExitError:
+		return 1, []byte(e.stderr), true
+	}
+	return 0, nil, false
+}
+
 func isNestedTmuxErr(err error) bool {
 	if err == nil {
 		return false
@@ -463,8 +513,7 @@ func (c *Client) SelectPane(ctx context.Context, target, title string) error {
 	if target == "" {
 		return errors.New("select-pane target cannot be empty")
 	}
-	args := []string{"select-pane", "-t", target, "-T", title}
-	cmd := c.run(ctx, c.bin, args...)
+	cmd := c.run(ctx, c.bin, PaneTitle{Target: target, Title: title}.Args()...)
 	if out, err := cmd.CombinedOutput(); err != nil {
 		return wrapTmuxErr("select-pane", err, out)
 	}
@@ -508,7 +557,7 @@ func (c *Client) SplitWindowWithCmd(ctx context.Context, target, startDir string
 	if err != nil {
 		return "", wrapTmuxErr("split-window", err, nil)
 	}
-	return strings.TrimSpace(string(out)), nil
+	return strings.TrimSpace(sanitizeOutput(out)), nil
 }
 
 // NewSessionWithCmd creates a new session and returns the first pane ID.
@@ -531,7 +580,7 @@ func (c *Client) NewSessionWithCmd(ctx context.Context, session, startDir, windo
 	if err != nil {
 		return "", wrapTmuxErr("new-session", err, nil)
 	}
-	return strings.TrimSpace(string(out)), nil
+	return strings.TrimSpace(sanitizeOutput(out)), nil
 }
 
 // NewWindowWithCmd creates a window and returns the pane ID.
@@ -554,5 +603,5 @@ func (c *Client) NewWindowWithCmd(ctx context.Context, session, windowName, star
 	if err != nil {
 		return "", wrapTmuxErr("new-window", err, nil)
 	}
-	return strings.TrimSpace(string(out)), nil
+	return strings.TrimSpace(sanitizeOutput(out)), nil
 }
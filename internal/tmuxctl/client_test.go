@@ -0,0 +1,37 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNewSessionWithCmdDetached(t *testing.T) {
+	c, calls := fakeClient("%0\n")
+	pane, err := c.NewSessionWithCmd(context.Background(), "api", "/srv/api", "editor", "")
+	if err != nil {
+		t.Fatalf("NewSessionWithCmd() error: %v", err)
+	}
+	if pane != "%0" {
+		t.Errorf("pane = %q, want %%0", pane)
+	}
+	want := [][]string{{"new-session", "-d", "-s", "api", "-P", "-F", "#{pane_id}", "-n", "editor", "-c", "/srv/api"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
+
+func TestRenameSession(t *testing.T) {
+	c, calls := fakeClient("")
+	if err := c.RenameSession(context.Background(), "api", "work-api"); err != nil {
+		t.Fatalf("RenameSession() error: %v", err)
+	}
+	want := [][]string{{"rename-session", "-t", "api", "work-api"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+
+	if err := c.RenameSession(context.Background(), "api", ""); err == nil {
+		t.Error("RenameSession() without a new name should fail")
+	}
+}
@@ -0,0 +1,57 @@
+package tmuxctl
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+)
+
+// idleShells are commands reported for a pane sitting at a shell prompt.
+var idleShells = map[string]bool{
+	"sh": true, "bash": true, "zsh": true, "fish": true, "dash": true,
+	"ksh": true, "tcsh": true, "csh": true, "nu": true, "pwsh": true,
+}
+
+// CommandName normalizes a pane's current command. Login shells are
+// reported as "-zsh" and some tmux builds report full paths.
+func CommandName(command string) string {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return ""
+	}
+	return filepath.Base(strings.TrimPrefix(command, "-"))
+}
+
+// IsIdleShell reports whether command, as reported by
+// #{pane_current_command}, is a shell sitting at its prompt.
+func IsIdleShell(command string) bool {
+	return idleShells[CommandName(command)]
+}
+
+// ActiveCommands returns the command running in the active pane of each
+// session's active window, keyed by session name. When no server is
+// running the map is empty and the error is nil.
+func (c *Client) ActiveCommands(ctx context.Context) (map[string]string, error) {
+	cmd := c.run(ctx, c.bin, "list-sessions", "-F", "#{session_name}\t#{pane_current_command}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		msg := strings.ToLower(sanitizeOutput(out))
+		if strings.Contains(msg, "no server") || strings.Contains(msg, "failed to connect") {
+			return map[string]string{}, nil
+		}
+		return nil, wrapTmuxErr("list-sessions", err, out)
+	}
+	return parseSessionCommands(sanitizeOutput(out)), nil
+}
+
+func parseSessionCommands(out string) map[string]string {
+	commands := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		session, command, ok := strings.Cut(strings.TrimRight(line, "\r"), "\t")
+		if !ok || session == "" {
+			continue
+		}
+		commands[session] = strings.TrimSpace(command)
+	}
+	return commands
+}
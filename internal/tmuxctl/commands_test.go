@@ -0,0 +1,31 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseSessionCommands(t *testing.T) {
+	out := "api\tnode\nweb\tzsh\nnotes\tnvim\n\nbroken\n"
+	got := parseSessionCommands(out)
+	want := map[string]string{"api": "node", "web": "zsh", "notes": "nvim"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSessionCommands() = %v, want %v", got, want)
+	}
+}
+
+func TestActiveCommands(t *testing.T) {
+	c, calls := fakeClient("api\tnpm\n")
+	got, err := c.ActiveCommands(context.Background())
+	if err != nil {
+		t.Fatalf("ActiveCommands() error: %v", err)
+	}
+	if got["api"] != "npm" {
+		t.Errorf("ActiveCommands() = %v", got)
+	}
+	want := [][]string{{"list-sessions", "-F", "#{session_name}\t#{pane_current_command}"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
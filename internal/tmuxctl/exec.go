@@ -0,0 +1,20 @@
+package tmuxctl
+
+import (
+	"context"
+	"errors"
+)
+
+// Exec runs a raw tmux command and returns its standard output. On failure
+// the error carries tmux's stderr.
+func (c *Client) Exec(ctx context.Context, args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("tmux command cannot be empty")
+	}
+	cmd := c.run(ctx, c.bin, args...)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", wrapTmuxErr(args[0], err, nil)
+	}
+	return sanitizeOutput(out), nil
+}
@@ -0,0 +1,38 @@
+package tmuxctl
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExec(t *testing.T) {
+	c, calls := fakeClient("0: main\n")
+	out, err := c.Exec(context.Background(), []string{"list-windows", "-t", "proj"})
+	if err != nil {
+		t.Fatalf("Exec() error: %v", err)
+	}
+	if out != "0: main\n" {
+		t.Errorf("Exec() = %q", out)
+	}
+	if want := [][]string{{"list-windows", "-t", "proj"}}; !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %v, want %v", *calls, want)
+	}
+
+	if _, err := c.Exec(context.Background(), nil); err == nil {
+		t.Error("Exec(nil) should fail")
+	}
+}
+
+func TestExecReportsStderr(t *testing.T) {
+	c := &Client{bin: "tmux"}
+	c.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "sh", "-c", "echo \"can't find window: nope\" >&2; exit 1")
+	})
+	_, err := c.Exec(context.Background(), []string{"select-window", "-t", "nope"})
+	if err == nil || !strings.Contains(err.Error(), "tmux select-window: can't find window: nope") {
+		t.Errorf("Exec() error = %v, want stderr in message", err)
+	}
+}
@@ -0,0 +1,69 @@
+package tmuxctl
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// SessionGroups returns the session group of each grouped session, keyed by
+// session name. Sessions outside a group are omitted. When no server is
+// running the map is empty and the error is nil.
+func (c *Client) SessionGroups(ctx context.Context) (map[string]string, error) {
+	cmd := c.run(ctx, c.bin, "list-sessions", "-F", "#{session_name}\t#{session_group}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		msg := strings.ToLower(sanitizeOutput(out))
+		if strings.Contains(msg, "no server") || strings.Contains(msg, "failed to connect") {
+			return map[string]string{}, nil
+		}
+		return nil, wrapTmuxErr("list-sessions", err, out)
+	}
+	return parseSessionGroups(sanitizeOutput(out)), nil
+}
+
+// parseSessionGroups reads "name<TAB>group" lines, skipping ungrouped
+// sessions.
+func parseSessionGroups(out string) map[string]string {
+	groups := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		name, group, ok := strings.Cut(strings.TrimRight(line, "\r"), "\t")
+		group = strings.TrimSpace(group)
+		if !ok || name == "" || group == "" {
+			continue
+		}
+		groups[name] = group
+	}
+	return groups
+}
+
+// GroupExists reports whether group names a session group, or a session a
+// new grouped session can join. groups is the result of SessionGroups and
+// sessions the running session names.
+func GroupExists(group string, groups map[string]string, sessions []string) bool {
+	for _, g := range groups {
+		if g == group {
+			return true
+		}
+	}
+	for _, s := range sessions {
+		if s == group {
+			return true
+		}
+	}
+	return false
+}
+
+// NewGroupedSession creates a detached session that joins group, sharing its
+// windows while keeping its own current window. group may be a group name or
+// the name of any session; tmux creates the group if needed.
+func (c *Client) NewGroupedSession(ctx context.Context, session, group string) error {
+	if session == "" || group == "" {
+		return errors.New("session and group names are required")
+	}
+	cmd := c.run(ctx, c.bin, "new-session", "-d", "-s", session, "-t", group)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return wrapTmuxErr("new-session", err, out)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseSessionGroups(t *testing.T) {
+	out := "api\tteam\napi-2\tteam\nweb\t\n\nbroken\n\tteam\n"
+	got := parseSessionGroups(out)
+	want := map[string]string{"api": "team", "api-2": "team"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSessionGroups() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGroupExists(t *testing.T) {
+	groups := map[string]string{"api": "team"}
+	sessions := []string{"api", "web"}
+	tests := []struct {
+		group string
+		want  bool
+	}{
+		{"team", true},
+		{"web", true},
+		{"ops", false},
+	}
+	for _, tt := range tests {
+		if got := GroupExists(tt.group, groups, sessions); got != tt.want {
+			t.Errorf("GroupExists(%q) = %v, want %v", tt.group, got, tt.want)
+		}
+	}
+}
+
+func TestNewGroupedSession(t *testing.T) {
+	c, calls := fakeClient("")
+	if err := c.NewGroupedSession(context.Background(), "api-review", "team"); err != nil {
+		t.Fatalf("NewGroupedSession() error: %v", err)
+	}
+	want := [][]string{{"new-session", "-d", "-s", "api-review", "-t", "team"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+
+	if err := c.NewGroupedSession(context.Background(), "api-review", ""); err == nil {
+		t.Error("NewGroupedSession() without a group should fail")
+	}
+}
@@ -0,0 +1,112 @@
+package tmuxctl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+// CapturedPane is the geometry and foreground command of a running pane.
+type CapturedPane struct {
+	Left, Top     int
+	Width, Height int
+	// Command is #{pane_current_command}: the process name only, without
+	// its arguments.
+	Command string
+}
+
+// CapturedWindow is a running window with its panes in index order.
+type CapturedWindow struct {
+	Index string
+	Name  string
+	// Layout is #{window_layout}, which select-layout accepts to restore
+	// the exact pane sizes.
+	Layout string
+	Panes  []CapturedPane
+}
+
+// captureFormat prints one line per pane for CaptureSession.
+const captureFormat = "#{window_index}\t#{window_name}\t#{window_layout}\t#{pane_left}\t#{pane_top}\t#{pane_width}\t#{pane_height}\t#{pane_current_command}"
+
+// CaptureSession reads the windows and panes of a running session.
+func (c *Client) CaptureSession(ctx context.Context, session string) ([]CapturedWindow, error) {
+	session = strings.TrimSpace(session)
+	if session == "" {
+		return nil, fmt.Errorf("session is required")
+	}
+	out, err := c.run(ctx, c.bin, "list-panes", "-s", "-t", session+":", "-F", captureFormat).CombinedOutput()
+	if err != nil {
+		return nil, wrapTmuxErr("list-panes", err, out)
+	}
+	return parseCapture(sanitizeOutput(out)), nil
+}
+
+// parseCapture groups captureFormat lines into windows, keeping the order
+// tmux lists them in. Malformed lines are skipped.
+func parseCapture(out string) []CapturedWindow {
+	var windows []CapturedWindow
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.Split(strings.TrimRight(line, "\r"), "\t")
+		if len(parts) != 8 || parts[0] == "" {
+			continue
+		}
+		var geom [4]int
+		valid := true
+		for i := range geom {
+			n, err := strconv.Atoi(parts[3+i])
+			if err != nil {
+				valid = false
+				break
+			}
+			geom[i] = n
+		}
+		if !valid {
+			continue
+		}
+		pane := CapturedPane{Left: geom[0], Top: geom[1], Width: geom[2], Height: geom[3], Command: parts[7]}
+		if n := len(windows); n > 0 && windows[n-1].Index == parts[0] {
+			windows[n-1].Panes = append(windows[n-1].Panes, pane)
+			continue
+		}
+		windows = append(windows, CapturedWindow{Index: parts[0], Name: parts[1], Layout: parts[2], Panes: []CapturedPane{pane}})
+	}
+	return windows
+}
+
+// CapturedLayout turns captured windows into a layout definition. Each
+// pane after the first splits off the previous one, beside it when it
+// starts to its right and below it otherwise, and the window's tmux layout
+// string restores the exact sizes. Pane commands are best effort: tmux only
+// reports the process name, so "npm run dev" is captured as "node", and
+// panes at a shell prompt get no command.
+func CapturedLayout(name, session string, windows []CapturedWindow) *layout.LayoutConfig {
+	l := &layout.LayoutConfig{
+		Name:        name,
+		Description: fmt.Sprintf("Captured from session %s; pane commands are best effort", session),
+	}
+	for _, w := range windows {
+		win := layout.WindowDef{Name: w.Name, Layout: w.Layout}
+		if win.Name == "" {
+			win.Name = "window-" + w.Index
+		}
+		for i, p := range w.Panes {
+			def := layout.PaneDef{}
+			if !IsIdleShell(p.Command) {
+				def.Cmd = CommandName(p.Command)
+			}
+			if i > 0 {
+				prev := w.Panes[i-1]
+				def.Split = "vertical"
+				if p.Left >= prev.Left+prev.Width {
+					def.Split = "horizontal"
+				}
+			}
+			win.Panes = append(win.Panes, def)
+		}
+		l.Windows = append(l.Windows, win)
+	}
+	return l
+}
@@ -0,0 +1,75 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+func TestParseCapture(t *testing.T) {
+	out := "0\tdev\tb25d,204x50,0,0{102x50,0,0,1,101x50,103,0,2}\t0\t0\t102\t50\t-zsh\n" +
+		"0\tdev\tb25d,204x50,0,0{102x50,0,0,1,101x50,103,0,2}\t103\t0\t101\t50\tnode\n" +
+		"\nbroken\n1\tlogs\t5a1f\tx\t0\t1\t1\ttail\n" +
+		"1\tlogs\t5a1f,204x50,0,0,3\t0\t0\t204\t50\ttail\n"
+	got := parseCapture(out)
+	want := []CapturedWindow{
+		{Index: "0", Name: "dev", Layout: "b25d,204x50,0,0{102x50,0,0,1,101x50,103,0,2}", Panes: []CapturedPane{
+			{Left: 0, Top: 0, Width: 102, Height: 50, Command: "-zsh"},
+			{Left: 103, Top: 0, Width: 101, Height: 50, Command: "node"},
+		}},
+		{Index: "1", Name: "logs", Layout: "5a1f,204x50,0,0,3", Panes: []CapturedPane{
+			{Left: 0, Top: 0, Width: 204, Height: 50, Command: "tail"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCapture() = %+v, want %+v", got, want)
+	}
+}
+
+// TestCapturedLayout tests splits follow the pane geometry, shells get no
+// command and the window layout string is kept
+func TestCapturedLayout(t *testing.T) {
+	windows := []CapturedWindow{
+		{Index: "0", Name: "dev", Layout: "dev-layout", Panes: []CapturedPane{
+			{Left: 0, Top: 0, Width: 102, Height: 50, Command: "-zsh"},
+			{Left: 103, Top: 0, Width: 101, Height: 25, Command: "/usr/bin/nvim"},
+			{Left: 103, Top: 26, Width: 101, Height: 24, Command: "node"},
+		}},
+		{Index: "3", Layout: "logs-layout", Panes: []CapturedPane{
+			{Width: 204, Height: 50, Command: "bash"},
+		}},
+	}
+	got := CapturedLayout("api-dev", "api", windows)
+	want := &layout.LayoutConfig{
+		Name:        "api-dev",
+		Description: "Captured from session api; pane commands are best effort",
+		Windows: []layout.WindowDef{
+			{Name: "dev", Layout: "dev-layout", Panes: []layout.PaneDef{
+				{},
+				{Cmd: "nvim", Split: "horizontal"},
+				{Cmd: "node", Split: "vertical"},
+			}},
+			{Name: "window-3", Layout: "logs-layout", Panes: []layout.PaneDef{{}}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CapturedLayout() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCaptureSession(t *testing.T) {
+	c, calls := fakeClient("0\tdev\tlay\t0\t0\t80\t24\tzsh\n")
+	got, err := c.CaptureSession(context.Background(), "api")
+	if err != nil {
+		t.Fatalf("CaptureSession() error: %v", err)
+	}
+	if len(got) != 1 || len(got[0].Panes) != 1 {
+		t.Errorf("CaptureSession() = %+v", got)
+	}
+	want := [][]string{{"list-panes", "-s", "-t", "api:", "-F", captureFormat}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
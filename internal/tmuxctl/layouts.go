@@ -0,0 +1,65 @@
+package tmuxctl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// WindowLayout describes the pane arrangement of a window as reported by
+// tmux's #{window_layout}.
+type WindowLayout struct {
+	Index  string
+	Name   string
+	Layout string
+}
+
+// WindowLayouts captures the layout string of every window in a session.
+func (c *Client) WindowLayouts(ctx context.Context, session string) ([]WindowLayout, error) {
+	session = strings.TrimSpace(session)
+	if session == "" {
+		return nil, fmt.Errorf("session is required")
+	}
+	cmd := c.run(ctx, c.bin, "list-windows", "-t", session, "-F", "#{window_index}\t#{window_name}\t#{window_layout}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, wrapTmuxErr("list-windows", err, out)
+	}
+	return parseWindowLayouts(sanitizeOutput(out)), nil
+}
+
+func parseWindowLayouts(out string) []WindowLayout {
+	var layouts []WindowLayout
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		parts := strings.Split(strings.TrimSpace(line), "\t")
+		if len(parts) < 3 || parts[2] == "" {
+			continue
+		}
+		layouts = append(layouts, WindowLayout{
+			Index:  parts[0],
+			Name:   parts[1],
+			Layout: parts[2],
+		})
+	}
+	return layouts
+}
+
+// RestoreWindowLayouts replays captured layouts onto a session's windows,
+// matching windows by name. tmux rejects layouts whose pane count differs
+// from the window; those windows are skipped and reported in the error.
+func (c *Client) RestoreWindowLayouts(ctx context.Context, session string, layouts []WindowLayout) error {
+	var failed []string
+	for _, l := range layouts {
+		target := fmt.Sprintf("%s:%s", session, l.Name)
+		if l.Name == "" {
+			target = fmt.Sprintf("%s:%s", session, l.Index)
+		}
+		if err := c.SelectLayout(ctx, target, l.Layout); err != nil {
+			failed = append(failed, target)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("restore layout for %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
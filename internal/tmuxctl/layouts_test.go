@@ -0,0 +1,65 @@
+package tmuxctl
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+// fakeClient returns a client whose tmux invocations are recorded and answered
+// with output.
+func fakeClient(output string) (*Client, *[][]string) {
+	var calls [][]string
+	c := &Client{bin: "tmux"}
+	c.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls = append(calls, args)
+		return exec.CommandContext(ctx, "printf", "%s", output)
+	})
+	return c, &calls
+}
+
+func TestParseWindowLayouts(t *testing.T) {
+	out := "0\teditor\tb25d,204x50,0,0{102x50,0,0,1,101x50,103,0,2}\n1\tlogs\t5a1f,204x50,0,0,3\n\nbroken line\n"
+	got := parseWindowLayouts(out)
+	want := []WindowLayout{
+		{Index: "0", Name: "editor", Layout: "b25d,204x50,0,0{102x50,0,0,1,101x50,103,0,2}"},
+		{Index: "1", Name: "logs", Layout: "5a1f,204x50,0,0,3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWindowLayouts() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWindowLayouts(t *testing.T) {
+	c, calls := fakeClient("0\tmain\tabcd,80x24,0,0,1\n")
+	got, err := c.WindowLayouts(context.Background(), "proj")
+	if err != nil {
+		t.Fatalf("WindowLayouts() error: %v", err)
+	}
+	if len(got) != 1 || got[0].Layout != "abcd,80x24,0,0,1" {
+		t.Errorf("WindowLayouts() = %+v", got)
+	}
+	want := []string{"list-windows", "-t", "proj", "-F", "#{window_index}\t#{window_name}\t#{window_layout}"}
+	if !reflect.DeepEqual((*calls)[0], want) {
+		t.Errorf("args = %v, want %v", (*calls)[0], want)
+	}
+}
+
+func TestRestoreWindowLayouts(t *testing.T) {
+	c, calls := fakeClient("")
+	layouts := []WindowLayout{
+		{Name: "editor", Layout: "b25d,204x50,0,0,1"},
+		{Index: "2", Layout: "5a1f,204x50,0,0,3"},
+	}
+	if err := c.RestoreWindowLayouts(context.Background(), "proj", layouts); err != nil {
+		t.Fatalf("RestoreWindowLayouts() error: %v", err)
+	}
+	want := [][]string{
+		{"select-layout", "-t", "proj:editor", "b25d,204x50,0,0,1"},
+		{"select-layout", "-t", "proj:2", "5a1f,204x50,0,0,3"},
+	}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %v, want %v", *calls, want)
+	}
+}
@@ -0,0 +1,536 @@
+package tmuxctl
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mock is an in-memory stand-in for a tmux server. Plugged into a Client
+// with NewMockClient, it answers the commands peakypanes issues by
+// simulating sessions, windows and panes, so the TUI can be exercised
+// without tmux. No process is started. Commands it does not model succeed
+// without effect.
+type Mock struct {
+	mu       sync.Mutex
+	sessions []*mockSession
+	current  string
+	nextPane int
+	now      func() time.Time
+}
+
+type mockSession struct {
+	name    string
+	path    string
+	created time.Time
+	windows []*mockWindow
+}
+
+type mockWindow struct {
+	index  int
+	name   string
+	panes  []int
+	active bool
+}
+
+// NewMock returns an empty mock server.
+func NewMock() *Mock {
+	return &Mock{now: time.Now}
+}
+
+// NewMockClient returns a Client whose commands are answered by mock
+// instead of a tmux binary.
+func NewMockClient(mock *Mock) *Client {
+	return &Client{bin: "tmux", run: mock.command}
+}
+
+// Sessions returns the names of the simulated sessions in creation order.
+func (m *Mock) Sessions() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, len(m.sessions))
+	for i, s := range m.sessions {
+		names[i] = s.name
+	}
+	return names
+}
+
+// command applies the tmux command in args to the simulated server when
+// the returned command is run. Like exec.CommandContext, it fails without
+// effect once ctx is done.
+func (m *Mock) command(ctx context.Context, _ string, args ...string) command {
+	return mockCommand{ctx: ctx, mock: m, args: args}
+}
+
+// mockCommand is a tmux command answered by a Mock.
+type mockCommand struct {
+	ctx  context.Context
+	mock *Mock
+	args []string
+}
+
+// mockExitError is how a failed mock command ends, like tmux exiting 1
+// with its message on standard error.
+type mockExitError struct {
+	stderr string
+}
+
+func (e *mockExitError) Error() string { return "exit status 1" }
+
+func (c mockCommand) Output() ([]byte, error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, err
+	}
+	out, err := c.mock.apply(c.args)
+	if err != nil {
+		return nil, &mockExitError{stderr: err.Error() + "\n"}
+	}
+	return []byte(out), nil
+}
+
+func (c mockCommand) CombinedOutput() ([]byte, error) {
+	out, err := c.Output()
+	if exitErr, ok := err.(*mockExitError); ok {
+		return []byte(exitErr.stderr), err
+	}
+	return out, nil
+}
+
+func (c mockCommand) Run() error {
+	_, err := c.Output()
+	return err
+}
+
+// mockArgs are the flags and positional arguments of one tmux command.
+type mockArgs struct {
+	flags map[string]string
+	args  []string
+}
+
+func (a mockArgs) has(flag string) bool {
+	_, ok := a.flags[flag]
+	return ok
+}
+
+// parseMockArgs splits args into flags and positional arguments. Flags
+// listed in valued take the following argument as their value.
+func parseMockArgs(args []string, valued string) mockArgs {
+	parsed := mockArgs{flags: map[string]string{}}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if len(arg) < 2 || arg[0] != '-' || len(parsed.args) > 0 {
+			parsed.args = append(parsed.args, arg)
+			continue
+		}
+		flag := arg[1:]
+		if len(flag) == 1 && strings.Contains(valued, flag) && i+1 < len(args) {
+			parsed.flags[flag] = args[i+1]
+			i++
+			continue
+		}
+		parsed.flags[flag] = ""
+	}
+	return parsed
+}
+
+func (m *Mock) apply(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("no command given")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	valued := "tscnFfexylL"
+	if args[0] == "split-window" {
+		valued += "p"
+	}
+	a := parseMockArgs(args[1:], valued)
+	switch args[0] {
+	case "new-session":
+		return m.newSession(a)
+	case "kill-session":
+		s, err := m.target(a)
+		if err != nil {
+			return "", err
+		}
+		m.removeSession(s)
+		return "", nil
+	case "has-session":
+		_, err := m.target(a)
+		return "", err
+	case "rename-session":
+		s, err := m.target(a)
+		if err != nil {
+			return "", err
+		}
+		if len(a.args) == 0 {
+			return "", fmt.Errorf("usage: rename-session [-t target-session] new-name")
+		}
+		if m.session(a.args[0]) != nil {
+			return "", fmt.Errorf("duplicate session: %s", a.args[0])
+		}
+		if m.current == s.name {
+			m.current = a.args[0]
+		}
+		s.name = a.args[0]
+		return "", nil
+	case "new-window":
+		s, err := m.target(a)
+		if err != nil {
+			return "", err
+		}
+		w := s.addWindow(a.flags["n"], m.newPane())
+		return m.printed(a, s, w, w.panes[0]), nil
+	case "split-window":
+		s, w, err := m.targetWindow(a)
+		if err != nil {
+			return "", err
+		}
+		pane := m.newPane()
+		w.panes = append(w.panes, pane)
+		return m.printed(a, s, w, pane), nil
+	case "kill-window":
+		s, w, err := m.targetWindow(a)
+		if err != nil {
+			return "", err
+		}
+		s.removeWindow(w)
+		if len(s.windows) == 0 {
+			m.removeSession(s)
+		}
+		return "", nil
+	case "select-window":
+		s, w, err := m.targetWindow(a)
+		if err != nil {
+			return "", err
+		}
+		s.activate(w)
+		return "", nil
+	case "list-sessions":
+		if len(m.sessions) == 0 {
+			return "", fmt.Errorf("no server running on /tmp/tmux-mock/default")
+		}
+		var lines []string
+		for _, s := range m.sessions {
+			lines = append(lines, m.expand(a.flags["F"], s, s.activeWindow(), 0))
+		}
+		return joinLines(lines), nil
+	case "list-windows":
+		sessions, err := m.scope(a, a.has("a"))
+		if err != nil {
+			return "", err
+		}
+		var lines []string
+		for _, s := range sessions {
+			for _, w := range s.windows {
+				lines = append(lines, m.expand(a.flags["F"], s, w, 0))
+			}
+		}
+		return joinLines(lines), nil
+	case "list-panes":
+		return m.listPanes(a)
+	case "display-message":
+		if !a.has("p") {
+			return "", nil
+		}
+		s := m.session(m.current)
+		if a.has("t") {
+			var err error
+			if s, err = m.target(a); err != nil {
+				return "", err
+			}
+		}
+		if s == nil {
+			return "", fmt.Errorf("no current client")
+		}
+		format := ""
+		if len(a.args) > 0 {
+			format = a.args[0]
+		}
+		return m.expand(format, s, s.activeWindow(), 0) + "\n", nil
+	case "switch-client", "attach-session":
+		s, err := m.target(a)
+		if err != nil {
+			return "", err
+		}
+		m.current = s.name
+		return "", nil
+	case "detach-client":
+		m.current = ""
+		return "", nil
+	case "-V":
+		return "tmux 3.4\n", nil
+	}
+	return "", nil
+}
+
+func (m *Mock) newSession(a mockArgs) (string, error) {
+	name := a.flags["s"]
+	if name == "" {
+		name = strconv.Itoa(len(m.sessions))
+	}
+	if m.session(name) != nil {
+		return "", fmt.Errorf("duplicate session: %s", name)
+	}
+	s := &mockSession{name: name, path: a.flags["c"], created: m.now()}
+	w := s.addWindow(a.flags["n"], m.newPane())
+	m.sessions = append(m.sessions, s)
+	return m.printed(a, s, w, w.panes[0]), nil
+}
+
+func (m *Mock) listPanes(a mockArgs) (string, error) {
+	var (
+		sessions []*mockSession
+		window   *mockWindow
+		err      error
+	)
+	switch {
+	case a.has("a"):
+		sessions = m.sessions
+	case a.has("s"):
+		sessions, err = m.scope(a, false)
+	default:
+		var s *mockSession
+		s, window, err = m.targetWindow(a)
+		sessions = []*mockSession{s}
+	}
+	if err != nil {
+		return "", err
+	}
+	var lines []string
+	for _, s := range sessions {
+		for _, w := range s.windows {
+			if window != nil && w != window {
+				continue
+			}
+			for _, pane := range w.panes {
+				lines = append(lines, m.expand(a.flags["F"], s, w, pane))
+			}
+		}
+	}
+	return joinLines(lines), nil
+}
+
+// printed returns the -P output of a command that created pane.
+func (m *Mock) printed(a mockArgs, s *mockSession, w *mockWindow, pane int) string {
+	if !a.has("P") {
+		return ""
+	}
+	format := a.flags["F"]
+	if format == "" {
+		format = "#{session_name}:#{window_index}.#{pane_index}"
+	}
+	return m.expand(format, s, w, pane) + "\n"
+}
+
+func (m *Mock) newPane() int {
+	m.nextPane++
+	return m.nextPane
+}
+
+func (m *Mock) session(name string) *mockSession {
+	for _, s := range m.sessions {
+		if s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func (m *Mock) removeSession(s *mockSession) {
+	for i, other := range m.sessions {
+		if other == s {
+			m.sessions = append(m.sessions[:i], m.sessions[i+1:]...)
+			break
+		}
+	}
+	if m.current == s.name {
+		m.current = ""
+	}
+}
+
+// scope returns every session when all is set, else the -t session.
+func (m *Mock) scope(a mockArgs, all bool) ([]*mockSession, error) {
+	if all {
+		return m.sessions, nil
+	}
+	s, err := m.target(a)
+	if err != nil {
+		return nil, err
+	}
+	return []*mockSession{s}, nil
+}
+
+// target resolves the session of the -t flag, the current one when unset.
+func (m *Mock) target(a mockArgs) (*mockSession, error) {
+	s, _, err := m.resolve(a.flags["t"])
+	return s, err
+}
+
+// targetWindow resolves the window of the -t flag, the session's active
+// one when the target names no window.
+func (m *Mock) targetWindow(a mockArgs) (*mockSession, *mockWindow, error) {
+	s, window, err := m.resolve(a.flags["t"])
+	if err != nil {
+		return nil, nil, err
+	}
+	if window == "" {
+		return s, s.activeWindow(), nil
+	}
+	for _, w := range s.windows {
+		if w.name == window || strconv.Itoa(w.index) == window {
+			return s, w, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("can't find window: %s", window)
+}
+
+// resolve splits a session:window.pane target and looks up its session.
+// Pane ids like %3 resolve to the session holding that pane.
+func (m *Mock) resolve(target string) (*mockSession, string, error) {
+	if strings.HasPrefix(target, "%") {
+		id, _ := strconv.Atoi(target[1:])
+		for _, s := range m.sessions {
+			for _, w := range s.windows {
+				for _, pane := range w.panes {
+					if pane == id {
+						return s, strconv.Itoa(w.index), nil
+					}
+				}
+			}
+		}
+		return nil, "", fmt.Errorf("can't find pane: %s", target)
+	}
+	name, window, _ := strings.Cut(strings.TrimPrefix(target, "="), ":")
+	window, _, _ = strings.Cut(window, ".")
+	if name == "" {
+		name = m.current
+	}
+	s := m.session(name)
+	if s == nil {
+		if len(m.sessions) == 0 {
+			return nil, "", fmt.Errorf("no server running on /tmp/tmux-mock/default")
+		}
+		return nil, "", fmt.Errorf("can't find session: %s", name)
+	}
+	return s, window, nil
+}
+
+func (s *mockSession) addWindow(name string, pane int) *mockWindow {
+	index := 0
+	for _, w := range s.windows {
+		if w.index >= index {
+			index = w.index + 1
+		}
+	}
+	if name == "" {
+		name = "zsh"
+	}
+	w := &mockWindow{index: index, name: name, panes: []int{pane}}
+	s.windows = append(s.windows, w)
+	s.activate(w)
+	return w
+}
+
+func (s *mockSession) removeWindow(w *mockWindow) {
+	for i, other := range s.windows {
+		if other == w {
+			s.windows = append(s.windows[:i], s.windows[i+1:]...)
+			break
+		}
+	}
+	if w.active && len(s.windows) > 0 {
+		s.windows[0].active = true
+	}
+}
+
+func (s *mockSession) activate(w *mockWindow) {
+	for _, other := range s.windows {
+		other.active = other == w
+	}
+}
+
+func (s *mockSession) activeWindow() *mockWindow {
+	for _, w := range s.windows {
+		if w.active {
+			return w
+		}
+	}
+	return nil
+}
+
+var mockFormatVar = regexp.MustCompile(`#\{([a-z_]+)\}|#S`)
+
+// expand fills in the tmux format variables the mock knows about; any
+// other variable expands to nothing.
+func (m *Mock) expand(format string, s *mockSession, w *mockWindow, pane int) string {
+	return mockFormatVar.ReplaceAllStringFunc(format, func(v string) string {
+		name := strings.TrimSuffix(strings.TrimPrefix(v, "#{"), "}")
+		if v == "#S" {
+			name = "session_name"
+		}
+		return m.formatValue(name, s, w, pane)
+	})
+}
+
+func (m *Mock) formatValue(name string, s *mockSession, w *mockWindow, pane int) string {
+	if pane == 0 && w != nil && len(w.panes) > 0 {
+		pane = w.panes[0]
+	}
+	switch name {
+	case "session_name":
+		return s.name
+	case "session_path", "pane_current_path":
+		return s.path
+	case "session_created", "session_activity":
+		return strconv.FormatInt(s.created.Unix(), 10)
+	case "session_attached":
+		return boolFlag(m.current == s.name)
+	case "session_windows":
+		return strconv.Itoa(len(s.windows))
+	case "pane_current_command":
+		return "zsh"
+	case "pane_id":
+		return "%" + strconv.Itoa(pane)
+	case "client_width":
+		return "200"
+	case "client_height":
+		return "50"
+	}
+	if w == nil {
+		return ""
+	}
+	switch name {
+	case "window_index":
+		return strconv.Itoa(w.index)
+	case "window_name":
+		return w.name
+	case "window_active":
+		return boolFlag(w.active)
+	case "window_panes":
+		return strconv.Itoa(len(w.panes))
+	case "pane_index":
+		return strconv.Itoa(sort.SearchInts(w.panes, pane))
+	case "pane_active":
+		return boolFlag(len(w.panes) > 0 && w.panes[0] == pane)
+	}
+	return ""
+}
+
+func boolFlag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,139 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMockCreateListKill(t *testing.T) {
+	t.Setenv("PATH", "") // the mock must not start processes
+	ctx := context.Background()
+	mock := NewMock()
+	c := NewMockClient(mock)
+
+	if got, err := c.ListSessions(ctx); err != nil || len(got) != 0 {
+		t.Fatalf("ListSessions() on empty mock = %v, %v", got, err)
+	}
+	pane, err := c.NewSessionWithCmd(ctx, "api", "/src/api", "editor", "")
+	if err != nil {
+		t.Fatalf("NewSessionWithCmd() error: %v", err)
+	}
+	if pane != "%1" {
+		t.Errorf("NewSessionWithCmd() pane = %q, want %%1", pane)
+	}
+	if _, err := c.NewSessionWithCmd(ctx, "web", "/src/web", "", ""); err != nil {
+		t.Fatalf("NewSessionWithCmd(web) error: %v", err)
+	}
+	if _, err := c.NewSessionWithCmd(ctx, "api", "", "", ""); err == nil || !strings.Contains(err.Error(), "duplicate session") {
+		t.Errorf("NewSessionWithCmd(duplicate) error = %v", err)
+	}
+
+	got, err := c.ListSessions(ctx)
+	if err != nil {
+		t.Fatalf("ListSessions() error: %v", err)
+	}
+	if want := []string{"api", "web"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListSessions() = %v, want %v", got, want)
+	}
+	paths, err := c.ListSessionPaths(ctx)
+	if err != nil {
+		t.Fatalf("ListSessionPaths() error: %v", err)
+	}
+	if paths["web"].Start != "/src/web" {
+		t.Errorf("ListSessionPaths()[web] = %+v", paths["web"])
+	}
+
+	if err := c.KillSession(ctx, "api"); err != nil {
+		t.Fatalf("KillSession() error: %v", err)
+	}
+	if err := c.KillSession(ctx, "api"); err == nil || !strings.Contains(err.Error(), "can't find session") {
+		t.Errorf("KillSession(killed) error = %v", err)
+	}
+	if want := []string{"web"}; !reflect.DeepEqual(mock.Sessions(), want) {
+		t.Errorf("Sessions() after kill = %v, want %v", mock.Sessions(), want)
+	}
+}
+
+func TestMockWindowsAndPanes(t *testing.T) {
+	ctx := context.Background()
+	c := NewMockClient(NewMock())
+	first, err := c.NewSessionWithCmd(ctx, "api", "/src/api", "editor", "")
+	if err != nil {
+		t.Fatalf("NewSessionWithCmd() error: %v", err)
+	}
+	if _, err := c.SplitWindowWithCmd(ctx, first, "", false, 50, ""); err != nil {
+		t.Fatalf("SplitWindowWithCmd() error: %v", err)
+	}
+	if _, err := c.NewWindowWithCmd(ctx, "api", "server", "", ""); err != nil {
+		t.Fatalf("NewWindowWithCmd() error: %v", err)
+	}
+
+	windows, err := c.ListWindows(ctx, "api")
+	if err != nil {
+		t.Fatalf("ListWindows() error: %v", err)
+	}
+	want := []Window{
+		{Index: "0", Name: "editor", Panes: 2},
+		{Index: "1", Name: "server", Panes: 1, Active: true},
+	}
+	if !reflect.DeepEqual(windows, want) {
+		t.Errorf("ListWindows() = %+v, want %+v", windows, want)
+	}
+
+	if err := c.SelectWindow(ctx, "api:0"); err != nil {
+		t.Fatalf("SelectWindow() error: %v", err)
+	}
+	active, err := c.SessionActiveWindow(ctx, "api")
+	if err != nil {
+		t.Fatalf("SessionActiveWindow() error: %v", err)
+	}
+	if active.Name != "editor" || active.Windows != 2 {
+		t.Errorf("SessionActiveWindow() = %+v", active)
+	}
+
+	if err := c.KillWindow(ctx, "api", "server"); err != nil {
+		t.Fatalf("KillWindow() error: %v", err)
+	}
+	if err := c.KillWindow(ctx, "api", "editor"); err != nil {
+		t.Fatalf("KillWindow(last) error: %v", err)
+	}
+	if got, _ := c.ListSessions(ctx); len(got) != 0 {
+		t.Errorf("killing the last window left sessions %v", got)
+	}
+}
+
+func TestMockCurrentSession(t *testing.T) {
+	ctx := context.Background()
+	c := NewMockClient(NewMock())
+	if _, err := c.NewSessionWithCmd(ctx, "api", "", "", ""); err != nil {
+		t.Fatalf("NewSessionWithCmd() error: %v", err)
+	}
+	if err := c.switchClient(ctx, "api"); err != nil {
+		t.Fatalf("switchClient() error: %v", err)
+	}
+	got, err := c.CurrentSession(ctx)
+	if err != nil || got != "api" {
+		t.Errorf("CurrentSession() = %q, %v, want api", got, err)
+	}
+	attached, err := c.SessionsAttached(ctx)
+	if err != nil || attached["api"] != 1 {
+		t.Errorf("SessionsAttached() = %v, %v", attached, err)
+	}
+	if err := c.switchClient(ctx, "missing"); err == nil {
+		t.Error("switchClient(missing) succeeded")
+	}
+}
+
+func TestMockHonorsContext(t *testing.T) {
+	c := NewMockClient(NewMock())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.NewSessionWithCmd(ctx, "api", "", "", ""); err == nil {
+		t.Fatal("NewSessionWithCmd() with a done context succeeded")
+	}
+	if got, _ := c.ListSessions(context.Background()); len(got) != 0 {
+		t.Errorf("a command with a done context created sessions %v", got)
+	}
+}
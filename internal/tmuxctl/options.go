@@ -0,0 +1,114 @@
+package tmuxctl
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// sessionOptions are the tmux session options, used to catch typos in
+// configured option names.
+var sessionOptions = map[string]bool{
+	"activity-action":             true,
+	"assume-paste-time":           true,
+	"base-index":                  true,
+	"bell-action":                 true,
+	"default-command":             true,
+	"default-shell":               true,
+	"default-size":                true,
+	"destroy-unattached":          true,
+	"detach-on-destroy":           true,
+	"display-panes-active-colour": true,
+	"display-panes-colour":        true,
+	"display-panes-time":          true,
+	"display-time":                true,
+	"history-limit":               true,
+	"key-table":                   true,
+	"lock-after-time":             true,
+	"lock-command":                true,
+	"menu-border-lines":           true,
+	"menu-border-style":           true,
+	"menu-selected-style":         true,
+	"menu-style":                  true,
+	"message-command-style":       true,
+	"message-line":                true,
+	"message-style":               true,
+	"mouse":                       true,
+	"prefix":                      true,
+	"prefix2":                     true,
+	"renumber-windows":            true,
+	"repeat-time":                 true,
+	"set-titles":                  true,
+	"set-titles-string":           true,
+	"silence-action":              true,
+	"status":                      true,
+	"status-format":               true,
+	"status-interval":             true,
+	"status-justify":              true,
+	"status-keys":                 true,
+	"status-left":                 true,
+	"status-left-length":          true,
+	"status-left-style":           true,
+	"status-position":             true,
+	"status-right":                true,
+	"status-right-length":         true,
+	"status-right-style":          true,
+	"status-style":                true,
+	"update-environment":          true,
+	"visual-activity":             true,
+	"visual-bell":                 true,
+	"visual-silence":              true,
+	"word-separators":             true,
+}
+
+// KnownSessionOption reports whether name is a tmux session option or a
+// user option (starting with @).
+func KnownSessionOption(name string) bool {
+	return sessionOptions[name] || (strings.HasPrefix(name, "@") && len(name) > 1)
+}
+
+// UnknownOptions returns the sorted names in opts that are not known
+// session options.
+func UnknownOptions(opts map[string]string) []string {
+	var unknown []string
+	for name := range opts {
+		if !KnownSessionOption(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// SetOptionCommands returns the set-option invocations that apply opts to
+// session, sorted by option name. Unknown options are left out.
+func SetOptionCommands(session string, opts map[string]string) [][]string {
+	names := make([]string, 0, len(opts))
+	for name := range opts {
+		if KnownSessionOption(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var cmds [][]string
+	for _, name := range names {
+		cmds = append(cmds, []string{"set-option", "-t", session, name, opts[name]})
+	}
+	return cmds
+}
+
+// SetOptions applies opts to session with SetOptionCommands. Every option
+// is tried; the error lists the ones tmux rejected.
+func (c *Client) SetOptions(ctx context.Context, session string, opts map[string]string) error {
+	var failed []string
+	for _, args := range SetOptionCommands(session, opts) {
+		if out, err := c.run(ctx, c.bin, args...).CombinedOutput(); err != nil {
+			failed = append(failed, wrapTmuxErr(args[0]+" "+args[3], err, out).Error())
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
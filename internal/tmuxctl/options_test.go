@@ -0,0 +1,57 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSetOptionCommands(t *testing.T) {
+	opts := map[string]string{
+		"status-position": "top",
+		"mouse":           "off",
+		"@theme":          "dark",
+		"mouze":           "on",
+	}
+	got := SetOptionCommands("api", opts)
+	want := [][]string{
+		{"set-option", "-t", "api", "@theme", "dark"},
+		{"set-option", "-t", "api", "mouse", "off"},
+		{"set-option", "-t", "api", "status-position", "top"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SetOptionCommands() = %q, want %q", got, want)
+	}
+	if got := SetOptionCommands("api", nil); got != nil {
+		t.Errorf("SetOptionCommands(nil) = %q, want nil", got)
+	}
+}
+
+func TestUnknownOptions(t *testing.T) {
+	opts := map[string]string{
+		"mouse":          "on",
+		"status-postion": "top",
+		"mouze":          "on",
+		"@":              "x",
+		"@custom":        "x",
+	}
+	got := UnknownOptions(opts)
+	want := []string{"@", "mouze", "status-postion"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnknownOptions() = %q, want %q", got, want)
+	}
+	if got := UnknownOptions(map[string]string{"history-limit": "50000"}); got != nil {
+		t.Errorf("UnknownOptions(known) = %q, want nil", got)
+	}
+}
+
+func TestSetOptions(t *testing.T) {
+	c, calls := fakeClient("")
+	if err := c.SetOptions(context.Background(), "api", map[string]string{"mouse": "off", "typo": "x"}); err != nil {
+		t.Fatalf("SetOptions() error: %v", err)
+	}
+	want := [][]string{{"set-option", "-t", "api", "mouse", "off"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
@@ -0,0 +1,11 @@
+package tmuxctl
+
+import "strings"
+
+// sanitizeOutput converts tmux output to a string, replacing byte sequences
+// that are not valid UTF-8 with U+FFFD. Unusual locales and binary pane
+// content can produce such bytes; left in, they garble the TUI and corrupt
+// names parsed from the output.
+func sanitizeOutput(out []byte) string {
+	return strings.ToValidUTF8(string(out), "\uFFFD")
+}
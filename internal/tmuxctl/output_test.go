@@ -0,0 +1,62 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitizeOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		want string
+	}{
+		{name: "valid", in: []byte("api ◆ café\n"), want: "api ◆ café\n"},
+		{name: "invalid byte", in: []byte("ap\xffi"), want: "ap\uFFFDi"},
+		{name: "truncated rune", in: []byte("caf\xc3"), want: "caf\uFFFD"},
+		{name: "invalid run", in: []byte("\xfe\xfe\xfe"), want: "\uFFFD"},
+		{name: "empty", in: nil, want: ""},
+	}
+	for _, tt := range tests {
+		if got := sanitizeOutput(tt.in); got != tt.want {
+			t.Errorf("%s: sanitizeOutput() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestListSessionsInvalidUTF8(t *testing.T) {
+	c, _ := fakeClient("api\nbin\xff\xfename\n")
+	got, err := c.ListSessions(context.Background())
+	if err != nil {
+		t.Fatalf("ListSessions() error: %v", err)
+	}
+	want := []string{"api", "bin\uFFFDname"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListSessions() = %q, want %q", got, want)
+	}
+}
+
+func TestCapturePaneInvalidUTF8(t *testing.T) {
+	c, _ := fakeClient("build ok\n\x1b[32m\xc0\xafdone\n")
+	got, err := c.CapturePane(context.Background(), "api")
+	if err != nil {
+		t.Fatalf("CapturePane() error: %v", err)
+	}
+	if !utf8.ValidString(got) {
+		t.Errorf("CapturePane() = %q, want valid UTF-8", got)
+	}
+}
+
+func TestParseWithInvalidUTF8(t *testing.T) {
+	out := sanitizeOutput([]byte("api\t/srv/\xffapi\t/srv/api\nwe\xffb\tteam\n"))
+	paths := parseSessionPaths(out)
+	if paths["api"].Start != "/srv/\uFFFDapi" {
+		t.Errorf("parseSessionPaths() = %+v", paths)
+	}
+	groups := parseSessionGroups(out)
+	if groups["we\uFFFDb"] != "team" {
+		t.Errorf("parseSessionGroups() = %+v", groups)
+	}
+}
@@ -0,0 +1,41 @@
+package tmuxctl
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// PanePIDs returns the PIDs of the processes started in each session's
+// panes, keyed by session name. When no server is running the map is empty
+// and the error is nil.
+func (c *Client) PanePIDs(ctx context.Context) (map[string][]int, error) {
+	cmd := c.run(ctx, c.bin, "list-panes", "-a", "-F", "#{session_name}\t#{pane_pid}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		msg := strings.ToLower(sanitizeOutput(out))
+		if strings.Contains(msg, "no server") || strings.Contains(msg, "failed to connect") {
+			return map[string][]int{}, nil
+		}
+		return nil, wrapTmuxErr("list-panes", err, out)
+	}
+	return parsePanePIDs(sanitizeOutput(out)), nil
+}
+
+// parsePanePIDs reads "name<TAB>pid" lines, skipping lines without a valid
+// PID.
+func parsePanePIDs(out string) map[string][]int {
+	pids := make(map[string][]int)
+	for _, line := range strings.Split(out, "\n") {
+		session, field, ok := strings.Cut(strings.TrimRight(line, "\r"), "\t")
+		if !ok || session == "" {
+			continue
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || pid <= 0 {
+			continue
+		}
+		pids[session] = append(pids[session], pid)
+	}
+	return pids
+}
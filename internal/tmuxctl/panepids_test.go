@@ -0,0 +1,34 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParsePanePIDs(t *testing.T) {
+	out := "api\t101\napi\t102\nweb\t201\n\nbroken\nbad\tpid\nzero\t0\n"
+	got := parsePanePIDs(out)
+	want := map[string][]int{
+		"api": {101, 102},
+		"web": {201},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePanePIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestPanePIDs(t *testing.T) {
+	c, calls := fakeClient("api\t101\n")
+	got, err := c.PanePIDs(context.Background())
+	if err != nil {
+		t.Fatalf("PanePIDs() error: %v", err)
+	}
+	if !reflect.DeepEqual(got, map[string][]int{"api": {101}}) {
+		t.Errorf("PanePIDs() = %v", got)
+	}
+	want := [][]string{{"list-panes", "-a", "-F", "#{session_name}\t#{pane_pid}"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
@@ -0,0 +1,27 @@
+package tmuxctl
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// PaneCommands returns the foreground command of every pane in session,
+// across all of its windows.
+func (c *Client) PaneCommands(ctx context.Context, session string) ([]string, error) {
+	if session == "" {
+		return nil, errors.New("session name is required")
+	}
+	cmd := c.run(ctx, c.bin, "list-panes", "-s", "-t", session, "-F", "#{pane_current_command}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, wrapTmuxErr("list-panes", err, out)
+	}
+	var commands []string
+	for _, line := range strings.Split(sanitizeOutput(out), "\n") {
+		if command := strings.TrimSpace(line); command != "" {
+			commands = append(commands, command)
+		}
+	}
+	return commands, nil
+}
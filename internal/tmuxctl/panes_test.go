@@ -0,0 +1,22 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestPaneCommands(t *testing.T) {
+	c, calls := fakeClient("zsh\nnode\n\nvim\n")
+	got, err := c.PaneCommands(context.Background(), "api")
+	if err != nil {
+		t.Fatalf("PaneCommands() error: %v", err)
+	}
+	if want := []string{"zsh", "node", "vim"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PaneCommands() = %q, want %q", got, want)
+	}
+	want := [][]string{{"list-panes", "-s", "-t", "api", "-F", "#{pane_current_command}"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
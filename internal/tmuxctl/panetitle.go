@@ -0,0 +1,37 @@
+package tmuxctl
+
+import (
+	"strings"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+// PaneTitle is the title a layout gives one created pane.
+type PaneTitle struct {
+	Target string
+	Title  string
+}
+
+// Args returns the tmux command applying the title.
+func (t PaneTitle) Args() []string {
+	return []string{"select-pane", "-t", t.Target, "-T", t.Title}
+}
+
+// PaneTitles returns the title of every titled pane of l, in window and
+// pane order. paneIDs[w][p] is the target of l.Windows[w].Panes[p]; panes
+// without a title or target are skipped. Titles are applied once all
+// panes exist, so a later split or layout change cannot leave a pane
+// unlabelled.
+func PaneTitles(l *layout.LayoutConfig, paneIDs [][]string) []PaneTitle {
+	var titles []PaneTitle
+	for wi, win := range l.Windows {
+		for pi, pane := range win.Panes {
+			title := strings.TrimSpace(pane.Title)
+			if title == "" || wi >= len(paneIDs) || pi >= len(paneIDs[wi]) {
+				continue
+			}
+			titles = append(titles, PaneTitle{Target: paneIDs[wi][pi], Title: title})
+		}
+	}
+	return titles
+}
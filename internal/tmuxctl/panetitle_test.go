@@ -0,0 +1,39 @@
+package tmuxctl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+func TestPaneTitles(t *testing.T) {
+	l := &layout.LayoutConfig{
+		Windows: []layout.WindowDef{
+			{Name: "dev", Panes: []layout.PaneDef{
+				{Title: "editor", Cmd: "vim"},
+				{Cmd: "bash"},
+				{Title: " server ", Cmd: "npm run dev"},
+			}},
+			{Name: "logs", Panes: []layout.PaneDef{{Title: "logs", Cmd: "tail -f log"}}},
+			{Name: "extra", Panes: []layout.PaneDef{{Title: "orphan"}}},
+		},
+	}
+	paneIDs := [][]string{{"%1", "%2", "%3"}, {"%4"}}
+
+	var got [][]string
+	for _, title := range PaneTitles(l, paneIDs) {
+		got = append(got, title.Args())
+	}
+	want := [][]string{
+		{"select-pane", "-t", "%1", "-T", "editor"},
+		{"select-pane", "-t", "%3", "-T", "server"},
+		{"select-pane", "-t", "%4", "-T", "logs"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PaneTitles() commands = %q, want %q", got, want)
+	}
+	if got := PaneTitles(&layout.LayoutConfig{}, nil); got != nil {
+		t.Errorf("PaneTitles(no windows) = %q, want nil", got)
+	}
+}
@@ -0,0 +1,49 @@
+package tmuxctl
+
+import (
+	"context"
+	"strings"
+)
+
+// SessionPaths describes where a live session is working.
+type SessionPaths struct {
+	// Start is the session's start directory (#{session_path}).
+	Start string
+	// Pane is the current path of the active pane in the session's active
+	// window (#{pane_current_path}).
+	Pane string
+}
+
+// ListSessionPaths returns the start and active pane paths of each session,
+// keyed by session name. When no server is running the map is empty and the
+// error is nil.
+func (c *Client) ListSessionPaths(ctx context.Context) (map[string]SessionPaths, error) {
+	cmd := c.run(ctx, c.bin, "list-sessions", "-F", "#{session_name}\t#{session_path}\t#{pane_current_path}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		msg := strings.ToLower(sanitizeOutput(out))
+		if strings.Contains(msg, "no server") || strings.Contains(msg, "failed to connect") {
+			return map[string]SessionPaths{}, nil
+		}
+		return nil, wrapTmuxErr("list-sessions", err, out)
+	}
+	return parseSessionPaths(sanitizeOutput(out)), nil
+}
+
+// parseSessionPaths reads "name<TAB>start<TAB>pane" lines. A missing pane
+// column is tolerated; lines without a session name are skipped.
+func parseSessionPaths(out string) map[string]SessionPaths {
+	paths := make(map[string]SessionPaths)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(strings.TrimRight(line, "\r"), "\t")
+		if len(fields) < 2 || fields[0] == "" {
+			continue
+		}
+		sp := SessionPaths{Start: strings.TrimSpace(fields[1])}
+		if len(fields) > 2 {
+			sp.Pane = strings.TrimSpace(fields[2])
+		}
+		paths[fields[0]] = sp
+	}
+	return paths
+}
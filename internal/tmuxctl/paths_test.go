@@ -0,0 +1,34 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseSessionPaths(t *testing.T) {
+	out := "api\t/srv/api\t/srv/api/cmd\nweb\t/srv/web\n\nbroken\n\t/srv/none\t/srv/none\n"
+	got := parseSessionPaths(out)
+	want := map[string]SessionPaths{
+		"api": {Start: "/srv/api", Pane: "/srv/api/cmd"},
+		"web": {Start: "/srv/web"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSessionPaths() = %+v, want %+v", got, want)
+	}
+}
+
+func TestListSessionPaths(t *testing.T) {
+	c, calls := fakeClient("api\t/srv/api\t/srv/api\n")
+	got, err := c.ListSessionPaths(context.Background())
+	if err != nil {
+		t.Fatalf("ListSessionPaths() error: %v", err)
+	}
+	if got["api"].Start != "/srv/api" {
+		t.Errorf("ListSessionPaths() = %+v", got)
+	}
+	want := [][]string{{"list-sessions", "-F", "#{session_name}\t#{session_path}\t#{pane_current_path}"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
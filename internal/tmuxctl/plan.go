@@ -0,0 +1,142 @@
+package tmuxctl
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+// PlanStep is one tmux command of a session creation plan. Pane names the
+// shell variable that receives the id of the pane the command creates;
+// later steps target that pane as "$<name>".
+type PlanStep struct {
+	Pane string
+	Args []string
+}
+
+// paneVar matches a pane reference in PlanStep.Args.
+var paneVar = regexp.MustCompile(`^\$p[0-9]+$`)
+
+// CreatePlan returns the tmux commands that create session in dir with the
+// layout l, in the order peakypanes start runs them: windows and panes
+// first, then the pane titles and commands, then the project's options. It is what
+// start --dry-run prints.
+func CreatePlan(session, dir string, l *layout.LayoutConfig, defaultPaneCmd string, options map[string]string) []PlanStep {
+	if len(l.Windows) == 0 {
+		return nil
+	}
+	var plan []PlanStep
+	add := func(pane string, args ...string) {
+		plan = append(plan, PlanStep{Pane: pane, Args: args})
+	}
+	withDir := func(args []string) []string {
+		if dir != "" {
+			args = append(args, "-c", dir)
+		}
+		return args
+	}
+
+	next := 0
+	newPane := func() string {
+		name := "p" + strconv.Itoa(next)
+		next++
+		return name
+	}
+	paneIDs := make([][]string, len(l.Windows))
+
+	first := newPane()
+	args := []string{"new-session", "-d", "-s", session, "-P", "-F", "#{pane_id}"}
+	if l.Windows[0].Name != "" {
+		args = append(args, "-n", l.Windows[0].Name)
+	}
+	add(first, withDir(args)...)
+	add("", "set-option", "-t", session, "remain-on-exit", "off")
+	names := make([]string, 0, len(l.Settings.TmuxOptions))
+	for name := range l.Settings.TmuxOptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add("", "set-option", "-t", session, name, l.Settings.TmuxOptions[name])
+	}
+
+	for wi, win := range l.Windows {
+		pane := first
+		if wi > 0 {
+			pane = newPane()
+			args := []string{"new-window", "-t", session, "-P", "-F", "#{pane_id}"}
+			if win.Name != "" {
+				args = append(args, "-n", win.Name)
+			}
+			add(pane, withDir(args)...)
+		}
+		paneIDs[wi] = append(paneIDs[wi], "$"+pane)
+		for i := 1; i < len(win.Panes); i++ {
+			def := win.Panes[i]
+			orientation := "-h"
+			if def.Split == "vertical" || def.Split == "v" {
+				orientation = "-v"
+			}
+			split := newPane()
+			args := withDir([]string{"split-window", orientation, "-t", "$" + pane, "-P", "-F", "#{pane_id}"})
+			if percent, err := strconv.Atoi(strings.TrimSuffix(def.Size, "%")); err == nil && percent > 0 {
+				args = append(args, "-p", strconv.Itoa(percent))
+			}
+			add(split, args...)
+			paneIDs[wi] = append(paneIDs[wi], "$"+split)
+			pane = split
+		}
+		if win.Layout != "" {
+			add("", "select-layout", "-t", fmt.Sprintf("%s:%s", session, win.Name), win.Layout)
+		}
+	}
+
+	for _, title := range PaneTitles(l, paneIDs) {
+		add("", title.Args()...)
+	}
+	for _, step := range l.StepsWithDefault(defaultPaneCmd) {
+		add("", RunOnceArgs(paneIDs[step.Window][step.Pane], step.Cmd)...)
+	}
+	for _, args := range SetOptionCommands(session, options) {
+		add("", args...)
+	}
+	add("", "select-window", "-t", fmt.Sprintf("%s:%s", session, l.Windows[0].Name))
+	add("", "select-pane", "-t", "$"+first)
+	return plan
+}
+
+// Script renders plan as shell commands, one tmux invocation per line.
+// Commands that create a pane store its id in the step's variable.
+func Script(plan []PlanStep) string {
+	var b strings.Builder
+	for _, step := range plan {
+		words := make([]string, len(step.Args))
+		for i, arg := range step.Args {
+			words[i] = scriptWord(arg)
+		}
+		line := "tmux " + strings.Join(words, " ")
+		if step.Pane != "" {
+			line = step.Pane + "=$(" + line + ")"
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// plainWord matches arguments that need no quoting in a POSIX shell.
+var plainWord = regexp.MustCompile(`^[A-Za-z0-9_./:%@=+,-]+$`)
+
+func scriptWord(arg string) string {
+	switch {
+	case paneVar.MatchString(arg):
+		return `"` + arg + `"`
+	case plainWord.MatchString(arg):
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
@@ -0,0 +1,57 @@
+package tmuxctl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+func TestCreatePlan(t *testing.T) {
+	l := &layout.LayoutConfig{
+		Settings: layout.LayoutSettings{TmuxOptions: map[string]string{"history-limit": "50000"}},
+		Windows: []layout.WindowDef{
+			{Name: "dev", Layout: "main-vertical", Panes: []layout.PaneDef{
+				{Title: "editor", Cmd: "vim"},
+				{Title: "server", Cmd: "npm run dev", Split: "v", Size: "30%", Order: 1},
+			}},
+			{Name: "logs", Panes: []layout.PaneDef{{Cmd: "tail -f log"}}},
+		},
+	}
+	got := CreatePlan("api", "/srv/api", l, "", map[string]string{"mouse": "off"})
+	want := []PlanStep{
+		{Pane: "p0", Args: []string{"new-session", "-d", "-s", "api", "-P", "-F", "#{pane_id}", "-n", "dev", "-c", "/srv/api"}},
+		{Args: []string{"set-option", "-t", "api", "remain-on-exit", "off"}},
+		{Args: []string{"set-option", "-t", "api", "history-limit", "50000"}},
+		{Pane: "p1", Args: []string{"split-window", "-v", "-t", "$p0", "-P", "-F", "#{pane_id}", "-c", "/srv/api", "-p", "30"}},
+		{Args: []string{"select-layout", "-t", "api:dev", "main-vertical"}},
+		{Pane: "p2", Args: []string{"new-window", "-t", "api", "-P", "-F", "#{pane_id}", "-n", "logs", "-c", "/srv/api"}},
+		{Args: []string{"select-pane", "-t", "$p0", "-T", "editor"}},
+		{Args: []string{"select-pane", "-t", "$p1", "-T", "server"}},
+		{Args: []string{"send-keys", "-t", "$p1", "npm run dev", "Enter"}},
+		{Args: []string{"send-keys", "-t", "$p0", "vim", "Enter"}},
+		{Args: []string{"send-keys", "-t", "$p2", "tail -f log", "Enter"}},
+		{Args: []string{"set-option", "-t", "api", "mouse", "off"}},
+		{Args: []string{"select-window", "-t", "api:dev"}},
+		{Args: []string{"select-pane", "-t", "$p0"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CreatePlan() =\n%q\nwant\n%q", got, want)
+	}
+	if got := CreatePlan("api", "/srv/api", &layout.LayoutConfig{}, "", nil); got != nil {
+		t.Errorf("CreatePlan(no windows) = %q, want nil", got)
+	}
+}
+
+func TestScript(t *testing.T) {
+	plan := []PlanStep{
+		{Pane: "p0", Args: []string{"new-session", "-d", "-s", "api", "-P", "-F", "#{pane_id}", "-c", "/srv/my api"}},
+		{Args: []string{"send-keys", "-t", "$p0", "echo 'hi' $HOME", "Enter"}},
+	}
+	want := `p0=$(tmux new-session -d -s api -P -F '#{pane_id}' -c '/srv/my api')
+tmux send-keys -t "$p0" 'echo '\''hi'\'' $HOME' Enter
+`
+	if got := Script(plan); got != want {
+		t.Errorf("Script() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+package tmuxctl
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PopupMinVersion is the first tmux release with display-popup.
+var PopupMinVersion = Version{Major: 3, Minor: 2}
+
+// Version is a tmux release number as reported by `tmux -V`.
+type Version struct {
+	Major int
+	Minor int
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// AtLeast reports whether v is the same as or newer than min.
+func (v Version) AtLeast(min Version) bool {
+	if v.Major != min.Major {
+		return v.Major > min.Major
+	}
+	return v.Minor >= min.Minor
+}
+
+var versionRe = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// ParseVersion extracts the version from `tmux -V` output such as
+// "tmux 3.3a" or "tmux next-3.4". Development builds reporting "tmux master"
+// are treated as newer than any release.
+func ParseVersion(out string) (Version, error) {
+	out = strings.TrimSpace(out)
+	if strings.HasSuffix(out, "master") {
+		return Version{Major: 999}, nil
+	}
+	match := versionRe.FindStringSubmatch(out)
+	if match == nil {
+		return Version{}, fmt.Errorf("unrecognized tmux version %q", out)
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	return Version{Major: major, Minor: minor}, nil
+}
+
+// Version returns the version of the tmux binary.
+func (c *Client) Version(ctx context.Context) (Version, error) {
+	out, err := c.run(ctx, c.bin, "-V").CombinedOutput()
+	if err != nil {
+		return Version{}, wrapTmuxErr("-V", err, out)
+	}
+	return ParseVersion(sanitizeOutput(out))
+}
+
+// SupportsPopup reports whether the tmux binary provides display-popup.
+func (c *Client) SupportsPopup(ctx context.Context) (bool, error) {
+	v, err := c.Version(ctx)
+	if err != nil {
+		return false, err
+	}
+	return v.AtLeast(PopupMinVersion), nil
+}
+
+// PopupArgs returns the tmux arguments that open session in a popup over the
+// current client. The popup attaches a nested client, so TMUX is cleared for
+// the inner command; -E closes the popup once that client detaches. The
+// popup is sized with PopupSize when the client size is known, and to 90% of
+// the terminal otherwise.
+func (c *Client) PopupArgs(session string, client Size) []string {
+	width, height := "90%", "90%"
+	if size := PopupSize(client); size != (Size{}) {
+		width, height = strconv.Itoa(size.Width), strconv.Itoa(size.Height)
+	}
+	inner := fmt.Sprintf("TMUX= %s attach-session -t %s", shellQuote(c.bin), shellQuote("="+session))
+	return []string{"display-popup", "-E", "-w", width, "-h", height, "-T", " " + session + " ", inner}
+}
+
+// shellQuote quotes s for use as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
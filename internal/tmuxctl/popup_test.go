@@ -0,0 +1,72 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+	}{
+		{"tmux 3.2", Version{3, 2}},
+		{"tmux 3.3a\n", Version{3, 3}},
+		{"tmux next-3.5", Version{3, 5}},
+		{"tmux 2.9a", Version{2, 9}},
+		{"tmux master", Version{999, 0}},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseVersion(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseVersion("screen"); err == nil {
+		t.Error("ParseVersion(\"screen\") should fail")
+	}
+}
+
+func TestSupportsPopup(t *testing.T) {
+	tests := []struct {
+		out  string
+		want bool
+	}{
+		{"tmux 3.1c", false},
+		{"tmux 2.9", false},
+		{"tmux 3.2", true},
+		{"tmux 3.4", true},
+		{"tmux 4.0", true},
+	}
+	for _, tt := range tests {
+		c, calls := fakeClient(tt.out)
+		got, err := c.SupportsPopup(context.Background())
+		if err != nil {
+			t.Fatalf("SupportsPopup(%q) error: %v", tt.out, err)
+		}
+		if got != tt.want {
+			t.Errorf("SupportsPopup(%q) = %v, want %v", tt.out, got, tt.want)
+		}
+		if !reflect.DeepEqual(*calls, [][]string{{"-V"}}) {
+			t.Errorf("calls = %v", *calls)
+		}
+	}
+}
+
+func TestPopupArgs(t *testing.T) {
+	c := &Client{bin: "/usr/bin/tmux"}
+	got := c.PopupArgs("it's", Size{})
+	want := []string{
+		"display-popup", "-E", "-w", "90%", "-h", "90%", "-T", " it's ",
+		`TMUX= '/usr/bin/tmux' attach-session -t '=it'\''s'`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PopupArgs() =\n%q\nwant\n%q", got, want)
+	}
+
+	got = c.PopupArgs("proj", Size{Width: 200, Height: 50})
+	if got[3] != "180" || got[5] != "45" {
+		t.Errorf("PopupArgs() with client size = %q, want 180x45", got)
+	}
+}
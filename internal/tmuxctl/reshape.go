@@ -0,0 +1,64 @@
+package tmuxctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+// ReshapeCommands plans the tmux invocations that rearrange a window whose
+// panes are paneIDs (in index order) into win's pane arrangement. Missing
+// panes are split off the last pane in the window's current directory and
+// surplus panes are killed from the end; existing panes keep running. The
+// window layout (tiled when unset) is applied last.
+func ReshapeCommands(target string, paneIDs []string, win layout.WindowDef) [][]string {
+	want := len(win.Panes)
+	if want == 0 {
+		want = 1
+	}
+
+	var cmds [][]string
+	for i := len(paneIDs) - 1; i >= want; i-- {
+		cmds = append(cmds, []string{"kill-pane", "-t", paneIDs[i]})
+	}
+	for i := len(paneIDs); i < want; i++ {
+		orientation := "-h"
+		if split := win.Panes[i].Split; split == "vertical" || split == "v" {
+			orientation = "-v"
+		}
+		cmds = append(cmds, []string{"split-window", orientation, "-d", "-t", target, "-c", "#{pane_current_path}"})
+	}
+
+	layoutName := win.Layout
+	if layoutName == "" {
+		layoutName = "tiled"
+	}
+	cmds = append(cmds, []string{"select-layout", "-t", target, layoutName})
+	return cmds
+}
+
+// ReshapeWindow applies win's pane arrangement to the window at target,
+// e.g. "session:" for a session's active window. This is destructive:
+// panes beyond the layout's pane count are closed.
+func (c *Client) ReshapeWindow(ctx context.Context, target string, win layout.WindowDef) error {
+	if strings.TrimSpace(target) == "" {
+		return errors.New("reshape target cannot be empty")
+	}
+	out, err := c.run(ctx, c.bin, "list-panes", "-t", target, "-F", "#{pane_id}").CombinedOutput()
+	if err != nil {
+		return wrapTmuxErr("list-panes", err, out)
+	}
+	paneIDs := strings.Fields(sanitizeOutput(out))
+	if len(paneIDs) == 0 {
+		return fmt.Errorf("no panes found in %s", target)
+	}
+	for _, args := range ReshapeCommands(target, paneIDs, win) {
+		if out, err := c.run(ctx, c.bin, args...).CombinedOutput(); err != nil {
+			return wrapTmuxErr(args[0], err, out)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+func TestReshapeCommands(t *testing.T) {
+	threePanes := layout.WindowDef{
+		Name:   "dev",
+		Layout: "main-vertical",
+		Panes:  []layout.PaneDef{{}, {Split: "horizontal"}, {Split: "vertical"}},
+	}
+
+	tests := []struct {
+		name  string
+		panes []string
+		win   layout.WindowDef
+		want  [][]string
+	}{
+		{
+			name:  "grow",
+			panes: []string{"%1"},
+			win:   threePanes,
+			want: [][]string{
+				{"split-window", "-h", "-d", "-t", "s:", "-c", "#{pane_current_path}"},
+				{"split-window", "-v", "-d", "-t", "s:", "-c", "#{pane_current_path}"},
+				{"select-layout", "-t", "s:", "main-vertical"},
+			},
+		},
+		{
+			name:  "shrink",
+			panes: []string{"%1", "%2", "%3", "%4"},
+			win:   layout.WindowDef{Panes: []layout.PaneDef{{}, {}}},
+			want: [][]string{
+				{"kill-pane", "-t", "%4"},
+				{"kill-pane", "-t", "%3"},
+				{"select-layout", "-t", "s:", "tiled"},
+			},
+		},
+		{
+			name:  "same count",
+			panes: []string{"%1", "%2", "%3"},
+			win:   threePanes,
+			want:  [][]string{{"select-layout", "-t", "s:", "main-vertical"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ReshapeCommands("s:", tt.panes, tt.win)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReshapeCommands() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReshapeWindow(t *testing.T) {
+	c, calls := fakeClient("%1\n")
+	win := layout.WindowDef{Panes: []layout.PaneDef{{}, {}}}
+	if err := c.ReshapeWindow(context.Background(), "s:", win); err != nil {
+		t.Fatalf("ReshapeWindow() error: %v", err)
+	}
+	want := [][]string{
+		{"list-panes", "-t", "s:", "-F", "#{pane_id}"},
+		{"split-window", "-h", "-d", "-t", "s:", "-c", "#{pane_current_path}"},
+		{"select-layout", "-t", "s:", "tiled"},
+	}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
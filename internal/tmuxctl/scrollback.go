@@ -0,0 +1,57 @@
+package tmuxctl
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// PaneIDs returns the id of every pane in session, across all of its
+// windows.
+func (c *Client) PaneIDs(ctx context.Context, session string) ([]string, error) {
+	if session == "" {
+		return nil, errors.New("session name is required")
+	}
+	cmd := c.run(ctx, c.bin, "list-panes", "-s", "-t", session, "-F", "#{pane_id}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, wrapTmuxErr("list-panes", err, out)
+	}
+	var panes []string
+	for _, line := range strings.Split(sanitizeOutput(out), "\n") {
+		if pane := strings.TrimSpace(line); pane != "" {
+			panes = append(panes, pane)
+		}
+	}
+	return panes, nil
+}
+
+// ClearHistoryCommands returns the tmux commands that clear the scrollback
+// of each pane. With screen, clear is typed into the pane first so the
+// visible screen is emptied as well.
+func ClearHistoryCommands(panes []string, screen bool) [][]string {
+	var cmds [][]string
+	for _, pane := range panes {
+		if screen {
+			cmds = append(cmds, []string{"send-keys", "-t", pane, "clear", "Enter"})
+		}
+		cmds = append(cmds, []string{"clear-history", "-t", pane})
+	}
+	return cmds
+}
+
+// ClearHistory clears the scrollback of every pane of session, see
+// ClearHistoryCommands, and returns how many panes it cleared.
+func (c *Client) ClearHistory(ctx context.Context, session string, screen bool) (int, error) {
+	panes, err := c.PaneIDs(ctx, session)
+	if err != nil {
+		return 0, err
+	}
+	for _, args := range ClearHistoryCommands(panes, screen) {
+		out, err := c.run(ctx, c.bin, args...).CombinedOutput()
+		if err != nil {
+			return 0, wrapTmuxErr(args[0], err, out)
+		}
+	}
+	return len(panes), nil
+}
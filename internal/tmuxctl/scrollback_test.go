@@ -0,0 +1,52 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestClearHistoryCommands(t *testing.T) {
+	got := ClearHistoryCommands([]string{"%1", "%4"}, false)
+	want := [][]string{
+		{"clear-history", "-t", "%1"},
+		{"clear-history", "-t", "%4"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ClearHistoryCommands() = %q, want %q", got, want)
+	}
+
+	got = ClearHistoryCommands([]string{"%1", "%4"}, true)
+	want = [][]string{
+		{"send-keys", "-t", "%1", "clear", "Enter"},
+		{"clear-history", "-t", "%1"},
+		{"send-keys", "-t", "%4", "clear", "Enter"},
+		{"clear-history", "-t", "%4"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ClearHistoryCommands(screen) = %q, want %q", got, want)
+	}
+
+	if got := ClearHistoryCommands(nil, true); got != nil {
+		t.Errorf("ClearHistoryCommands(nil) = %q, want nil", got)
+	}
+}
+
+func TestClearHistory(t *testing.T) {
+	c, calls := fakeClient("%1\n%2\n")
+	n, err := c.ClearHistory(context.Background(), "api", false)
+	if err != nil {
+		t.Fatalf("ClearHistory() error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("ClearHistory() = %d, want 2", n)
+	}
+	want := [][]string{
+		{"list-panes", "-s", "-t", "api", "-F", "#{pane_id}"},
+		{"clear-history", "-t", "%1"},
+		{"clear-history", "-t", "%2"},
+	}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
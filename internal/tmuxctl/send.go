@@ -0,0 +1,65 @@
+package tmuxctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FirstPaneTarget returns the target of the first pane in a session's first
+// window, independent of base-index settings.
+func FirstPaneTarget(session string) string {
+	return session + ":^.{top-left}"
+}
+
+// ActivePaneTarget returns the target of the active pane in a session's
+// current window.
+func ActivePaneTarget(session string) string {
+	return session + ":"
+}
+
+// RunOnceArgs returns the send-keys arguments that type command into target
+// and press Enter.
+func RunOnceArgs(target, command string) []string {
+	return []string{"send-keys", "-t", target, command, "Enter"}
+}
+
+// RunOnce types command into the pane at target and presses Enter.
+func (c *Client) RunOnce(ctx context.Context, target, command string) error {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return errors.New("command cannot be empty")
+	}
+	args := RunOnceArgs(target, command)
+	return c.SendKeys(ctx, args[2], args[3:]...)
+}
+
+// SendStep is a command typed into a pane as part of an ordered sequence.
+type SendStep struct {
+	Target  string
+	Command string
+}
+
+// RunSteps types each step's command into its pane strictly in order,
+// pausing for delay between consecutive steps so a command can start before
+// the next one depends on it.
+func (c *Client) RunSteps(ctx context.Context, steps []SendStep, delay time.Duration) error {
+	sleep := c.sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	for i, step := range steps {
+		if i > 0 && delay > 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			sleep(delay)
+		}
+		if err := c.RunOnce(ctx, step.Target, step.Command); err != nil {
+			return fmt.Errorf("step %d (%s): %w", i+1, step.Target, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRunOnce(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   []string
+	}{
+		{"new session", FirstPaneTarget("api"), []string{"send-keys", "-t", "api:^.{top-left}", "npm test", "Enter"}},
+		{"running session", ActivePaneTarget("api"), []string{"send-keys", "-t", "api:", "npm test", "Enter"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, calls := fakeClient("")
+			if err := c.RunOnce(context.Background(), tt.target, "  npm test "); err != nil {
+				t.Fatalf("RunOnce() error: %v", err)
+			}
+			if !reflect.DeepEqual(*calls, [][]string{tt.want}) {
+				t.Errorf("calls = %q, want %q", *calls, tt.want)
+			}
+		})
+	}
+
+	c, calls := fakeClient("")
+	if err := c.RunOnce(context.Background(), "api:", "   "); err == nil || len(*calls) != 0 {
+		t.Errorf("RunOnce() with blank command = %v, calls %v; want error and no calls", err, *calls)
+	}
+}
+
+func TestRunSteps(t *testing.T) {
+	c, calls := fakeClient("")
+	var slept []time.Duration
+	c.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	steps := []SendStep{
+		{Target: "%1", Command: "npm run dev"},
+		{Target: "%2", Command: "tail -f server.log"},
+		{Target: "%1", Command: "echo ready"},
+	}
+	if err := c.RunSteps(context.Background(), steps, 250*time.Millisecond); err != nil {
+		t.Fatalf("RunSteps() error: %v", err)
+	}
+
+	want := [][]string{
+		{"send-keys", "-t", "%1", "npm run dev", "Enter"},
+		{"send-keys", "-t", "%2", "tail -f server.log", "Enter"},
+		{"send-keys", "-t", "%1", "echo ready", "Enter"},
+	}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+	if !reflect.DeepEqual(slept, []time.Duration{250 * time.Millisecond, 250 * time.Millisecond}) {
+		t.Errorf("delays = %v, want one between each pair of steps", slept)
+	}
+
+	slept = nil
+	if err := c.RunSteps(context.Background(), steps, 0); err != nil {
+		t.Fatalf("RunSteps() error: %v", err)
+	}
+	if len(slept) != 0 {
+		t.Errorf("zero delay slept %v", slept)
+	}
+}
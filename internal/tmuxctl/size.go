@@ -0,0 +1,121 @@
+package tmuxctl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+// Size is a terminal or pane size in cells.
+type Size struct {
+	Width  int
+	Height int
+}
+
+func (s Size) String() string {
+	return fmt.Sprintf("%dx%d", s.Width, s.Height)
+}
+
+// MinPaneSize is the smallest pane still usable for a shell prompt.
+var MinPaneSize = Size{Width: 20, Height: 5}
+
+// popupMinSize is the popup size aimed for on small clients, where 90% of
+// the terminal would leave the popup cramped.
+var popupMinSize = Size{Width: 80, Height: 24}
+
+// ClientSize returns the size of the current tmux client's terminal.
+func (c *Client) ClientSize(ctx context.Context) (Size, error) {
+	out, err := c.run(ctx, c.bin, "display-message", "-p", "#{client_width} #{client_height}").Output()
+	if err != nil {
+		return Size{}, wrapTmuxErr("display-message", err, nil)
+	}
+	return parseClientSize(sanitizeOutput(out))
+}
+
+// parseClientSize reads "width height" as printed by display-message.
+func parseClientSize(out string) (Size, error) {
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return Size{}, fmt.Errorf("unexpected client size %q", strings.TrimSpace(out))
+	}
+	width, errW := strconv.Atoi(fields[0])
+	height, errH := strconv.Atoi(fields[1])
+	if errW != nil || errH != nil || width <= 0 || height <= 0 {
+		return Size{}, fmt.Errorf("unexpected client size %q", strings.TrimSpace(out))
+	}
+	return Size{Width: width, Height: height}, nil
+}
+
+// PopupSize returns the popup dimensions for a client: 90% of the terminal,
+// grown towards 80x24 on small clients while leaving room for the border.
+// A zero client size yields a zero Size.
+func PopupSize(client Size) Size {
+	fit := func(total, min int) int {
+		n := total * 9 / 10
+		if n < min {
+			n = min
+		}
+		if n > total-2 {
+			n = total - 2
+		}
+		if n < 1 {
+			n = 1
+		}
+		return n
+	}
+	if client.Width <= 0 || client.Height <= 0 {
+		return Size{}
+	}
+	return Size{
+		Width:  fit(client.Width, popupMinSize.Width),
+		Height: fit(client.Height, popupMinSize.Height),
+	}
+}
+
+// PaneSizes estimates the size of each pane in win when created on a
+// client of the given size. Panes are split off the previous pane with
+// their size percentage (tmux defaults to half), matching how sessions are
+// built; one cell per split goes to the pane border.
+func PaneSizes(win layout.WindowDef, client Size) []Size {
+	if len(win.Panes) == 0 {
+		return []Size{client}
+	}
+	sizes := []Size{client}
+	for _, pane := range win.Panes[1:] {
+		percent := 50
+		if p, err := strconv.Atoi(strings.TrimSuffix(pane.Size, "%")); err == nil && p > 0 && p < 100 {
+			percent = p
+		}
+		cur := &sizes[len(sizes)-1]
+		next := *cur
+		if pane.Split == "vertical" || pane.Split == "v" {
+			next.Height = (cur.Height - 1) * percent / 100
+			cur.Height -= next.Height + 1
+		} else {
+			next.Width = (cur.Width - 1) * percent / 100
+			cur.Width -= next.Width + 1
+		}
+		sizes = append(sizes, next)
+	}
+	return sizes
+}
+
+// PaneSizeWarnings describes the panes of win that would be smaller than
+// MinPaneSize on the client. Windows with a named layout are skipped since
+// tmux rearranges their panes regardless of the split percentages.
+func PaneSizeWarnings(win layout.WindowDef, client Size) []string {
+	if win.Layout != "" || client.Width <= 0 || client.Height <= 0 {
+		return nil
+	}
+	var warnings []string
+	for i, size := range PaneSizes(win, client) {
+		if size.Width < MinPaneSize.Width || size.Height < MinPaneSize.Height {
+			warnings = append(warnings, fmt.Sprintf("window %s: pane %d would be %s on a %s client (minimum %s)",
+				win.Name, i+1, size, client, MinPaneSize))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,101 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+func TestParseClientSize(t *testing.T) {
+	tests := []struct {
+		out     string
+		want    Size
+		wantErr bool
+	}{
+		{out: "212 58\n", want: Size{Width: 212, Height: 58}},
+		{out: "80 24", want: Size{Width: 80, Height: 24}},
+		{out: "", wantErr: true},
+		{out: "80", wantErr: true},
+		{out: "wide tall", wantErr: true},
+		{out: "0 0", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseClientSize(tt.out)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseClientSize(%q) = %v, %v; want %v, err %v", tt.out, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestClientSize(t *testing.T) {
+	c, calls := fakeClient("120 40\n")
+	got, err := c.ClientSize(context.Background())
+	if err != nil || got != (Size{Width: 120, Height: 40}) {
+		t.Errorf("ClientSize() = %v, %v", got, err)
+	}
+	want := [][]string{{"display-message", "-p", "#{client_width} #{client_height}"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
+
+func TestPopupSize(t *testing.T) {
+	tests := []struct {
+		client Size
+		want   Size
+	}{
+		{client: Size{}, want: Size{}},
+		{client: Size{Width: 200, Height: 50}, want: Size{Width: 180, Height: 45}},
+		{client: Size{Width: 84, Height: 26}, want: Size{Width: 80, Height: 24}},
+		{client: Size{Width: 60, Height: 20}, want: Size{Width: 58, Height: 18}},
+	}
+	for _, tt := range tests {
+		if got := PopupSize(tt.client); got != tt.want {
+			t.Errorf("PopupSize(%v) = %v, want %v", tt.client, got, tt.want)
+		}
+	}
+}
+
+func TestPaneSizes(t *testing.T) {
+	win := layout.WindowDef{Name: "dev", Panes: []layout.PaneDef{
+		{},
+		{Split: "horizontal", Size: "30%"},
+		{Split: "vertical"},
+	}}
+	got := PaneSizes(win, Size{Width: 101, Height: 41})
+	want := []Size{{Width: 70, Height: 41}, {Width: 30, Height: 20}, {Width: 30, Height: 20}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PaneSizes() = %v, want %v", got, want)
+	}
+}
+
+func TestPaneSizeWarnings(t *testing.T) {
+	win := layout.WindowDef{Name: "dev", Panes: []layout.PaneDef{
+		{},
+		{Split: "horizontal", Size: "20%"},
+		{Split: "vertical", Size: "50%"},
+	}}
+
+	if w := PaneSizeWarnings(win, Size{Width: 200, Height: 50}); len(w) != 0 {
+		t.Errorf("large client warnings = %q, want none", w)
+	}
+
+	w := PaneSizeWarnings(win, Size{Width: 80, Height: 10})
+	if len(w) != 2 {
+		t.Fatalf("small client warnings = %q, want 2", w)
+	}
+	if !strings.Contains(w[0], "pane 2 would be 15x5 on a 80x10 client") {
+		t.Errorf("warning = %q", w[0])
+	}
+
+	if w := PaneSizeWarnings(win, Size{}); w != nil {
+		t.Errorf("unknown client size should not warn, got %q", w)
+	}
+	win.Layout = "tiled"
+	if w := PaneSizeWarnings(win, Size{Width: 80, Height: 10}); w != nil {
+		t.Errorf("named layouts should be skipped, got %q", w)
+	}
+}
@@ -0,0 +1,41 @@
+package tmuxctl
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SessionsCreated returns when each session was created, keyed by session
+// name. When no server is running the map is empty and the error is nil.
+func (c *Client) SessionsCreated(ctx context.Context) (map[string]time.Time, error) {
+	cmd := c.run(ctx, c.bin, "list-sessions", "-F", "#{session_name}\t#{session_created}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		msg := strings.ToLower(sanitizeOutput(out))
+		if strings.Contains(msg, "no server") || strings.Contains(msg, "failed to connect") {
+			return map[string]time.Time{}, nil
+		}
+		return nil, wrapTmuxErr("list-sessions", err, out)
+	}
+	return parseSessionsCreated(sanitizeOutput(out)), nil
+}
+
+// parseSessionsCreated reads "name<TAB>unix-seconds" lines, skipping lines
+// without a valid timestamp.
+func parseSessionsCreated(out string) map[string]time.Time {
+	created := make(map[string]time.Time)
+	for _, line := range strings.Split(out, "\n") {
+		session, stamp, ok := strings.Cut(strings.TrimRight(line, "\r"), "\t")
+		if !ok || session == "" {
+			continue
+		}
+		secs, err := strconv.ParseInt(strings.TrimSpace(stamp), 10, 64)
+		if err != nil || secs <= 0 {
+			continue
+		}
+		created[session] = time.Unix(secs, 0)
+	}
+	return created
+}
@@ -0,0 +1,35 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSessionsCreated(t *testing.T) {
+	out := "api\t1700000000\nweb\t1700003600\n\nbroken\nbad\tsoon\nzero\t0\n"
+	got := parseSessionsCreated(out)
+	want := map[string]time.Time{
+		"api": time.Unix(1700000000, 0),
+		"web": time.Unix(1700003600, 0),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSessionsCreated() = %v, want %v", got, want)
+	}
+}
+
+func TestSessionsCreated(t *testing.T) {
+	c, calls := fakeClient("api\t1700000000\n")
+	got, err := c.SessionsCreated(context.Background())
+	if err != nil {
+		t.Fatalf("SessionsCreated() error: %v", err)
+	}
+	if !got["api"].Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("SessionsCreated() = %v", got)
+	}
+	want := [][]string{{"list-sessions", "-F", "#{session_name}\t#{session_created}"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
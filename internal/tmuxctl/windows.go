@@ -0,0 +1,145 @@
+package tmuxctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ActiveWindow describes a session's current window.
+type ActiveWindow struct {
+	Index string
+	Name  string
+	// Windows is how many windows the session has in total.
+	Windows int
+}
+
+// Target returns the tmux target of the window, e.g. "api:2".
+func (w ActiveWindow) Target(session string) string {
+	return session + ":" + w.Index
+}
+
+// SessionActiveWindow returns the active window of session along with the
+// session's window count.
+func (c *Client) SessionActiveWindow(ctx context.Context, session string) (ActiveWindow, error) {
+	if session == "" {
+		return ActiveWindow{}, errors.New("session name is required")
+	}
+	out, err := c.run(ctx, c.bin, "display-message", "-p", "-t", session+":", "#{session_windows}\t#{window_index}\t#{window_name}").Output()
+	if err != nil {
+		return ActiveWindow{}, wrapTmuxErr("display-message", err, nil)
+	}
+	return parseActiveWindow(sanitizeOutput(out))
+}
+
+// parseActiveWindow reads "count<TAB>index<TAB>name" as printed by
+// display-message.
+func parseActiveWindow(out string) (ActiveWindow, error) {
+	line := strings.TrimRight(strings.TrimSpace(out), "\r")
+	parts := strings.SplitN(line, "\t", 3)
+	if len(parts) != 3 {
+		return ActiveWindow{}, fmt.Errorf("unexpected window info %q", line)
+	}
+	count, err := strconv.Atoi(parts[0])
+	if err != nil || count <= 0 || parts[1] == "" {
+		return ActiveWindow{}, fmt.Errorf("unexpected window info %q", line)
+	}
+	return ActiveWindow{Index: parts[1], Name: parts[2], Windows: count}, nil
+}
+
+// activeWindowsFormat prints one line per window for ActiveWindows.
+const activeWindowsFormat = "#{window_active}\t#{session_name}\t#{session_windows}\t#{window_index}\t#{window_name}"
+
+// ActiveWindows returns the active window of every session, keyed by
+// session name. When no server is running the map is empty and the error
+// is nil.
+func (c *Client) ActiveWindows(ctx context.Context) (map[string]ActiveWindow, error) {
+	cmd := c.run(ctx, c.bin, "list-windows", "-a", "-F", activeWindowsFormat)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		msg := strings.ToLower(sanitizeOutput(out))
+		if strings.Contains(msg, "no server") || strings.Contains(msg, "failed to connect") {
+			return map[string]ActiveWindow{}, nil
+		}
+		return nil, wrapTmuxErr("list-windows", err, out)
+	}
+	return parseActiveWindows(sanitizeOutput(out)), nil
+}
+
+// parseActiveWindows reads "active<TAB>session<TAB>count<TAB>index<TAB>name"
+// lines and keeps the active window of each session. Malformed lines are
+// skipped.
+func parseActiveWindows(out string) map[string]ActiveWindow {
+	windows := make(map[string]ActiveWindow)
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(strings.TrimRight(line, "\r"), "\t", 3)
+		if len(parts) != 3 || parts[0] != "1" || parts[1] == "" {
+			continue
+		}
+		w, err := parseActiveWindow(parts[2])
+		if err != nil {
+			continue
+		}
+		windows[parts[1]] = w
+	}
+	return windows
+}
+
+// Window is one window of a session, as listed by ListWindows.
+type Window struct {
+	Index  string
+	Name   string
+	Panes  int
+	Active bool
+}
+
+// Target returns the tmux target of the window, e.g. "api:2".
+func (w Window) Target(session string) string {
+	return session + ":" + w.Index
+}
+
+// ListWindows returns the windows of session in index order.
+func (c *Client) ListWindows(ctx context.Context, session string) ([]Window, error) {
+	if session == "" {
+		return nil, errors.New("session name is required")
+	}
+	cmd := c.run(ctx, c.bin, "list-windows", "-t", session+":", "-F", "#{window_index}\t#{window_name}\t#{window_panes}\t#{window_active}")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, wrapTmuxErr("list-windows", err, out)
+	}
+	return parseWindows(sanitizeOutput(out)), nil
+}
+
+// parseWindows reads "index<TAB>name<TAB>panes<TAB>active" lines. Malformed
+// lines are skipped.
+func parseWindows(out string) []Window {
+	var windows []Window
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.Split(strings.TrimRight(line, "\r"), "\t")
+		if len(parts) != 4 || parts[0] == "" {
+			continue
+		}
+		panes, err := strconv.Atoi(parts[2])
+		if err != nil {
+			continue
+		}
+		windows = append(windows, Window{Index: parts[0], Name: parts[1], Panes: panes, Active: parts[3] == "1"})
+	}
+	return windows
+}
+
+// SelectWindow makes target, e.g. "api:2", the current window of its
+// session, so the next attach lands on it.
+func (c *Client) SelectWindow(ctx context.Context, target string) error {
+	if target == "" {
+		return errors.New("window target is required")
+	}
+	out, err := c.run(ctx, c.bin, "select-window", "-t", target).CombinedOutput()
+	if err != nil {
+		return wrapTmuxErr("select-window", err, out)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package tmuxctl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseActiveWindow(t *testing.T) {
+	tests := []struct {
+		out     string
+		want    ActiveWindow
+		wantErr bool
+	}{
+		{out: "3\t1\tserver\n", want: ActiveWindow{Index: "1", Name: "server", Windows: 3}},
+		{out: "1\t0\tmy editor\n", want: ActiveWindow{Index: "0", Name: "my editor", Windows: 1}},
+		{out: "", wantErr: true},
+		{out: "x\t0\tmain", wantErr: true},
+		{out: "2\t\tmain", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseActiveWindow(tt.out)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseActiveWindow(%q) error = %v, wantErr %v", tt.out, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseActiveWindow(%q) = %+v, want %+v", tt.out, got, tt.want)
+		}
+	}
+}
+
+func TestSessionActiveWindow(t *testing.T) {
+	c, calls := fakeClient("2\t1\tlogs\n")
+	got, err := c.SessionActiveWindow(context.Background(), "api")
+	if err != nil {
+		t.Fatalf("SessionActiveWindow() error: %v", err)
+	}
+	if got.Target("api") != "api:1" || got.Windows != 2 {
+		t.Errorf("SessionActiveWindow() = %+v", got)
+	}
+	want := [][]string{{"display-message", "-p", "-t", "api:", "#{session_windows}\t#{window_index}\t#{window_name}"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
+
+func TestParseActiveWindows(t *testing.T) {
+	out := "0\tapi\t3\t0\tshell\n1\tapi\t3\t1\teditor\n0\tapi\t3\t2\tlogs\n1\tweb\t1\t0\tmy server\n1\t\t1\t0\torphan\n1\tbroken\tx\t0\tmain\n"
+	got := parseActiveWindows(out)
+	want := map[string]ActiveWindow{
+		"api": {Index: "1", Name: "editor", Windows: 3},
+		"web": {Index: "0", Name: "my server", Windows: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseActiveWindows() = %+v, want %+v", got, want)
+	}
+}
+
+func TestActiveWindows(t *testing.T) {
+	c, calls := fakeClient("1\tapi\t2\t1\teditor\n")
+	got, err := c.ActiveWindows(context.Background())
+	if err != nil {
+		t.Fatalf("ActiveWindows() error: %v", err)
+	}
+	if got["api"].Name != "editor" {
+		t.Errorf("ActiveWindows() = %+v", got)
+	}
+	want := [][]string{{"list-windows", "-a", "-F", activeWindowsFormat}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
+
+func TestParseWindows(t *testing.T) {
+	out := "0\teditor\t2\t0\n1\tmy server\t1\t1\n\nbroken\n2\tlogs\tx\t0\n"
+	got := parseWindows(out)
+	want := []Window{
+		{Index: "0", Name: "editor", Panes: 2},
+		{Index: "1", Name: "my server", Panes: 1, Active: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWindows() = %+v, want %+v", got, want)
+	}
+}
+
+func TestListWindows(t *testing.T) {
+	c, calls := fakeClient("0\teditor\t2\t1\n")
+	got, err := c.ListWindows(context.Background(), "api")
+	if err != nil {
+		t.Fatalf("ListWindows() error: %v", err)
+	}
+	if len(got) != 1 || got[0].Target("api") != "api:0" {
+		t.Errorf("ListWindows() = %+v", got)
+	}
+	want := [][]string{{"list-windows", "-t", "api:", "-F", "#{window_index}\t#{window_name}\t#{window_panes}\t#{window_active}"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
+
+func TestSelectWindow(t *testing.T) {
+	c, calls := fakeClient("")
+	if err := c.SelectWindow(context.Background(), Window{Index: "3"}.Target("api")); err != nil {
+		t.Fatalf("SelectWindow() error: %v", err)
+	}
+	want := [][]string{{"select-window", "-t", "api:3"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("calls = %q, want %q", *calls, want)
+	}
+}
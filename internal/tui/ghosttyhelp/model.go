@@ -1,6 +1,8 @@
 package ghosttyhelp
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -9,33 +11,152 @@ import (
 )
 
 type shortcut struct {
-	key  string
-	desc string
+	category string
+	key      string
+	desc     string
+	// needsPrefix is set when the key reaches tmux as its prefix followed
+	// by a binding, rather than being handled directly by the terminal.
+	needsPrefix bool
 }
 
-// Model renders a list of Ghostty -> tmux shortcuts.
+// prefixMarker flags prefixed shortcuts in tables that mix both kinds.
+const prefixMarker = "◆"
+
+// Shortcut categories, in display order.
+const (
+	categoryNavigation = "Navigation"
+	categoryWindows    = "Windows"
+	categoryPanes      = "Panes"
+	categoryMisc       = "Misc"
+)
+
+var categories = []string{categoryNavigation, categoryWindows, categoryPanes, categoryMisc}
+
+// Terminal names a terminal whose shortcut table the help view can show.
+type Terminal string
+
+const (
+	// TerminalGhostty is Ghostty with the peakypanes keybinds, where Cmd
+	// sends the tmux prefix.
+	TerminalGhostty Terminal = "ghostty"
+	// TerminalPlain is any terminal without remapped keys, using tmux's
+	// default prefix bindings.
+	TerminalPlain Terminal = "plain"
+)
+
+// DefaultTerminal is shown when no terminal is chosen.
+const DefaultTerminal = TerminalGhostty
+
+// table is the shortcut list for one terminal.
+type table struct {
+	title     string
+	note      string
+	shortcuts []shortcut
+}
+
+// mixed reports whether some, but not all, shortcuts need the prefix.
+// Only then is the prefix marker worth showing.
+func (t table) mixed() bool {
+	prefixed := 0
+	for _, s := range t.shortcuts {
+		if s.needsPrefix {
+			prefixed++
+		}
+	}
+	return prefixed > 0 && prefixed < len(t.shortcuts)
+}
+
+// Model renders a list of terminal -> tmux shortcuts.
 type Model struct {
 	width  int
 	height int
+	table  table
 }
 
 var shortcuts = []shortcut{
-	{"Cmd+H/J/K/L", "Navigate panes"},
-	{"Cmd+[ / ]", "Prev/next window"},
-	{"Cmd+T", "New window"},
-	{"Cmd+W", "Close window"},
-	{"Cmd+1…9", "Jump to window"},
-	{"Cmd+R", "Respawn pane"},
-	{"Cmd+Shift+W", "Kill session"},
-	{"Cmd+Shift+H/J/K/L", "Resize panes"},
-	{"Cmd+Backspace", "Clear line"},
-	{"Cmd+Shift+P", "Command palette"},
-	{"Cmd+I", "Toggle this help"},
+	{categoryNavigation, "Cmd+H/J/K/L", "Navigate panes", true},
+	{categoryNavigation, "Cmd+[ / ]", "Prev/next window", true},
+	{categoryWindows, "Cmd+T", "New window", true},
+	{categoryWindows, "Cmd+W", "Close window", true},
+	{categoryNavigation, "Cmd+1…9", "Jump to window", true},
+	{categoryPanes, "Cmd+R", "Respawn pane", true},
+	{categoryMisc, "Cmd+Shift+W", "Kill session", true},
+	{categoryPanes, "Cmd+Shift+H/J/K/L", "Resize panes", true},
+	{categoryMisc, "Cmd+Backspace", "Clear line", false},
+	{categoryMisc, "Cmd+Shift+P", "Command palette", true},
+	{categoryMisc, "Cmd+I", "Toggle this help", true},
+}
+
+// plainShortcuts are tmux's stock bindings, reached through the prefix.
+var plainShortcuts = []shortcut{
+	{categoryNavigation, "Prefix ←↑↓→", "Navigate panes", true},
+	{categoryNavigation, "Prefix p / n", "Prev/next window", true},
+	{categoryWindows, "Prefix c", "New window", true},
+	{categoryWindows, "Prefix &", "Close window", true},
+	{categoryNavigation, "Prefix 0…9", "Jump to window", true},
+	{categoryPanes, "Prefix x", "Close pane", true},
+	{categoryPanes, "Prefix Ctrl+←↑↓→", "Resize panes", true},
+	{categoryMisc, "Prefix d", "Detach", true},
+	{categoryMisc, "Prefix :", "Command prompt", true},
+	{categoryMisc, "Prefix ?", "List all bindings", true},
+}
+
+var tables = map[Terminal]table{
+	TerminalGhostty: {
+		title:     "⌨️  Ghostty → tmux",
+		note:      "Cmd sends tmux prefix automatically; unmarked keys go straight to the shell",
+		shortcuts: shortcuts,
+	},
+	TerminalPlain: {
+		title:     "⌨️  tmux",
+		note:      "Prefix is Ctrl+b unless your tmux.conf changes it",
+		shortcuts: plainShortcuts,
+	},
 }
 
-// NewModel creates a help view with the predefined shortcuts.
+// Terminals returns the names of the available shortcut tables, sorted.
+func Terminals() []string {
+	names := make([]string, 0, len(tables))
+	for t := range tables {
+		names = append(names, string(t))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveTerminal picks the shortcut table to show: the --term flag value
+// wins over the PEAKYPANES_TERM environment value, and DefaultTerminal is
+// used when both are empty. Names are matched case-insensitively. An
+// unknown name falls back to DefaultTerminal and returns an error
+// describing the valid choices.
+func ResolveTerminal(flag, env string) (Terminal, error) {
+	name := strings.TrimSpace(flag)
+	if name == "" {
+		name = strings.TrimSpace(env)
+	}
+	if name == "" {
+		return DefaultTerminal, nil
+	}
+	t := Terminal(strings.ToLower(name))
+	if _, ok := tables[t]; !ok {
+		return DefaultTerminal, fmt.Errorf("unknown terminal %q (choose %s)", name, strings.Join(Terminals(), ", "))
+	}
+	return t, nil
+}
+
+// NewModel creates a help view with the Ghostty shortcuts.
 func NewModel() Model {
-	return Model{}
+	return NewModelFor(DefaultTerminal)
+}
+
+// NewModelFor creates a help view with the shortcuts of t, falling back to
+// the default terminal for unknown names.
+func NewModelFor(t Terminal) Model {
+	tbl, ok := tables[t]
+	if !ok {
+		tbl = tables[DefaultTerminal]
+	}
+	return Model{table: tbl}
 }
 
 func (m Model) Init() tea.Cmd { return tea.ClearScreen }
@@ -59,19 +180,37 @@ func (m Model) View() string {
 	var b strings.Builder
 
 	// Title - using centralized theme
-	b.WriteString(theme.HelpTitle.Render("⌨️  Ghostty → tmux"))
+	b.WriteString(theme.HelpTitle.Render(m.table.title))
 	b.WriteString("\n\n")
 
-	// Shortcuts - using centralized theme
-	for _, s := range shortcuts {
-		b.WriteString(theme.ShortcutKey.Render(s.key))
-		b.WriteString(theme.ShortcutDesc.Render(s.desc))
+	// Shortcuts grouped by category - using centralized theme
+	mixed := m.table.mixed()
+	for i, category := range categories {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(theme.ShortcutCategory.Render(category))
 		b.WriteString("\n")
+		for _, s := range m.table.shortcuts {
+			if s.category != category {
+				continue
+			}
+			if mixed {
+				b.WriteString(theme.ShortcutNote.Render(marker(s)))
+			}
+			b.WriteString(theme.ShortcutKey.Render(s.key))
+			b.WriteString(theme.ShortcutDesc.Render(s.desc))
+			b.WriteString("\n")
+		}
 	}
 
 	// Footer note
 	b.WriteString("\n")
-	b.WriteString(theme.ShortcutNote.Render("Cmd sends tmux prefix automatically"))
+	note := m.table.note
+	if mixed {
+		note = prefixMarker + " " + note
+	}
+	b.WriteString(theme.ShortcutNote.Render(note))
 	b.WriteString("\n\n")
 
 	// Close hint
@@ -79,3 +218,11 @@ func (m Model) View() string {
 
 	return b.String()
 }
+
+// marker returns the prefix column for s: the marker or matching blanks.
+func marker(s shortcut) string {
+	if s.needsPrefix {
+		return prefixMarker + " "
+	}
+	return "  "
+}
@@ -0,0 +1,126 @@
+package ghosttyhelp
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestViewCategoryOrder tests category headers render in order
+func TestViewCategoryOrder(t *testing.T) {
+	view := NewModel().View()
+
+	last := -1
+	for _, category := range categories {
+		idx := strings.Index(view, category)
+		if idx < 0 {
+			t.Fatalf("View() missing category header %q", category)
+		}
+		if idx < last {
+			t.Errorf("category %q rendered out of order", category)
+		}
+		last = idx
+	}
+}
+
+// TestShortcutsCategorized tests every shortcut belongs to a known category
+func TestShortcutsCategorized(t *testing.T) {
+	known := make(map[string]bool)
+	for _, c := range categories {
+		known[c] = true
+	}
+	if len(shortcuts) != 11 {
+		t.Errorf("got %d shortcuts, want the 11 existing entries", len(shortcuts))
+	}
+	view := NewModel().View()
+	for _, s := range shortcuts {
+		if !known[s.category] {
+			t.Errorf("shortcut %q has unknown category %q", s.key, s.category)
+		}
+		if !strings.Contains(view, s.desc) {
+			t.Errorf("View() missing shortcut %q", s.desc)
+		}
+	}
+}
+
+// TestResolveTerminal tests flag/env selection and fallback
+func TestResolveTerminal(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    string
+		env     string
+		want    Terminal
+		wantErr bool
+	}{
+		{name: "unset", want: TerminalGhostty},
+		{name: "env", env: "plain", want: TerminalPlain},
+		{name: "flag beats env", flag: "ghostty", env: "plain", want: TerminalGhostty},
+		{name: "case insensitive", flag: " Plain ", want: TerminalPlain},
+		{name: "invalid flag", flag: "hyper", env: "plain", want: TerminalGhostty, wantErr: true},
+		{name: "invalid env", env: "hyper", want: TerminalGhostty, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveTerminal(tt.flag, tt.env)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResolveTerminal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTerminal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewModelForTable tests that each terminal renders its own table
+func TestNewModelForTable(t *testing.T) {
+	plain := NewModelFor(TerminalPlain).View()
+	if !strings.Contains(plain, "Prefix c") || strings.Contains(plain, "Cmd+T") {
+		t.Error("plain view should show prefix bindings only")
+	}
+	ghostty := NewModelFor(TerminalGhostty).View()
+	if !strings.Contains(ghostty, "Cmd+T") || strings.Contains(ghostty, "Prefix c") {
+		t.Error("ghostty view should show Cmd bindings only")
+	}
+	if NewModelFor("hyper").View() != NewModel().View() {
+		t.Error("unknown terminal should fall back to the default table")
+	}
+}
+
+// TestPrefixMarkers tests that mixed tables mark prefixed shortcuts and
+// explain the marker, while all-prefix tables skip it
+func TestPrefixMarkers(t *testing.T) {
+	ghostty := NewModelFor(TerminalGhostty).View()
+	for _, line := range strings.Split(ghostty, "\n") {
+		switch {
+		case strings.Contains(line, "New window"):
+			if !strings.Contains(line, prefixMarker) {
+				t.Errorf("prefixed shortcut line %q has no marker", line)
+			}
+		case strings.Contains(line, "Clear line"):
+			if strings.Contains(line, prefixMarker) {
+				t.Errorf("direct shortcut line %q is marked", line)
+			}
+		}
+	}
+	if !strings.Contains(ghostty, prefixMarker+" Cmd sends tmux prefix") {
+		t.Error("footer should explain the prefix marker")
+	}
+
+	plain := NewModelFor(TerminalPlain).View()
+	if strings.Contains(plain, prefixMarker) {
+		t.Error("plain view needs the prefix everywhere and should not mark keys")
+	}
+}
+
+// TestTableMixed tests detection of tables mixing prefixed and direct keys
+func TestTableMixed(t *testing.T) {
+	if !tables[TerminalGhostty].mixed() {
+		t.Error("ghostty table should be mixed")
+	}
+	if tables[TerminalPlain].mixed() {
+		t.Error("plain table should not be mixed")
+	}
+	if (table{}).mixed() {
+		t.Error("empty table should not be mixed")
+	}
+}
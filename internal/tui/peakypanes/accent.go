@@ -0,0 +1,58 @@
+package peakypanes
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// A project's color tints its status icon whatever the status, so related
+// projects can be told apart at a glance. It is independent of the tag
+// color, which tints the name.
+
+var hexColor = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validColor reports whether s is a color lipgloss understands: #rgb,
+// #rrggbb or an ANSI color number from 0 to 255.
+func validColor(s string) bool {
+	if hexColor.MatchString(s) {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255 && !strings.HasPrefix(s, "+")
+}
+
+// accentColor returns p's color, or "" when it has none or it is invalid.
+func (p Project) accentColor() lipgloss.Color {
+	if !validColor(p.Color) {
+		return ""
+	}
+	return lipgloss.Color(p.Color)
+}
+
+// iconStyle returns the style of p's status icon, tinted by its accent.
+func iconStyle(p Project) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	if c := p.accentColor(); c != "" {
+		style = style.Foreground(c)
+	}
+	return style
+}
+
+// colorWarning names the projects whose color is not a valid color. Their
+// icons are left untinted.
+func colorWarning(projects []Project) error {
+	var parts []string
+	for _, p := range projects {
+		if p.Color != "" && !validColor(p.Color) {
+			parts = append(parts, fmt.Sprintf("%q (%s)", p.Color, p.Name))
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid project color %s", strings.Join(parts, ", "))
+}
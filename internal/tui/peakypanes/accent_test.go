@@ -0,0 +1,94 @@
+package peakypanes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestValidColor(t *testing.T) {
+	tests := map[string]bool{
+		"#ff8800": true,
+		"#F80":    true,
+		"208":     true,
+		"0":       true,
+		"":        false,
+		"ff8800":  false,
+		"#ff880":  false,
+		"#gg8800": false,
+		"256":     false,
+		"-1":      false,
+		"+5":      false,
+		"orange":  false,
+	}
+	for color, want := range tests {
+		if got := validColor(color); got != want {
+			t.Errorf("validColor(%q) = %v, want %v", color, got, want)
+		}
+	}
+}
+
+// TestIconStyleAccent tests that the project color tints the status icon
+// whatever the status, and that invalid colors leave it plain
+func TestIconStyleAccent(t *testing.T) {
+	for _, status := range []Status{StatusStopped, StatusRunning, StatusMissing} {
+		p := Project{Name: "api", Status: status, Color: "#ff8800"}
+		if got := iconStyle(p).GetForeground(); got != lipgloss.Color("#ff8800") {
+			t.Errorf("icon foreground for %v = %v, want #ff8800", status, got)
+		}
+	}
+	for _, color := range []string{"", "orange"} {
+		p := Project{Name: "api", Color: color}
+		if _, ok := iconStyle(p).GetForeground().(lipgloss.NoColor); !ok {
+			t.Errorf("icon foreground with color %q = %v, want none", color, iconStyle(p).GetForeground())
+		}
+	}
+}
+
+// TestRenderTitleInvalidColor tests that an invalid color renders the
+// default title
+func TestRenderTitleInvalidColor(t *testing.T) {
+	p := Project{Name: "api", Status: StatusStopped, glyphs: GlyphsASCII, Color: "orange"}
+	plain := p
+	plain.Color = ""
+	if got, want := renderTitle(p, nil), renderTitle(plain, nil); got != want {
+		t.Errorf("renderTitle() = %q, want %q", got, want)
+	}
+}
+
+func TestColorWarning(t *testing.T) {
+	projects := []Project{
+		{Name: "api", Color: "#ff8800"},
+		{Name: "web", Color: "orange"},
+		{Name: "docs"},
+		{Name: "ops", Color: "#12345"},
+	}
+	err := colorWarning(projects)
+	if err == nil {
+		t.Fatal("colorWarning() = nil, want an error")
+	}
+	want := `invalid project color "orange" (web), "#12345" (ops)`
+	if err.Error() != want {
+		t.Errorf("colorWarning() = %q, want %q", err, want)
+	}
+	if err := colorWarning(projects[:1]); err != nil {
+		t.Errorf("colorWarning(valid) = %v, want nil", err)
+	}
+}
+
+// TestConfigProjectColor tests that color is read from the config and an
+// invalid one is reported
+func TestConfigProjectColor(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	m.projects, _ = configProjects(config{Projects: []projectConfig{
+		{Name: "api", Path: "/srv/api", Color: "#ff8800"},
+		{Name: "web", Path: "/srv/web", Color: "nope"},
+	}})
+	if m.projects[0].Color != "#ff8800" {
+		t.Errorf("api color = %q, want #ff8800", m.projects[0].Color)
+	}
+	if w := m.configWarning(); !strings.Contains(w, `invalid project color "nope" (web) (ignored)`) {
+		t.Errorf("configWarning() = %q, want the invalid color", w)
+	}
+}
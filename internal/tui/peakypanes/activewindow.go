@@ -0,0 +1,33 @@
+package peakypanes
+
+import (
+	"context"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// windowLabel formats the window a session shows for the project
+// description, e.g. "on: editor". Sessions with a single window get no
+// label: there is nowhere else to land. Unnamed windows show their index.
+func windowLabel(w tmuxctl.ActiveWindow) string {
+	if w.Windows < 2 {
+		return ""
+	}
+	if w.Name == "" {
+		return "on: #" + w.Index
+	}
+	return "on: " + w.Name
+}
+
+// refreshWindows records the active window of each running project. It is
+// best effort: on error the previous values are cleared.
+func (m *Model) refreshWindows(ctx context.Context) {
+	windows, _ := m.tmux.ActiveWindows(ctx)
+	for i := range m.projects {
+		p := &m.projects[i]
+		p.Window = tmuxctl.ActiveWindow{}
+		if p.Status.running() {
+			p.Window = windows[p.Session]
+		}
+	}
+}
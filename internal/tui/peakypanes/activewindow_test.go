@@ -0,0 +1,50 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// TestWindowLabel tests the active window label of the description
+func TestWindowLabel(t *testing.T) {
+	tests := []struct {
+		w    tmuxctl.ActiveWindow
+		want string
+	}{
+		{tmuxctl.ActiveWindow{Index: "1", Name: "editor", Windows: 3}, "on: editor"},
+		{tmuxctl.ActiveWindow{Index: "2", Windows: 3}, "on: #2"},
+		{tmuxctl.ActiveWindow{Index: "0", Name: "main", Windows: 1}, ""},
+		{tmuxctl.ActiveWindow{}, ""},
+	}
+	for _, tt := range tests {
+		if got := windowLabel(tt.w); got != tt.want {
+			t.Errorf("windowLabel(%+v) = %q, want %q", tt.w, got, tt.want)
+		}
+	}
+}
+
+// TestRefreshWindows tests that a running project shows its named active
+// window and a stopped one shows none
+func TestRefreshWindows(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning},
+		{Name: "web", Session: "web", Path: "/srv/web", Status: StatusStopped},
+	})
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "printf", "0\tapi\t2\t0\tshell\n1\tapi\t2\t1\teditor\n1\tweb\t2\t0\tmain\n")
+	})
+
+	m.refreshWindows(context.Background())
+	if got := m.projects[0].Window; got.Name != "editor" || got.Index != "1" {
+		t.Errorf("api window = %+v, want editor", got)
+	}
+	if got := m.projects[1].Window; got != (tmuxctl.ActiveWindow{}) {
+		t.Errorf("stopped project window = %+v, want none", got)
+	}
+	if desc := m.projects[0].Description(); desc != "/srv/api · on: editor" {
+		t.Errorf("Description() = %q", desc)
+	}
+}
@@ -0,0 +1,133 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// adoptionCandidates matches stopped projects to live sessions that were
+// created under a different name, e.g. by hand with `tmux new -s`. A live
+// session matches when its start directory or active pane path is the
+// project's path. Sessions that already belong to a configured project are
+// never offered. The result maps project session to live session name.
+func adoptionCandidates(projects []Project, paths map[string]tmuxctl.SessionPaths) map[string]string {
+	owned := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		if p.Path != "" {
+			owned[p.Session] = true
+		}
+	}
+	var live []string
+	for s := range paths {
+		if !owned[s] {
+			live = append(live, s)
+		}
+	}
+	sort.Strings(live)
+
+	candidates := make(map[string]string)
+	taken := make(map[string]bool)
+	for _, p := range projects {
+		if p.Path == "" || p.Status.running() {
+			continue
+		}
+		for _, s := range live {
+			sp := paths[s]
+			if taken[s] || !(samePath(sp.Start, p.Path) || samePath(sp.Pane, p.Path)) {
+				continue
+			}
+			candidates[p.Session] = s
+			taken[s] = true
+			break
+		}
+	}
+	return candidates
+}
+
+// refreshAdoptions records which stopped projects could adopt a live
+// session. It is best effort: on error no adoptions are offered.
+func (m *Model) refreshAdoptions(ctx context.Context) {
+	paths, _ := m.tmux.ListSessionPaths(ctx)
+	candidates := adoptionCandidates(m.projects, paths)
+	for i := range m.projects {
+		m.projects[i].Adoptable = candidates[m.projects[i].Session]
+	}
+}
+
+// adoptDialog asks before pointing p at the live session it matched.
+func adoptDialog(p Project) *confirmDialog {
+	return &confirmDialog{
+		title:  "🔗 Adopt Session?",
+		action: "Adopt",
+		fields: []confirmField{{"Project", p.Name}, {"Session", p.Session + " → " + p.Adoptable}},
+		note:   "The running session works in this project's path; the config will use its name",
+		prompt: "adopt",
+		data:   promptDataFor(p),
+		result: func(m Model, confirmed bool) (tea.Model, tea.Cmd) {
+			if !confirmed {
+				return m, nil
+			}
+			return m.adoptSession(p)
+		},
+	}
+}
+
+// startAdopt opens the adopt dialog for the selected project.
+func (m Model) startAdopt() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	if item.Adoptable == "" {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("No running session matches this project's path"))
+	}
+	return m, m.openConfirm(adoptDialog(item))
+}
+
+// adoptSession renames p's session to the live session it matched, in
+// memory and in the config file.
+func (m Model) adoptSession(p Project) (tea.Model, tea.Cmd) {
+	old, live := p.Session, p.Adoptable
+	found := false
+	for i := range m.projects {
+		if m.projects[i].Session == old && m.projects[i].Path != "" {
+			m.projects[i].Session = live
+			m.projects[i].Adoptable = ""
+			found = true
+			break
+		}
+	}
+	if !found {
+		return m, m.list.NewStatusMessage(FormatStatusError(fmt.Errorf("project %q not found", p.Name)))
+	}
+	_ = m.refreshStatuses()
+	m.list.SetItems(m.projectsToItems())
+	write := func(m *Model) error { return m.saveProjectSession(old, live) }
+	if cmd := m.saveConfig(write); cmd != nil || m.confirmSave {
+		return m, cmd
+	}
+	return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Adopted session %s for %s", live, p.Name)))
+}
+
+// saveProjectSession sets the session key of the project entry that
+// currently resolves to old.
+func (m *Model) saveProjectSession(old, session string) error {
+	return m.updateConfigFile(func(doc *yaml.Node) error {
+		if seq := projectsNode(doc, false); seq != nil {
+			for _, n := range seq.Content {
+				if n.Kind == yaml.MappingNode && projectNodeSession(n, m.sessionPrefix) == old {
+					v := mappingValue(n, "session", yaml.ScalarNode, true)
+					v.Tag, v.Value = "!!str", session
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("project with session %q is not in %s", old, m.configPath)
+	})
+}
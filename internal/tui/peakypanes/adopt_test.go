@@ -0,0 +1,87 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// TestAdoptionCandidates tests matching stopped projects to live sessions by path
+func TestAdoptionCandidates(t *testing.T) {
+	projects := []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Status: StatusStopped},
+		{Name: "web", Session: "web", Path: "/srv/web/", Status: StatusStopped},
+		{Name: "cli", Session: "cli", Path: "/srv/cli", Status: StatusRunning},
+		{Name: "docs", Session: "docs", Path: "/srv/docs", Status: StatusStopped},
+	}
+	paths := map[string]tmuxctl.SessionPaths{
+		"my-api": {Start: "/srv/api", Pane: "/tmp"},       // start dir matches
+		"w":      {Start: "/home/me", Pane: "/srv/web"},   // active pane matches
+		"cli-2":  {Start: "/srv/cli"},                     // project already running
+		"cli":    {Start: "/srv/docs"},                    // owned by a configured project
+		"misc":   {Start: "/srv/other", Pane: "/srv/etc"}, // no match
+	}
+	got := adoptionCandidates(projects, paths)
+	want := map[string]string{"api": "my-api", "web": "w"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("adoptionCandidates() = %v, want %v", got, want)
+	}
+}
+
+// TestAdoptionCandidatesOnce tests that a live session is offered to one project only
+func TestAdoptionCandidatesOnce(t *testing.T) {
+	projects := []Project{
+		{Name: "a", Session: "a", Path: "/srv/x"},
+		{Name: "b", Session: "b", Path: "/srv/x"},
+	}
+	paths := map[string]tmuxctl.SessionPaths{"x": {Start: "/srv/x"}}
+	got := adoptionCandidates(projects, paths)
+	if !reflect.DeepEqual(got, map[string]string{"a": "x"}) {
+		t.Errorf("adoptionCandidates() = %v", got)
+	}
+}
+
+// TestAdoptSession tests that adopting rewrites the project's session in the config
+func TestAdoptSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	content := `projects:
+  - name: api
+    path: /srv/api
+  - name: web
+    path: /srv/web
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Adoptable: "my-api"},
+		{Name: "web", Session: "web", Path: "/srv/web"},
+	})
+	m.configPath = path
+
+	model := press(t, *m, "A")
+	if model.state != StateConfirm || model.confirm == nil {
+		t.Fatalf("state = %v, want StateConfirm", model.state)
+	}
+	model = press(t, model, "y")
+	if model.projects[0].Session != "my-api" {
+		t.Errorf("session = %q, want my-api", model.projects[0].Session)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Projects[0].Session != "my-api" || cfg.Projects[1].Session != "" {
+		t.Errorf("saved projects = %+v", cfg.Projects)
+	}
+}
@@ -0,0 +1,69 @@
+package peakypanes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoProjectMatch is returned by FindProject when no configured project
+// matches the query.
+var ErrNoProjectMatch = errors.New("no matching project")
+
+// AmbiguousProjectError is returned by FindProject when several configured
+// projects match the query.
+type AmbiguousProjectError struct {
+	Query      string
+	Candidates []Project
+}
+
+func (e *AmbiguousProjectError) Error() string {
+	names := make([]string, len(e.Candidates))
+	for i, p := range e.Candidates {
+		names[i] = p.Name
+	}
+	return fmt.Sprintf("%q matches several projects: %s", e.Query, strings.Join(names, ", "))
+}
+
+// matches reports whether query names p by name, session or alias,
+// ignoring case.
+func (p Project) matches(query string) bool {
+	if strings.EqualFold(p.Name, query) || strings.EqualFold(p.Session, query) {
+		return true
+	}
+	for _, alias := range p.Aliases {
+		if strings.EqualFold(alias, query) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchProjects returns the configured projects that query names.
+func matchProjects(projects []Project, query string) []Project {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+	var matched []Project
+	for _, p := range projects[:configuredCount(projects)] {
+		if p.matches(query) {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
+
+// FindProject returns the configured project named by query, matching
+// names, session names and aliases case-insensitively.
+func (m *Model) FindProject(query string) (Project, error) {
+	matched := matchProjects(m.projects, query)
+	switch len(matched) {
+	case 0:
+		return Project{}, ErrNoProjectMatch
+	case 1:
+		return matched[0], nil
+	default:
+		return Project{}, &AmbiguousProjectError{Query: query, Candidates: matched}
+	}
+}
@@ -0,0 +1,62 @@
+package peakypanes
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestFindProject tests matching by name, session and alias
+func TestFindProject(t *testing.T) {
+	m := &Model{projects: []Project{
+		{Name: "API Gateway", Session: "api-gateway", Path: "/srv/api", Aliases: []string{"api", "gw"}},
+		{Name: "Web", Session: "web", Path: "/srv/web"},
+		{Name: "Docs", Session: "docs", Path: "/srv/docs", Aliases: []string{"WEB-DOCS"}},
+		{Name: "stray"}, // unconfigured running session
+	}}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "api", want: "API Gateway"},
+		{query: "GW", want: "API Gateway"},
+		{query: "api gateway", want: "API Gateway"},
+		{query: "api-gateway", want: "API Gateway"},
+		{query: "web", want: "Web"},
+		{query: "web-docs", want: "Docs"},
+		{query: " docs ", want: "Docs"},
+	}
+	for _, tt := range tests {
+		got, err := m.FindProject(tt.query)
+		if err != nil || got.Name != tt.want {
+			t.Errorf("FindProject(%q) = %q, %v; want %q", tt.query, got.Name, err, tt.want)
+		}
+	}
+
+	for _, query := range []string{"nope", "", "stray"} {
+		if _, err := m.FindProject(query); !errors.Is(err, ErrNoProjectMatch) {
+			t.Errorf("FindProject(%q) error = %v, want ErrNoProjectMatch", query, err)
+		}
+	}
+}
+
+// TestFindProjectAmbiguous tests that several matches are reported as candidates
+func TestFindProjectAmbiguous(t *testing.T) {
+	m := &Model{projects: []Project{
+		{Name: "API Gateway", Session: "api-gateway", Path: "/srv/api", Aliases: []string{"api"}},
+		{Name: "api", Session: "api", Path: "/srv/api-legacy"},
+		{Name: "Web", Session: "web", Path: "/srv/web"},
+	}}
+
+	_, err := m.FindProject("API")
+	var ambiguous *AmbiguousProjectError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("FindProject() error = %v, want AmbiguousProjectError", err)
+	}
+	if len(ambiguous.Candidates) != 2 || ambiguous.Candidates[0].Name != "API Gateway" || ambiguous.Candidates[1].Name != "api" {
+		t.Errorf("candidates = %+v", ambiguous.Candidates)
+	}
+	if want := `"API" matches several projects: API Gateway, api`; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,92 @@
+package peakypanes
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// Archived projects stay in the config but are left out of the list and of
+// status refreshes until shown with the showArchived toggle.
+
+// listedProjects returns the projects the list shows: archived ones only
+// when showArchived is set.
+func listedProjects(projects []Project, showArchived bool) []Project {
+	if showArchived {
+		return projects
+	}
+	var listed []Project
+	for _, p := range projects {
+		if !p.Archived {
+			listed = append(listed, p)
+		}
+	}
+	return listed
+}
+
+// toggleArchive archives the selected project, or restores it when it is
+// archived, in memory and in the config file.
+func (m Model) toggleArchive() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	if item.Path == "" || item.Scratch || item.transient() {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Only configured projects can be archived"))
+	}
+	archived := !item.Archived
+	for i := range m.projects {
+		if m.projects[i].Session == item.Session && m.projects[i].Path != "" {
+			m.projects[i].Archived = archived
+			break
+		}
+	}
+	_ = m.refreshStatuses()
+	m.list.SetItems(m.projectsToItems())
+	m.selectSession(item.Session)
+
+	write := func(m *Model) error { return m.saveProjectArchived(item.savedSession(), archived) }
+	if cmd := m.saveConfig(write); cmd != nil || m.confirmSave {
+		return m, cmd
+	}
+	msg := "Restored " + item.Name
+	if archived {
+		msg = fmt.Sprintf("Archived %s · %s shows archived", item.Name, m.keys.showArchived.Help().Key)
+	}
+	return m, m.list.NewStatusMessage(FormatStatusSuccess(msg))
+}
+
+// toggleShowArchived shows or hides archived projects in the list.
+func (m Model) toggleShowArchived() (tea.Model, tea.Cmd) {
+	m.showArchived = !m.showArchived
+	m.savePreferences()
+	_ = m.refreshStatuses()
+	m.list.SetItems(m.projectsToItems())
+	if m.showArchived {
+		return m, m.list.NewStatusMessage(FormatStatusInfo("Showing archived projects"))
+	}
+	return m, m.list.NewStatusMessage(FormatStatusInfo("Hiding archived projects"))
+}
+
+// saveProjectArchived sets or removes the archived key of the project entry
+// that resolves to session.
+func (m *Model) saveProjectArchived(session string, archived bool) error {
+	return m.updateConfigFile(func(doc *yaml.Node) error {
+		if seq := projectsNode(doc, false); seq != nil {
+			for _, n := range seq.Content {
+				if n.Kind != yaml.MappingNode || projectNodeSession(n, m.sessionPrefix) != session {
+					continue
+				}
+				if archived {
+					v := mappingValue(n, "archived", yaml.ScalarNode, true)
+					v.Tag, v.Value = "!!bool", "true"
+				} else {
+					deleteMappingKey(n, "archived")
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("project with session %q is not in %s", session, m.configPath)
+	})
+}
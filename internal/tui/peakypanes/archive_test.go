@@ -0,0 +1,98 @@
+package peakypanes
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// listedNames returns the names of the projects shown in the list.
+func listedNames(m Model) string {
+	var names []string
+	for _, item := range m.list.Items() {
+		if p, ok := item.(Project); ok {
+			names = append(names, p.Name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// TestArchivedHiddenByDefault tests that archived projects are left out of
+// the default list and shown with the toggle
+func TestArchivedHiddenByDefault(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/src/api"},
+		{Name: "old", Session: "old", Path: "/src/old", Archived: true},
+	})
+	m.list.SetItems(m.projectsToItems())
+	if got := listedNames(*m); got != "api" {
+		t.Errorf("default list = %s, want api", got)
+	}
+
+	model := press(t, *m, "H")
+	if got := listedNames(model); got != "api,old" {
+		t.Errorf("list showing archived = %s, want api,old", got)
+	}
+	if desc := model.list.Items()[1].(Project).Description(); !strings.Contains(desc, "archived") {
+		t.Errorf("archived project description = %q", desc)
+	}
+}
+
+// TestToggleArchive tests archiving and restoring the selected project in
+// memory and in the config file
+func TestToggleArchive(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/src/api"},
+		{Name: "web", Session: "web", Path: "/src/web"},
+	})
+	cfg := "projects:\n  - name: api\n    path: /src/api\n  - name: web\n    path: /src/web\n"
+	if err := os.WriteFile(m.configPath, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	model := press(t, *m, "X")
+	if got := listedNames(model); got != "web" {
+		t.Errorf("list after archiving api = %s, want web", got)
+	}
+	data, _ := os.ReadFile(m.configPath)
+	if !strings.Contains(string(data), "archived: true") {
+		t.Errorf("config not marked archived:\n%s", data)
+	}
+
+	model = press(t, model, "H")
+	model.selectSession("api")
+	model = press(t, model, "X", "H")
+	if got := listedNames(model); got != "api,web" {
+		t.Errorf("list after restoring api = %s, want api,web", got)
+	}
+	data, _ = os.ReadFile(m.configPath)
+	if strings.Contains(string(data), "archived") {
+		t.Errorf("archived key left after restoring:\n%s", data)
+	}
+}
+
+// TestRefreshSkipsArchived tests that an archived project's session is not
+// checked by the status refresh
+func TestRefreshSkipsArchived(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/src/api"},
+		{Name: "old", Session: "old", Path: "/src/old", Archived: true},
+	})
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		if args[0] == "list-sessions" {
+			return exec.CommandContext(ctx, "printf", "api\nold\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+	if err := m.refreshStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	if m.projects[0].Status != StatusRunning {
+		t.Errorf("api status = %v, want running", m.projects[0].Status)
+	}
+	if len(m.projects) != 2 || m.projects[1].Status != StatusStopped {
+		t.Errorf("archived project = %+v, want it kept and not refreshed", m.projects[1:])
+	}
+}
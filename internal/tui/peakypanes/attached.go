@@ -0,0 +1,28 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+)
+
+// formatClients renders the attached client count of a session, e.g.
+// "👥2". Sessions nobody is attached to show nothing.
+func formatClients(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("👥%d", n)
+}
+
+// refreshClients records how many clients are attached to each running
+// project's session. It is best effort: on error the counts are cleared.
+func (m *Model) refreshClients(ctx context.Context) {
+	attached, _ := m.tmux.SessionsAttached(ctx)
+	for i := range m.projects {
+		p := &m.projects[i]
+		p.Clients = 0
+		if p.Status.running() {
+			p.Clients = attached[p.Session]
+		}
+	}
+}
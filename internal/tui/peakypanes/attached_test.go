@@ -0,0 +1,52 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+)
+
+// TestFormatClients tests zero, one and several attached clients
+func TestFormatClients(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{-1, ""},
+		{0, ""},
+		{1, "👥1"},
+		{2, "👥2"},
+		{12, "👥12"},
+	}
+	for _, tt := range tests {
+		if got := formatClients(tt.n); got != tt.want {
+			t.Errorf("formatClients(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+// TestRefreshClients tests that attached counts reach running rows only
+func TestRefreshClients(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning},
+		{Name: "web", Session: "web", Path: "/srv/web", Status: StatusRunning},
+		{Name: "docs", Session: "docs", Path: "/srv/docs", Status: StatusStopped},
+	})
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "printf", "api\t2\nweb\t0\ndocs\t1\n")
+	})
+
+	m.refreshClients(context.Background())
+	want := []int{2, 0, 0}
+	for i, n := range want {
+		if m.projects[i].Clients != n {
+			t.Errorf("%s clients = %d, want %d", m.projects[i].Name, m.projects[i].Clients, n)
+		}
+	}
+	if desc := m.projects[0].Description(); desc != "/srv/api · 👥2" {
+		t.Errorf("Description() = %q", desc)
+	}
+	if desc := m.projects[1].Description(); desc != "/srv/web" {
+		t.Errorf("Description() with no clients = %q", desc)
+	}
+}
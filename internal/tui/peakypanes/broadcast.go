@@ -0,0 +1,166 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// broadcastSend is a single send-keys invocation of a broadcast.
+type broadcastSend struct {
+	Session string
+	Target  string
+	Keys    []string
+}
+
+// runningSessions returns the distinct session names of running projects.
+func runningSessions(projects []Project) []string {
+	seen := make(map[string]bool)
+	var sessions []string
+	for _, p := range projects {
+		if !p.Status.running() || p.Session == "" || seen[p.Session] {
+			continue
+		}
+		seen[p.Session] = true
+		sessions = append(sessions, p.Session)
+	}
+	return sessions
+}
+
+// buildBroadcast returns one send-keys invocation per running session,
+// targeting its first pane and pressing Enter after the command.
+func buildBroadcast(projects []Project, command string) []broadcastSend {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return nil
+	}
+	var sends []broadcastSend
+	for _, session := range runningSessions(projects) {
+		sends = append(sends, broadcastSend{
+			Session: session,
+			Target:  tmuxctl.FirstPaneTarget(session),
+			Keys:    []string{command, "Enter"},
+		})
+	}
+	return sends
+}
+
+func newCommandInput(placeholder string) textinput.Model {
+	in := textinput.New()
+	in.Prompt = "$ "
+	in.Placeholder = placeholder
+	in.CharLimit = 512
+	in.Focus()
+	return in
+}
+
+func (m Model) startBroadcast() (tea.Model, tea.Cmd) {
+	if len(runningSessions(m.projects)) == 0 {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("No running sessions"))
+	}
+	m.cmdInput = newCommandInput("git fetch")
+	m.broadcastCmd = ""
+	m.state = StateBroadcastInput
+	return m, textinput.Blink
+}
+
+func (m Model) updateBroadcastInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.state = StateHome
+		return m, nil
+	case "enter":
+		command := strings.TrimSpace(m.cmdInput.Value())
+		if command == "" {
+			return m, nil
+		}
+		m.broadcastCmd = command
+		m.state = StateConfirmBroadcast
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.cmdInput, cmd = m.cmdInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) updateConfirmBroadcast(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		sends := buildBroadcast(m.projects, m.broadcastCmd)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		sent := 0
+		var failed []string
+		for _, s := range sends {
+			if err := m.tmux.SendKeys(ctx, s.Target, s.Keys...); err != nil {
+				failed = append(failed, s.Session)
+				continue
+			}
+			sent++
+		}
+		m.broadcastCmd = ""
+		m.state = StateHome
+		if len(failed) > 0 {
+			return m, m.list.NewStatusMessage(FormatStatusWarning(
+				fmt.Sprintf("Sent to %d/%d sessions (failed: %s)", sent, len(sends), strings.Join(failed, ", "))))
+		}
+		return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Sent to %d sessions", sent)))
+
+	case "n", "esc":
+		m.broadcastCmd = ""
+		m.state = StateHome
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) viewBroadcastInput() string {
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("📣 Send to all running sessions"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogLabel.Render("Command"))
+	b.WriteString("\n")
+	b.WriteString(m.cmdInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogChoiceKey.Render("enter"))
+	b.WriteString(theme.DialogChoiceSep.Render(" continue • "))
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" cancel"))
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
+
+func (m Model) viewConfirmBroadcast() string {
+	listView := theme.ListDimmed.Render(m.list.View())
+	sends := buildBroadcast(m.projects, m.broadcastCmd)
+
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render(fmt.Sprintf("⚠️  Send to %d sessions?", len(sends))))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogLabel.Render("Command:  "))
+	b.WriteString(theme.DialogValue.Render(m.broadcastCmd))
+	b.WriteString("\n")
+	sessions := make([]string, len(sends))
+	for i, s := range sends {
+		sessions[i] = s.Session
+	}
+	b.WriteString(theme.DialogLabel.Render("Sessions: "))
+	b.WriteString(theme.DialogValue.Render(strings.Join(sessions, ", ")))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogNote.Render("The command is typed into the first pane of each session"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogChoiceKey.Render("y"))
+	b.WriteString(theme.DialogChoiceSep.Render(" confirm • "))
+	b.WriteString(theme.DialogChoiceKey.Render("n"))
+	b.WriteString(theme.DialogChoiceSep.Render(" cancel"))
+
+	return appStyle.Render(listView + "\n\n" + dialogStyle.Render(b.String()))
+}
@@ -0,0 +1,71 @@
+package peakypanes
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBuildBroadcast tests one send-keys per running session
+func TestBuildBroadcast(t *testing.T) {
+	projects := []Project{
+		{Name: "api", Session: "api", Status: StatusRunning},
+		{Name: "web", Session: "web", Status: StatusStopped},
+		{Name: "cur", Session: "cur", Status: StatusCurrent},
+		{Name: "dup", Session: "api", Status: StatusRunning},
+	}
+
+	got := buildBroadcast(projects, "  git fetch  ")
+	want := []broadcastSend{
+		{Session: "api", Target: "api:^.{top-left}", Keys: []string{"git fetch", "Enter"}},
+		{Session: "cur", Target: "cur:^.{top-left}", Keys: []string{"git fetch", "Enter"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildBroadcast() = %+v, want %+v", got, want)
+	}
+
+	if got := buildBroadcast(projects, "   "); got != nil {
+		t.Errorf("buildBroadcast() with empty command = %+v, want nil", got)
+	}
+}
+
+// TestBroadcastConfirmationGate tests nothing is sent until confirmed
+func TestBroadcastConfirmationGate(t *testing.T) {
+	projects := []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning},
+		{Name: "web", Session: "web", Path: "/srv/web", Status: StatusRunning},
+	}
+	tm, calls := newTestModel(t, projects)
+
+	m := press(t, *tm, "b", "l", "s", "enter")
+	if m.state != StateConfirmBroadcast {
+		t.Fatalf("state = %d, want StateConfirmBroadcast", m.state)
+	}
+	if len(calls.args) != 0 {
+		t.Fatalf("tmux called before confirmation: %v", calls.args)
+	}
+
+	cancelled := press(t, m, "n")
+	if cancelled.state != StateHome || len(calls.args) != 0 {
+		t.Fatalf("cancel: state = %d, calls = %v", cancelled.state, calls.args)
+	}
+
+	confirmed := press(t, m, "y")
+	if confirmed.state != StateHome {
+		t.Errorf("state after confirm = %d, want StateHome", confirmed.state)
+	}
+	want := [][]string{
+		{"send-keys", "-t", "api:^.{top-left}", "ls", "Enter"},
+		{"send-keys", "-t", "web:^.{top-left}", "ls", "Enter"},
+	}
+	if !reflect.DeepEqual(calls.args, want) {
+		t.Errorf("tmux calls = %v, want %v", calls.args, want)
+	}
+}
+
+// TestBroadcastNoRunningSessions tests the action is refused without running sessions
+func TestBroadcastNoRunningSessions(t *testing.T) {
+	tm, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api"}})
+	if m := press(t, *tm, "b"); m.state != StateHome {
+		t.Errorf("state = %d, want StateHome", m.state)
+	}
+}
@@ -0,0 +1,251 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// renamePreviewRows caps the renames listed in the bulk rename dialog.
+const renamePreviewRows = 8
+
+// sessionRename is one session name change of a bulk rename.
+type sessionRename struct {
+	Name string // project name
+	Old  string
+	New  string
+}
+
+// expandRenamePattern fills a bulk rename pattern for p. {name}, {session}
+// and {dir} are replaced by the project name, its current session and the
+// base name of its path; the result is sanitized into a session name.
+func expandRenamePattern(pattern string, p Project) string {
+	r := strings.NewReplacer(
+		"{name}", p.Name,
+		"{session}", p.Session,
+		"{dir}", filepath.Base(p.Path),
+	)
+	return sanitizeSessionName(r.Replace(pattern))
+}
+
+// planRenames applies pattern to every project of batch. New names never
+// clash with a name in taken or with another new name of the batch: a
+// numeric suffix is added instead. Projects whose name does not change are
+// left out, and an empty pattern renames nothing.
+func planRenames(pattern string, batch []Project, taken []string) []sessionRename {
+	if strings.TrimSpace(pattern) == "" {
+		return nil
+	}
+	used := make(map[string]bool)
+	for _, s := range taken {
+		used[s] = true
+	}
+	for _, p := range batch {
+		used[p.Session] = true
+	}
+
+	var renames []sessionRename
+	for _, p := range batch {
+		name := expandRenamePattern(pattern, p)
+		if name == p.Session {
+			continue
+		}
+		unique := name
+		for n := 2; used[unique] && unique != p.Session; n++ {
+			unique = name + "-" + strconv.Itoa(n)
+		}
+		if unique == p.Session {
+			continue
+		}
+		used[unique] = true
+		renames = append(renames, sessionRename{Name: p.Name, Old: p.Session, New: unique})
+	}
+	return renames
+}
+
+// renameBatch returns the configured projects shown by the list: all of
+// them, or those matching the current filter.
+func (m Model) renameBatch() []Project {
+	var batch []Project
+	for _, item := range m.list.VisibleItems() {
+		if p, ok := item.(Project); ok && p.Path != "" && !p.Scratch {
+			batch = append(batch, p)
+		}
+	}
+	return batch
+}
+
+// startBulkRename prompts for a pattern applied to the listed projects.
+func (m Model) startBulkRename() (tea.Model, tea.Cmd) {
+	batch := m.renameBatch()
+	if len(batch) == 0 {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("No projects to rename"))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	live, err := m.tmux.ListSessions(ctx)
+	if err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	taken := live
+	for _, p := range m.projects {
+		taken = append(taken, p.Session)
+	}
+
+	m.renameProjects = batch
+	m.renameTaken = taken
+	m.cmdInput = newCommandInput("work-{name}")
+	m.cmdInput.Prompt = "» "
+	m.state = StateBulkRename
+	return m, textinput.Blink
+}
+
+func (m Model) updateBulkRename(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.closeBulkRename()
+		return m, nil
+	case "enter":
+		renames := planRenames(m.cmdInput.Value(), m.renameProjects, m.renameTaken)
+		if len(renames) == 0 {
+			return m, nil
+		}
+		m.closeBulkRename()
+		return m.applyRenames(renames)
+	}
+	var cmd tea.Cmd
+	m.cmdInput, cmd = m.cmdInput.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) closeBulkRename() {
+	m.renameProjects = nil
+	m.renameTaken = nil
+	m.state = StateHome
+}
+
+// applyRenames renames the live sessions, then the projects in memory and
+// in the config file. A session tmux refuses to rename keeps its old name
+// everywhere.
+func (m Model) applyRenames(renames []sessionRename) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	live, _ := m.tmux.ListSessions(ctx)
+	running := make(map[string]bool)
+	for _, s := range live {
+		running[s] = true
+	}
+
+	var done []sessionRename
+	var failed []string
+	for _, r := range renames {
+		if running[r.Old] {
+			if err := m.tmux.RenameSession(ctx, r.Old, r.New); err != nil {
+				failed = append(failed, r.Old)
+				continue
+			}
+		}
+		done = append(done, r)
+	}
+	for _, r := range done {
+		for i := range m.projects {
+			if m.projects[i].Session == r.Old && m.projects[i].Path != "" {
+				m.projects[i].Session = r.New
+				break
+			}
+		}
+	}
+	_ = m.refreshStatuses()
+	m.list.SetItems(m.projectsToItems())
+
+	write := func(m *Model) error { return m.saveProjectSessions(done) }
+	if cmd := m.saveConfig(write); cmd != nil || m.confirmSave {
+		return m, cmd
+	}
+	if len(failed) > 0 {
+		err := fmt.Errorf("renamed %d, tmux refused %s", len(done), strings.Join(failed, ", "))
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Renamed %d sessions", len(done))))
+}
+
+// saveProjectSessions sets the session key of every project entry that
+// currently resolves to one of the renamed sessions, in a single pass so
+// that entries are matched by their names before the rename.
+func (m *Model) saveProjectSessions(renames []sessionRename) error {
+	if len(renames) == 0 {
+		return nil
+	}
+	pending := make(map[string]string)
+	for _, r := range renames {
+		pending[r.Old] = r.New
+	}
+	return m.updateConfigFile(func(doc *yaml.Node) error {
+		if seq := projectsNode(doc, false); seq != nil {
+			for _, n := range seq.Content {
+				if n.Kind != yaml.MappingNode {
+					continue
+				}
+				old := projectNodeSession(n, m.sessionPrefix)
+				if session, ok := pending[old]; ok {
+					v := mappingValue(n, "session", yaml.ScalarNode, true)
+					v.Tag, v.Value = "!!str", session
+					delete(pending, old)
+				}
+			}
+		}
+		if len(pending) > 0 {
+			var missing []string
+			for old := range pending {
+				missing = append(missing, old)
+			}
+			sort.Strings(missing)
+			return fmt.Errorf("sessions %s are not in %s", strings.Join(missing, ", "), m.configPath)
+		}
+		return nil
+	})
+}
+
+func (m Model) viewBulkRename() string {
+	renames := planRenames(m.cmdInput.Value(), m.renameProjects, m.renameTaken)
+
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("✎ Rename sessions"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogLabel.Render(fmt.Sprintf("Pattern for %d listed projects", len(m.renameProjects))))
+	b.WriteString("\n")
+	b.WriteString(m.cmdInput.View())
+	b.WriteString("\n\n")
+	if len(renames) == 0 {
+		b.WriteString(theme.DialogNote.Render("No session names change"))
+		b.WriteString("\n")
+	}
+	for i, r := range renames {
+		if i == renamePreviewRows {
+			b.WriteString(theme.DialogNote.Render(fmt.Sprintf("… and %d more", len(renames)-i)))
+			b.WriteString("\n")
+			break
+		}
+		b.WriteString(theme.DialogLabel.Render(r.Old + " → "))
+		b.WriteString(theme.DialogValue.Render(r.New))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(theme.DialogNote.Render("{name} {session} {dir} • filter the list first to rename fewer"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogChoiceKey.Render("enter"))
+	b.WriteString(theme.DialogChoiceSep.Render(" rename • "))
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" cancel"))
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
@@ -0,0 +1,120 @@
+package peakypanes
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestExpandRenamePattern tests placeholder substitution and sanitizing
+func TestExpandRenamePattern(t *testing.T) {
+	p := Project{Name: "My API", Session: "api", Path: "/src/backend"}
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"work-{name}", "work-my-api"},
+		{"{session}.old", "apiold"},
+		{"{dir}_{session}", "backend-api"},
+		{"static", "static"},
+	}
+	for _, tt := range tests {
+		if got := expandRenamePattern(tt.pattern, p); got != tt.want {
+			t.Errorf("expandRenamePattern(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+// TestPlanRenames tests that new names are unique across the batch and
+// against sessions outside it
+func TestPlanRenames(t *testing.T) {
+	batch := []Project{
+		{Name: "api", Session: "api"},
+		{Name: "web", Session: "web"},
+		{Name: "API", Session: "api-2"},
+		{Name: "docs", Session: "work-docs"},
+	}
+	taken := []string{"work-web", "ops"}
+
+	got := planRenames("work-{name}", batch, taken)
+	want := []sessionRename{
+		{Name: "api", Old: "api", New: "work-api"},
+		{Name: "web", Old: "web", New: "work-web-2"},
+		{Name: "API", Old: "api-2", New: "work-api-2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("planRenames() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	got = planRenames("same", batch, nil)
+	var names []string
+	for _, r := range got {
+		names = append(names, r.New)
+	}
+	if strings.Join(names, ",") != "same,same-2,same-3,same-4" {
+		t.Errorf("constant pattern gave %v, want numbered names", names)
+	}
+
+	if got := planRenames("  ", batch, nil); got != nil {
+		t.Errorf("empty pattern planned %+v, want nothing", got)
+	}
+	if got := planRenames("{session}", batch, nil); got != nil {
+		t.Errorf("identity pattern planned %+v, want nothing", got)
+	}
+}
+
+// TestBulkRenameApply tests renaming the live session, the projects and the
+// config entries
+func TestBulkRenameApply(t *testing.T) {
+	m, calls := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/src/api"},
+		{Name: "web", Session: "web", Path: "/src/web"},
+	})
+	cfg := "projects:\n  - name: api\n    path: /src/api\n  - name: web\n    session: web\n    path: /src/web\n"
+	if err := os.WriteFile(m.configPath, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls.args = append(calls.args, args)
+		if args[0] == "list-sessions" {
+			return exec.CommandContext(ctx, "printf", "api\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+
+	model := press(t, *m, "R")
+	if model.state != StateBulkRename {
+		t.Fatalf("state = %v, want StateBulkRename", model.state)
+	}
+	model = press(t, model, strings.Split("w-{name}", "")...)
+	if view := model.View(); !strings.Contains(view, "api → ") || !strings.Contains(view, "w-web") {
+		t.Errorf("preview does not list the new names:\n%s", view)
+	}
+	model = press(t, model, "enter")
+
+	if model.state != StateHome {
+		t.Errorf("state = %v after applying, want StateHome", model.state)
+	}
+	var renamed [][]string
+	for _, args := range calls.args {
+		if args[0] == "rename-session" {
+			renamed = append(renamed, args)
+		}
+	}
+	if want := [][]string{{"rename-session", "-t", "api", "w-api"}}; !reflect.DeepEqual(renamed, want) {
+		t.Errorf("tmux renames = %q, want only the live session %q", renamed, want)
+	}
+	if got := []string{model.projects[0].Session, model.projects[1].Session}; !reflect.DeepEqual(got, []string{"w-api", "w-web"}) {
+		t.Errorf("project sessions = %v", got)
+	}
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "session: w-api") || !strings.Contains(string(data), "session: w-web") {
+		t.Errorf("config not updated:\n%s", data)
+	}
+}
@@ -0,0 +1,128 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// startCaptureLayout prompts for the name to save the selected running
+// session's window and pane arrangement under.
+func (m Model) startCaptureLayout() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	if !item.Status.running() {
+		return m, m.list.NewStatusMessage(FormatStatusWarning(item.Name + " is not running"))
+	}
+	m.captureProject = &item
+	m.cmdInput = newCommandInput(sanitizeSessionName(item.Name))
+	m.cmdInput.Prompt = "› "
+	m.state = StateCaptureLayout
+	return m, textinput.Blink
+}
+
+// layoutExists reports whether a layout called name is already loaded.
+func (m Model) layoutExists(name string) bool {
+	for _, n := range m.layoutNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// captureLayout captures p's session as the layout name and saves it to
+// the inline layouts of the config file.
+func (m Model) captureLayout(p Project, name string) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	windows, err := m.tmux.CaptureSession(ctx, p.Session)
+	if err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	if len(windows) == 0 {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("No windows in " + p.Session))
+	}
+	l := tmuxctl.CapturedLayout(name, p.Session, windows)
+	if err := m.saveLayout(name, l); err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	if m.loader != nil {
+		_ = m.loader.LoadAll() // make the new layout selectable right away
+	}
+	return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Saved layout %s · check its pane commands, they are best effort", name)))
+}
+
+// saveLayout adds l to the inline layouts of the config file as name.
+func (m *Model) saveLayout(name string, l *layout.LayoutConfig) error {
+	return m.updateConfigFile(func(doc *yaml.Node) error {
+		layouts := mappingValue(doc.Content[0], "layouts", yaml.MappingNode, true)
+		if layouts.Tag == "" {
+			layouts.Tag = "!!map"
+		}
+		var n yaml.Node
+		if err := n.Encode(l); err != nil {
+			return fmt.Errorf("encode layout %q: %w", name, err)
+		}
+		deleteMappingKey(layouts, name)
+		layouts.Content = append(layouts.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, &n)
+		return nil
+	})
+}
+
+func (m Model) updateCaptureLayout(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.captureProject = nil
+		m.state = StateHome
+		return m, nil
+	case "enter":
+		name := strings.TrimSpace(m.cmdInput.Value())
+		if name == "" || m.captureProject == nil {
+			return m, nil
+		}
+		if m.layoutExists(name) {
+			return m, m.list.NewStatusMessage(FormatStatusWarning("Layout " + name + " already exists"))
+		}
+		p := *m.captureProject
+		m.captureProject = nil
+		m.state = StateHome
+		return m.captureLayout(p, name)
+	}
+	var cmd tea.Cmd
+	m.cmdInput, cmd = m.cmdInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) viewCaptureLayout() string {
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("📐 Capture Layout"))
+	b.WriteString("\n\n")
+	if m.captureProject != nil {
+		b.WriteString(theme.DialogLabel.Render("Session: "))
+		b.WriteString(theme.DialogValue.Render(m.captureProject.Session))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(theme.DialogLabel.Render("Layout name"))
+	b.WriteString("\n")
+	b.WriteString(m.cmdInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogNote.Render("Saved to the config; pane commands are process names only and may need editing"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogChoiceKey.Render("enter"))
+	b.WriteString(theme.DialogChoiceSep.Render(" save • "))
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" cancel"))
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
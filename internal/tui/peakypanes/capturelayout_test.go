@@ -0,0 +1,66 @@
+package peakypanes
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+// TestCaptureLayout tests Y saves the running session's arrangement as an
+// inline layout next to the existing config
+func TestCaptureLayout(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	m.list.StatusMessageLifetime = time.Millisecond
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "printf", "0\tdev\tlay\t0\t0\t80\t24\tzsh\n0\tdev\tlay\t0\t25\t80\t24\tnode\n")
+	})
+	if err := os.WriteFile(m.configPath, []byte("projects:\n  - name: api\n    path: /srv/api\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	model := press(t, *m, "Y")
+	if model.state != StateCaptureLayout {
+		t.Fatalf("state = %d, want StateCaptureLayout", model.state)
+	}
+	model = press(t, model, "api-dev", "enter")
+	if model.state != StateHome {
+		t.Fatalf("state = %d after enter, want StateHome", model.state)
+	}
+
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg struct {
+		Projects []projectConfig                `yaml:"projects"`
+		Layouts  map[string]layout.LayoutConfig `yaml:"layouts"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Projects) != 1 {
+		t.Errorf("projects = %+v, want the existing one kept", cfg.Projects)
+	}
+	l, ok := cfg.Layouts["api-dev"]
+	if !ok || len(l.Windows) != 1 || len(l.Windows[0].Panes) != 2 {
+		t.Fatalf("layouts = %+v, want api-dev with two panes", cfg.Layouts)
+	}
+	if p := l.Windows[0].Panes[1]; p.Cmd != "node" || p.Split != "vertical" {
+		t.Errorf("second pane = %+v", p)
+	}
+}
+
+// TestCaptureLayoutStopped tests stopped projects cannot be captured
+func TestCaptureLayoutStopped(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusStopped}})
+	m.list.StatusMessageLifetime = time.Millisecond
+	if model := press(t, *m, "Y"); model.state != StateHome {
+		t.Errorf("state = %d, want StateHome", model.state)
+	}
+}
@@ -0,0 +1,232 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// A temporary clone is a git URL cloned into a fresh temp dir and opened
+// as a transient project: it is listed like a configured project but never
+// written to the config, and killing its session deletes the clone. A
+// clone whose session ends some other way, e.g. by exiting its last shell,
+// is deleted on the next refresh. A clone whose session outlives
+// peakypanes is left in the temp dir.
+
+// cloneTimeout bounds how long a temporary clone may take.
+const cloneTimeout = 5 * time.Minute
+
+// cloneRunner clones url into dir.
+type cloneRunner func(ctx context.Context, url, dir string) error
+
+// gitClone makes a shallow clone, which is all a quick look needs.
+func gitClone(ctx context.Context, url, dir string) error {
+	out, err := exec.CommandContext(ctx, "git", "clone", "--depth", "1", "--", url, dir).CombinedOutput()
+	if err != nil {
+		// git prints the fatal error last
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("git clone: %s", lastLine(msg))
+		}
+		return fmt.Errorf("git clone: %w", err)
+	}
+	return nil
+}
+
+// repoName returns the repository name of a git URL, e.g. "app" for
+// "git@github.com:org/app.git".
+func repoName(url string) string {
+	url = strings.TrimRight(strings.TrimSpace(url), "/")
+	url = strings.TrimSuffix(url, ".git")
+	if i := strings.LastIndexAny(url, "/:"); i >= 0 {
+		url = url[i+1:]
+	}
+	if url == "" {
+		return "clone"
+	}
+	return url
+}
+
+// clonedMsg reports a finished temporary clone.
+type clonedMsg struct {
+	url string
+	// root is the temp dir holding the clone at dir.
+	root string
+	dir  string
+	err  error
+}
+
+// cloneCmd clones url into a new temp dir in the background.
+func (m Model) cloneCmd(url string) tea.Cmd {
+	run := m.cloneRunner
+	if run == nil {
+		run = gitClone
+	}
+	return func() tea.Msg {
+		root, err := os.MkdirTemp("", "peakypanes-")
+		if err != nil {
+			return clonedMsg{url: url, err: err}
+		}
+		dir := filepath.Join(root, repoName(url))
+		ctx, cancel := context.WithTimeout(context.Background(), cloneTimeout)
+		defer cancel()
+		if err := run(ctx, url, dir); err != nil {
+			_ = os.RemoveAll(root)
+			return clonedMsg{url: url, err: err}
+		}
+		return clonedMsg{url: url, root: root, dir: dir}
+	}
+}
+
+// handleCloned adds the clone as a transient project and starts its
+// session.
+func (m Model) handleCloned(msg clonedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(NewErrorMsg(msg.err, "clone "+msg.url)))
+	}
+	var taken []string
+	for _, p := range m.projects {
+		taken = append(taken, p.Session)
+	}
+	name := filepath.Base(msg.dir)
+	p := Project{
+		Name:     name,
+		Session:  uniqueSessionName(prefixSession(m.sessionPrefix, sanitizeSessionName(name)), taken),
+		Path:     msg.dir,
+		Status:   StatusStopped,
+		tempRoot: msg.root,
+	}
+	m.projects = withTransient(m.projects, p)
+	m.list.SetItems(m.projectsToItems())
+	m.selectSession(p.Session)
+	return m, m.startProject(p)
+}
+
+// withTransient inserts p after the configured and transient projects,
+// ahead of unconfigured sessions.
+func withTransient(projects []Project, p Project) []Project {
+	n := 0
+	for n < len(projects) && projects[n].Path != "" {
+		n++
+	}
+	added := append([]Project{}, projects[:n]...)
+	added = append(added, p)
+	return append(added, projects[n:]...)
+}
+
+// transientProjects returns the transient projects among projects, so a
+// config reload can keep them.
+func transientProjects(projects []Project) []Project {
+	var transient []Project
+	for _, p := range projects {
+		if p.transient() {
+			transient = append(transient, p)
+		}
+	}
+	return transient
+}
+
+// removeTransient deletes p's clone and drops it from the projects.
+func (m *Model) removeTransient(p Project) error {
+	var kept []Project
+	for _, q := range m.projects {
+		if q.Session != p.Session || !q.transient() {
+			kept = append(kept, q)
+		}
+	}
+	m.projects = kept
+	return os.RemoveAll(p.tempRoot)
+}
+
+// removeEndedClones deletes the clones of transient projects whose session
+// has stopped since it was last seen running and drops the projects. A
+// clone whose session was never seen running is kept, as it may still be
+// starting. Deleting is best effort.
+func (m *Model) removeEndedClones() {
+	kept := make([]Project, 0, len(m.projects))
+	for _, p := range m.projects {
+		if p.transient() {
+			if p.Status.running() {
+				p.tempSeen = true
+			} else if p.tempSeen {
+				_ = os.RemoveAll(p.tempRoot)
+				continue
+			}
+		}
+		kept = append(kept, p)
+	}
+	m.projects = kept
+}
+
+// startClone prompts for a git URL to clone into a temp dir.
+func (m Model) startClone() (tea.Model, tea.Cmd) {
+	m.cmdInput = newCommandInput("https://github.com/org/repo.git")
+	m.cmdInput.Prompt = "› "
+	m.state = StateCloneInput
+	return m, textinput.Blink
+}
+
+func (m Model) updateCloneInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.state = StateHome
+		return m, nil
+	case "enter":
+		url := strings.TrimSpace(m.cmdInput.Value())
+		if url == "" {
+			return m, nil
+		}
+		m.state = StateHome
+		return m, tea.Batch(
+			m.list.NewStatusMessage(FormatStatusInfo("Cloning "+url+"...")),
+			m.cloneCmd(url),
+		)
+	}
+	var cmd tea.Cmd
+	m.cmdInput, cmd = m.cmdInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) viewCloneInput() string {
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("🧪 Temporary Clone"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogLabel.Render("Git URL"))
+	b.WriteString("\n")
+	b.WriteString(m.cmdInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogNote.Render("Cloned to a temp dir; not saved to the config and deleted on kill"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogChoiceKey.Render("enter"))
+	b.WriteString(theme.DialogChoiceSep.Render(" clone & open • "))
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" cancel"))
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
+
+// transient reports whether p is a temporary clone that is not in the
+// config.
+func (p Project) transient() bool {
+	return p.tempRoot != ""
+}
+
+// killTransient finishes killing a temporary clone's session: the clone is
+// deleted and the project dropped. There is nothing left to undo.
+func (m Model) killTransient(p Project) (tea.Model, tea.Cmd) {
+	err := m.removeTransient(p)
+	m.undo = nil
+	_ = m.refreshStatuses()
+	m.list.SetItems(m.projectsToItems())
+	if err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusWarning(fmt.Sprintf("Killed session %s (clone not deleted: %v)", p.Session, err)))
+	}
+	return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Killed session %s and deleted its clone", p.Session)))
+}
@@ -0,0 +1,163 @@
+package peakypanes
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRepoName(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/org/app.git":   "app",
+		"https://github.com/org/app/":      "app",
+		"git@github.com:org/app.git":       "app",
+		"git@host:app.git":                 "app",
+		"/srv/repos/lib":                   "lib",
+		"":                                 "clone",
+		"https://example.com/org/app.git ": "app",
+	}
+	for url, want := range tests {
+		if got := repoName(url); got != want {
+			t.Errorf("repoName(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+// TestTemporaryCloneLifecycle tests a clone is listed as a transient
+// project, is not written to the config, survives a config reload and is
+// deleted on kill
+func TestTemporaryCloneLifecycle(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api"}})
+	m.list.StatusMessageLifetime = time.Millisecond
+	var cloned []string
+	m.cloneRunner = func(ctx context.Context, url, dir string) error {
+		cloned = append(cloned, url)
+		return os.MkdirAll(dir, 0o755)
+	}
+
+	model := press(t, *m, "t")
+	if model.state != StateCloneInput {
+		t.Fatalf("state = %d, want StateCloneInput", model.state)
+	}
+	model = press(t, model, "git@github.com:org/api.git")
+	next, cmd := model.Update(keyMsg("enter"))
+	model = next.(Model)
+	for _, msg := range runCmd(cmd) {
+		if done, ok := msg.(clonedMsg); ok {
+			next, _ = model.Update(done)
+			model = next.(Model)
+		}
+	}
+	if len(cloned) != 1 || cloned[0] != "git@github.com:org/api.git" {
+		t.Fatalf("cloned %v", cloned)
+	}
+
+	var clone Project
+	for _, p := range model.projects {
+		if p.transient() {
+			clone = p
+		}
+	}
+	if clone.Session != "api-2" || filepath.Base(clone.Path) != "api" {
+		t.Fatalf("transient project = %+v, want api-2 in the clone", clone)
+	}
+	if _, err := os.Stat(clone.Path); err != nil {
+		t.Fatalf("clone dir: %v", err)
+	}
+	if _, err := os.Stat(model.configPath); !os.IsNotExist(err) {
+		t.Errorf("config written for a transient project: %v", err)
+	}
+	if n := configuredCount(model.projects); n != 1 {
+		t.Errorf("configuredCount() = %d, want only the config's project", n)
+	}
+
+	if err := os.WriteFile(model.configPath, []byte("projects:\n  - name: web\n    path: /srv/web\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := model.loadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if len(model.projects) != 2 || model.projects[1].Session != "api-2" {
+		t.Fatalf("projects after reload = %+v, want web and the clone", model.projects)
+	}
+
+	next, _ = model.killSession(clone)
+	model = next.(Model)
+	if _, err := os.Stat(clone.tempRoot); !os.IsNotExist(err) {
+		t.Errorf("clone not deleted on kill: %v", err)
+	}
+	for _, p := range model.projects {
+		if p.Session == "api-2" {
+			t.Errorf("killed clone still listed: %+v", p)
+		}
+	}
+	if model.undo != nil {
+		t.Errorf("undo = %+v, want none for a deleted clone", model.undo)
+	}
+}
+
+// TestTemporaryCloneFails tests a failed clone leaves no temp dir and no
+// project behind
+func TestTemporaryCloneFails(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	m, _ := newTestModel(t, nil)
+	m.cloneRunner = func(ctx context.Context, url, dir string) error {
+		return errors.New("repository not found")
+	}
+	msg := m.cloneCmd("https://example.com/missing.git")().(clonedMsg)
+	if msg.err == nil {
+		t.Fatal("clone succeeded, want the runner's error")
+	}
+	if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+		t.Errorf("temp dir left behind: %v", entries)
+	}
+	next, _ := m.Update(msg)
+	if projects := next.(Model).projects; len(projects) != 0 {
+		t.Errorf("projects = %+v, want none", projects)
+	}
+}
+
+// TestEndedCloneRemovedOnRefresh tests that a clone is kept while its
+// session starts and deleted once the session ends outside peakypanes
+func TestEndedCloneRemovedOnRefresh(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "app")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := newMockModel(t, nil)
+	m.projects = []Project{{Name: "app", Session: "app", Path: dir, tempRoot: root}}
+
+	// Not started yet: the session may still be starting
+	if err := m.refreshStatuses(); err != nil {
+		t.Fatalf("refreshStatuses() error: %v", err)
+	}
+	if len(m.projects) != 1 {
+		t.Fatalf("projects before start = %+v, want the clone kept", m.projects)
+	}
+
+	ctx := context.Background()
+	if _, err := m.tmux.NewSessionWithCmd(ctx, "app", dir, "", ""); err != nil {
+		t.Fatalf("NewSessionWithCmd() error: %v", err)
+	}
+	_ = m.refreshStatuses()
+	if _, err := os.Stat(root); err != nil {
+		t.Fatalf("clone removed while running: %v", err)
+	}
+
+	if err := m.tmux.KillSession(ctx, "app"); err != nil {
+		t.Fatalf("KillSession() error: %v", err)
+	}
+	_ = m.refreshStatuses()
+	if len(m.projects) != 0 {
+		t.Errorf("projects after the session ended = %+v, want none", m.projects)
+	}
+	if _, err := os.Stat(root); !os.IsNotExist(err) {
+		t.Errorf("clone root still exists after the session ended: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// sessionCollision reports whether session is already live in tmux without
+// belonging to one of the configured projects. Starting it would silently
+// attach to someone else's session.
+func sessionCollision(session string, live []string, projects []Project) bool {
+	for _, p := range projects {
+		if p.Session == session && p.Path != "" {
+			return false
+		}
+	}
+	for _, s := range live {
+		if s == session {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueSessionName returns base, or base with the smallest numeric suffix
+// that is not in live.
+func uniqueSessionName(base string, live []string) string {
+	taken := make(map[string]bool, len(live))
+	for _, s := range live {
+		taken[s] = true
+	}
+	if !taken[base] {
+		return base
+	}
+	for i := 2; ; i++ {
+		name := fmt.Sprintf("%s-%d", base, i)
+		if !taken[name] {
+			return name
+		}
+	}
+}
+
+// createSession starts a new session for p, asking first when its name
+// collides with a live session that is not one of our projects.
+func (m Model) createSession(p Project) (tea.Model, tea.Cmd) {
+	p.Session = prefixSession(m.sessionPrefix, p.Session)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	live, err := m.tmux.ListSessions(ctx)
+	if err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	if sessionCollision(p.Session, live, m.projects) {
+		m.collision = &p
+		m.collisionLive = live
+		m.state = StateConfirmCollision
+		return m, nil
+	}
+	return m, m.startProject(p)
+}
+
+// sessionLive reports whether session is in live.
+func sessionLive(session string, live []string) bool {
+	for _, s := range live {
+		if s == session {
+			return true
+		}
+	}
+	return false
+}
+
+// recreateSession starts p's session again in the background, asking first
+// when a session with its name has appeared since it was killed.
+func (m Model) recreateSession(p Project) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	live, err := m.tmux.ListSessions(ctx)
+	if err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	if sessionLive(p.Session, live) {
+		m.collision = &p
+		m.collisionLive = live
+		m.collisionRecreate = true
+		m.state = StateConfirmCollision
+		return m, nil
+	}
+	return m, tea.Batch(
+		m.list.NewStatusMessage(FormatStatusInfo(fmt.Sprintf("Recreating %s…", p.Session))),
+		m.startDetachedCmd(p),
+	)
+}
+
+// replaceSession kills the live session named like p and recreates p in
+// its place.
+func (m Model) replaceSession(p Project) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := m.tmux.KillSession(ctx, p.Session); err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	return m, tea.Batch(
+		m.list.NewStatusMessage(FormatStatusInfo(fmt.Sprintf("Replacing %s…", p.Session))),
+		m.startDetachedCmd(p),
+	)
+}
+
+func (m Model) updateConfirmCollision(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.collision
+	if p == nil {
+		m.state = StateHome
+		return m, nil
+	}
+	recreate := m.collisionRecreate
+	unique := *p
+	unique.Session = uniqueSessionName(p.Session, m.collisionLive)
+	done := func() {
+		m.collision, m.collisionLive, m.collisionRecreate = nil, nil, false
+		m.state = StateHome
+	}
+	switch msg.String() {
+	case "a":
+		done()
+		return m, m.attachProject(*p)
+	case "u":
+		done()
+		if recreate {
+			return m, m.startDetachedCmd(unique)
+		}
+		return m, m.startProject(unique)
+	case "r":
+		if !recreate {
+			return m, nil
+		}
+		done()
+		return m.replaceSession(*p)
+	case "n", "esc":
+		done()
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) viewConfirmCollision() string {
+	listView := theme.ListDimmed.Render(m.list.View())
+	p := m.collision
+
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("⚠️  Session name in use"))
+	b.WriteString("\n\n")
+	if p != nil {
+		b.WriteString(theme.DialogLabel.Render("Session: "))
+		b.WriteString(theme.DialogValue.Render(p.Session))
+		b.WriteString("\n\n")
+		note := "A tmux session with this name is running but is not one of your projects"
+		if m.collisionRecreate {
+			note = "A session with this name was started after the kill; replacing it closes it"
+		}
+		b.WriteString(theme.DialogNote.Render(note))
+		b.WriteString("\n\n")
+		if m.collisionRecreate {
+			b.WriteString(theme.DialogChoiceKey.Render("r"))
+			b.WriteString(theme.DialogChoiceSep.Render(" replace it • "))
+		}
+		b.WriteString(theme.DialogChoiceKey.Render("a"))
+		b.WriteString(theme.DialogChoiceSep.Render(" attach to it • "))
+		b.WriteString(theme.DialogChoiceKey.Render("u"))
+		b.WriteString(theme.DialogChoiceSep.Render(" use " + uniqueSessionName(p.Session, m.collisionLive) + " • "))
+	}
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" cancel"))
+
+	return appStyle.Render(listView + "\n\n" + dialogStyle.Render(b.String()))
+}
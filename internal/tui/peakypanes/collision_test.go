@@ -0,0 +1,164 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+// TestSessionCollision tests detection of live sessions outside the project list
+func TestSessionCollision(t *testing.T) {
+	projects := []Project{
+		{Name: "api", Session: "api", Path: "/srv/api"},
+		{Name: "adhoc", Session: "adhoc", Status: StatusRunning}, // live, unconfigured
+	}
+	live := []string{"api", "adhoc", "other"}
+
+	tests := []struct {
+		session string
+		want    bool
+	}{
+		{"api", false},  // our own project
+		{"adhoc", true}, // live but not configured
+		{"other", true}, // live, not listed at all
+		{"new", false},  // not live
+	}
+	for _, tt := range tests {
+		if got := sessionCollision(tt.session, live, projects); got != tt.want {
+			t.Errorf("sessionCollision(%q) = %v, want %v", tt.session, got, tt.want)
+		}
+	}
+}
+
+// TestUniqueSessionName tests suffixing a taken session name
+func TestUniqueSessionName(t *testing.T) {
+	live := []string{"web", "web-2", "api"}
+	if got := uniqueSessionName("web", live); got != "web-3" {
+		t.Errorf("uniqueSessionName(web) = %q, want web-3", got)
+	}
+	if got := uniqueSessionName("cli", live); got != "cli" {
+		t.Errorf("uniqueSessionName(cli) = %q, want cli", got)
+	}
+}
+
+// TestPickerCollisionPrompt tests that opening a repo whose session name is taken asks first
+func TestPickerCollisionPrompt(t *testing.T) {
+	m, calls := newTestModel(t, nil)
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls.args = append(calls.args, args)
+		if args[0] == "list-sessions" {
+			return exec.CommandContext(ctx, "printf", "other\nweb\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+	m.gitProjects = []GitProject{{Name: "web", Path: "/srv/web"}}
+	m.projectPicker.SetItems(m.gitProjectsToItems())
+	m.state = StateProjectPicker
+
+	model := press(t, *m, "enter")
+	if model.state != StateConfirmCollision {
+		t.Fatalf("state = %v, want StateConfirmCollision", model.state)
+	}
+	if model.collision == nil || model.collision.Session != "web" {
+		t.Fatalf("collision = %+v", model.collision)
+	}
+
+	cancelled := press(t, model, "esc")
+	if cancelled.state != StateHome || cancelled.collision != nil {
+		t.Errorf("esc: state = %v, collision = %v", cancelled.state, cancelled.collision)
+	}
+}
+
+// hasCall reports whether subcmd was run.
+func hasCall(calls [][]string, subcmd string) bool {
+	for _, args := range calls {
+		if len(args) > 0 && args[0] == subcmd {
+			return true
+		}
+	}
+	return false
+}
+
+// undoWithLiveSession kills api and makes a session of the same name show up
+// again before undo. It returns the model at the collision prompt.
+func undoWithLiveSession(t *testing.T) (Model, *tmuxCalls, *[][]string) {
+	t.Helper()
+	p := Project{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}
+	m, calls := newTestModel(t, []Project{p})
+	var started [][]string
+	m.startRunner = func(args ...string) ([]byte, error) {
+		started = append(started, args)
+		return nil, nil
+	}
+
+	next, _ := m.killSession(p)
+	model := next.(Model)
+	model.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls.args = append(calls.args, args)
+		if args[0] == "list-sessions" {
+			return exec.CommandContext(ctx, "printf", "api\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+	calls.args = nil
+
+	model = press(t, model, "u")
+	return model, calls, &started
+}
+
+// TestRecreateCollision tests that undo asks before recreating over a
+// session that reappeared
+func TestRecreateCollision(t *testing.T) {
+	model, calls, started := undoWithLiveSession(t)
+	if model.state != StateConfirmCollision || !model.collisionRecreate {
+		t.Fatalf("state = %v, recreate = %v, want the recreate collision prompt", model.state, model.collisionRecreate)
+	}
+	if model.collision == nil || model.collision.Session != "api" {
+		t.Fatalf("collision = %+v", model.collision)
+	}
+	if len(*started) != 0 {
+		t.Errorf("recreated before confirming: %q", *started)
+	}
+	if hasCall(calls.args, "kill-session") {
+		t.Errorf("killed the live session before confirming: %q", calls.args)
+	}
+}
+
+// TestRecreateCollisionConfirm tests that replacing kills the live session
+// and recreates the project
+func TestRecreateCollisionConfirm(t *testing.T) {
+	model, calls, started := undoWithLiveSession(t)
+	model.list.StatusMessageLifetime = time.Millisecond
+
+	next, cmd := model.Update(keyMsg("r"))
+	model = next.(Model)
+	if model.state != StateHome || model.collision != nil || model.collisionRecreate {
+		t.Errorf("state = %v, collision = %+v after confirming", model.state, model.collision)
+	}
+	if !hasCall(calls.args, "kill-session") {
+		t.Errorf("tmux calls = %q, want kill-session", calls.args)
+	}
+	runCmd(cmd)
+	if len(*started) != 1 || (*started)[0][2] != "api" {
+		t.Errorf("started = %q, want api recreated", *started)
+	}
+}
+
+// TestRecreateCollisionCancel tests that cancelling leaves the live session
+// alone
+func TestRecreateCollisionCancel(t *testing.T) {
+	model, calls, started := undoWithLiveSession(t)
+
+	next, cmd := model.Update(keyMsg("esc"))
+	model = next.(Model)
+	if model.state != StateHome || model.collision != nil {
+		t.Errorf("state = %v, collision = %+v after cancel", model.state, model.collision)
+	}
+	if cmd != nil {
+		runCmd(cmd)
+	}
+	if hasCall(calls.args, "kill-session") || len(*started) != 0 {
+		t.Errorf("cancel acted: tmux %q, started %q", calls.args, *started)
+	}
+}
@@ -0,0 +1,49 @@
+package peakypanes
+
+import (
+	"context"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// commandLabel formats a pane's current command for the project description.
+func commandLabel(command string) string {
+	name := tmuxctl.CommandName(command)
+	if name == "" {
+		return ""
+	}
+	if tmuxctl.IsIdleShell(name) {
+		return "idle shell"
+	}
+	return "running: " + name
+}
+
+// busyCommands returns the pane commands that are not idle shells, i.e.
+// foreground processes that would be lost by killing their panes. Each
+// command is listed once, in first-seen order.
+func busyCommands(commands []string) []string {
+	var busy []string
+	seen := make(map[string]bool)
+	for _, c := range commands {
+		name := tmuxctl.CommandName(c)
+		if name == "" || tmuxctl.IsIdleShell(name) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		busy = append(busy, name)
+	}
+	return busy
+}
+
+// refreshCommands records the active pane command of each running project.
+// It is best effort: on error the previous values are cleared.
+func (m *Model) refreshCommands(ctx context.Context) {
+	commands, _ := m.tmux.ActiveCommands(ctx)
+	for i := range m.projects {
+		p := &m.projects[i]
+		p.Command = ""
+		if p.Status.running() {
+			p.Command = commands[p.Session]
+		}
+	}
+}
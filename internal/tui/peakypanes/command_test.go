@@ -0,0 +1,96 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestCommandLabel tests formatting of a pane's current command
+func TestCommandLabel(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"node", "running: node"},
+		{"nvim", "running: nvim"},
+		{"/usr/bin/python3", "running: python3"},
+		{"zsh", "idle shell"},
+		{"-bash", "idle shell"},
+		{"fish", "idle shell"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := commandLabel(tt.command); got != tt.want {
+			t.Errorf("commandLabel(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+// TestProjectDescriptionCommand tests that the command shows in the description
+func TestProjectDescriptionCommand(t *testing.T) {
+	p := Project{Name: "api", Path: "/srv/api", Status: StatusRunning, Command: "npm"}
+	if desc := p.Description(); desc != "/srv/api · running: npm" {
+		t.Errorf("Description() = %q", desc)
+	}
+	p.Command = "zsh"
+	if desc := p.Description(); !strings.HasSuffix(desc, "idle shell") {
+		t.Errorf("Description() = %q, want idle shell", desc)
+	}
+}
+
+// TestBusyCommands tests classifying pane commands as busy or idle shells
+func TestBusyCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     []string
+	}{
+		{"no panes", nil, nil},
+		{"all idle", []string{"zsh", "-bash", "/usr/bin/fish", "sh"}, nil},
+		{"one busy", []string{"zsh", "node", "bash"}, []string{"node"}},
+		{"deduplicated", []string{"vim", "zsh", "/usr/bin/vim", "npm"}, []string{"vim", "npm"}},
+		{"blank ignored", []string{"", "  "}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := busyCommands(tt.commands); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("busyCommands(%q) = %q, want %q", tt.commands, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestKillDialogBusy tests that busy panes escalate the kill confirmation
+func TestKillDialogBusy(t *testing.T) {
+	p := Project{Name: "api", Session: "api"}
+	idle := killDialog(p, nil)
+	if idle.title != "⚠️  Kill Session?" || len(idle.fields) != 2 {
+		t.Errorf("idle dialog = %q with %d fields", idle.title, len(idle.fields))
+	}
+	busy := killDialog(p, []string{"node", "vim"})
+	if busy.title == idle.title || busy.fields[2].value != "node, vim" {
+		t.Errorf("busy dialog = %q, fields %+v", busy.title, busy.fields)
+	}
+}
+
+// TestKillWarnsBusyPanes tests that K inspects the session's panes
+func TestKillWarnsBusyPanes(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls.args = append(calls.args, args)
+		if args[0] == "list-panes" {
+			return exec.CommandContext(ctx, "printf", "zsh\nnode\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+	model := press(t, *m, "K")
+	if model.state != StateConfirm || model.confirm == nil {
+		t.Fatalf("state = %v, want StateConfirm", model.state)
+	}
+	if model.confirm.title != "🛑 Kill Busy Session?" {
+		t.Errorf("title = %q, want busy warning", model.confirm.title)
+	}
+}
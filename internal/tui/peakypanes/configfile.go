@@ -0,0 +1,116 @@
+package peakypanes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The TUI edits the config file through yaml.Node so that keys it does not
+// know about (inline layouts, vars, comments) survive a round trip.
+
+// loadConfigDoc reads the config file as a YAML document node. A missing
+// file yields an empty mapping document.
+func loadConfigDoc(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var doc yaml.Node
+	if len(data) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parse config %q: %w", path, err)
+		}
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		doc = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parse config %q: top level must be a mapping", path)
+	}
+	return &doc, nil
+}
+
+// writeConfigDoc writes the document back to path, creating the parent
+// directory if needed.
+func writeConfigDoc(path string, doc *yaml.Node) error {
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write config %q: %w", path, err)
+	}
+	return nil
+}
+
+// mappingValue returns the value node for key in a mapping node, creating
+// it with the given kind when create is set.
+func mappingValue(m *yaml.Node, key string, kind yaml.Kind, create bool) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	if !create {
+		return nil
+	}
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	valNode := &yaml.Node{Kind: kind}
+	if kind == yaml.SequenceNode {
+		valNode.Tag = "!!seq"
+	}
+	m.Content = append(m.Content, keyNode, valNode)
+	return valNode
+}
+
+// deleteMappingKey removes key and its value from a mapping node.
+func deleteMappingKey(m *yaml.Node, key string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// projectsNode returns the "projects" sequence of a config document.
+func projectsNode(doc *yaml.Node, create bool) *yaml.Node {
+	return mappingValue(doc.Content[0], "projects", yaml.SequenceNode, create)
+}
+
+// projectNodeSession resolves the session name a project node maps to, using
+// the same defaulting rules as loadConfig with session prefix prefix.
+func projectNodeSession(n *yaml.Node, prefix string) string {
+	var pc projectConfig
+	if err := n.Decode(&pc); err != nil {
+		return ""
+	}
+	if pc.Session != "" {
+		return pc.Session
+	}
+	if pc.Name != "" {
+		return prefixSession(prefix, sanitizeSessionName(pc.Name))
+	}
+	return ""
+}
+
+// updateConfigFile loads the config document, applies fn and writes it back.
+func (m *Model) updateConfigFile(fn func(doc *yaml.Node) error) error {
+	doc, err := loadConfigDoc(m.configPath)
+	if err != nil {
+		return err
+	}
+	if err := fn(doc); err != nil {
+		return err
+	}
+	return writeConfigDoc(m.configPath, doc)
+}
@@ -0,0 +1,132 @@
+package peakypanes
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// confirmDialog is a yes/no prompt shown over the dimmed project list.
+// Actions that need confirmation build one and open it with openConfirm;
+// the dialog owns the keys, styling and timeout handling.
+type confirmDialog struct {
+	title string
+	// action names what is confirmed in status messages, e.g. "Kill".
+	action string
+	fields []confirmField
+	note   string
+	keys   *confirmKeyMap
+	// prompt names the dialog for confirm_prompts, which rewords it with
+	// data; unnamed dialogs keep their wording.
+	prompt string
+	data   promptData
+	// result runs once the dialog is answered, with confirmed set for yes.
+	// The dialog is already closed when it is called.
+	result func(m Model, confirmed bool) (tea.Model, tea.Cmd)
+}
+
+// confirmField is a label/value row in a confirm dialog.
+type confirmField struct {
+	label string
+	value string
+}
+
+type confirmKeyMap struct {
+	yes key.Binding
+	no  key.Binding
+}
+
+func newConfirmKeyMap() *confirmKeyMap {
+	return &confirmKeyMap{
+		yes: key.NewBinding(
+			key.WithKeys("y", "enter"),
+			key.WithHelp("y", "confirm"),
+		),
+		no: key.NewBinding(
+			key.WithKeys("n", "esc"),
+			key.WithHelp("n", "cancel"),
+		),
+	}
+}
+
+// confirmAnswer is how a key press answers a confirm dialog.
+type confirmAnswer int
+
+const (
+	confirmPending confirmAnswer = iota
+	confirmYes
+	confirmNo
+)
+
+// answer maps a key press to an answer; other keys leave it pending.
+func (d *confirmDialog) answer(msg tea.KeyMsg) confirmAnswer {
+	switch {
+	case key.Matches(msg, d.keys.yes):
+		return confirmYes
+	case key.Matches(msg, d.keys.no):
+		return confirmNo
+	}
+	return confirmPending
+}
+
+// openConfirm shows d and starts its auto-cancel timer.
+func (m *Model) openConfirm(d *confirmDialog) tea.Cmd {
+	if d.keys == nil {
+		d.keys = newConfirmKeyMap()
+	}
+	m.applyPrompt(d)
+	m.confirm = d
+	m.state = StateConfirm
+	return m.armConfirmTimeout()
+}
+
+func (m Model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := m.confirm
+	if d == nil {
+		m.state = StateHome
+		return m, nil
+	}
+	answer := d.answer(msg)
+	if answer == confirmPending {
+		// Any other key counts as activity and restarts the timeout
+		return m, m.armConfirmTimeout()
+	}
+	m.confirm = nil
+	m.state = StateHome
+	if d.result == nil {
+		return m, nil
+	}
+	return d.result(m, answer == confirmYes)
+}
+
+func (m Model) viewConfirm() string {
+	// Render list view dimmed in background - using centralized theme
+	listView := theme.ListDimmed.Render(m.list.View())
+
+	var b strings.Builder
+	if d := m.confirm; d != nil {
+		b.WriteString(dialogTitleStyle.Render(d.title))
+		b.WriteString("\n\n")
+		for _, f := range d.fields {
+			b.WriteString(theme.DialogLabel.Render(f.label + ": "))
+			b.WriteString(theme.DialogValue.Render(f.value))
+			b.WriteString("\n")
+		}
+		if len(d.fields) > 0 {
+			b.WriteString("\n")
+		}
+		if d.note != "" {
+			b.WriteString(theme.DialogNote.Render(d.note))
+			b.WriteString("\n\n")
+		}
+		b.WriteString(theme.DialogChoiceKey.Render(d.keys.yes.Help().Key))
+		b.WriteString(theme.DialogChoiceSep.Render(" " + d.keys.yes.Help().Desc + " • "))
+		b.WriteString(theme.DialogChoiceKey.Render(d.keys.no.Help().Key))
+		b.WriteString(theme.DialogChoiceSep.Render(" " + d.keys.no.Help().Desc))
+	}
+
+	return appStyle.Render(listView + "\n\n" + dialogStyle.Render(b.String()))
+}
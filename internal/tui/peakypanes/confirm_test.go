@@ -0,0 +1,90 @@
+package peakypanes
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testDialog returns a dialog that records how it was answered.
+func testDialog(answers *[]bool) *confirmDialog {
+	return &confirmDialog{
+		title:  "Do it?",
+		action: "Doing it",
+		fields: []confirmField{{"Thing", "widget"}},
+		note:   "This is only a test",
+		result: func(m Model, confirmed bool) (tea.Model, tea.Cmd) {
+			*answers = append(*answers, confirmed)
+			return m, nil
+		},
+	}
+}
+
+// TestConfirmDialogAnswer tests key handling independent of any action
+func TestConfirmDialogAnswer(t *testing.T) {
+	d := &confirmDialog{keys: newConfirmKeyMap()}
+	tests := []struct {
+		key  string
+		want confirmAnswer
+	}{
+		{"y", confirmYes},
+		{"enter", confirmYes},
+		{"n", confirmNo},
+		{"esc", confirmNo},
+		{"x", confirmPending},
+		{"up", confirmPending},
+	}
+	for _, tt := range tests {
+		if got := d.answer(keyMsg(tt.key)); got != tt.want {
+			t.Errorf("answer(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+// TestConfirmDialogResult tests that the callback sees the answer and the dialog closes
+func TestConfirmDialogResult(t *testing.T) {
+	tests := []struct {
+		keys []string
+		want []bool
+	}{
+		{keys: []string{"y"}, want: []bool{true}},
+		{keys: []string{"enter"}, want: []bool{true}},
+		{keys: []string{"n"}, want: []bool{false}},
+		{keys: []string{"esc"}, want: []bool{false}},
+		{keys: []string{"x", "q", "y"}, want: []bool{true}},
+	}
+	for _, tt := range tests {
+		m, _ := newTestModel(t, nil)
+		var answers []bool
+		m.openConfirm(testDialog(&answers))
+		if m.state != StateConfirm {
+			t.Fatalf("state = %v, want StateConfirm", m.state)
+		}
+
+		model := press(t, *m, tt.keys...)
+		if model.state != StateHome || model.confirm != nil {
+			t.Errorf("keys %q: state = %v, confirm = %v; want closed", tt.keys, model.state, model.confirm)
+		}
+		if len(answers) != 1 || answers[0] != tt.want[0] {
+			t.Errorf("keys %q: answers = %v, want %v", tt.keys, answers, tt.want)
+		}
+	}
+}
+
+// TestConfirmDialogView tests that the dialog renders its content
+func TestConfirmDialogView(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	var answers []bool
+	m.openConfirm(testDialog(&answers))
+
+	view := m.View()
+	for _, want := range []string{"Do it?", "Thing: ", "widget", "This is only a test", "confirm", "cancel"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+	if len(answers) != 0 {
+		t.Error("rendering must not answer the dialog")
+	}
+}
@@ -0,0 +1,123 @@
+package peakypanes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// confirmPrompts are the confirmations whose wording confirm_prompts can
+// replace, by name.
+var confirmPrompts = []string{"kill", "kill_busy", "adopt", "create_dir", "clear_scrollback"}
+
+// promptConfig rewords one confirmation. Each field is a text/template
+// over promptData; empty fields keep the English default.
+type promptConfig struct {
+	Title  string `yaml:"title"`
+	Note   string `yaml:"note"`
+	Action string `yaml:"action"`
+}
+
+// promptData holds the placeholders available to confirm_prompts.
+type promptData struct {
+	Session string // tmux session name
+	Project string // project name
+	Path    string // project path, shortened with ~
+	Windows int    // window count of a running session; 0 when unknown
+}
+
+// promptDataFor returns the placeholders describing p.
+func promptDataFor(p Project) promptData {
+	return promptData{Session: p.Session, Project: p.Name, Path: shortenPath(p.Path)}
+}
+
+// promptTemplates are the parsed templates of one confirmation; nil ones
+// keep the default.
+type promptTemplates struct {
+	title, note, action *template.Template
+}
+
+// parseConfirmPrompts compiles confirm_prompts. Entries for unknown
+// confirmations and templates that fail to parse or render are reported
+// and left out, so their defaults apply.
+func parseConfirmPrompts(prompts map[string]promptConfig) (map[string]promptTemplates, error) {
+	if len(prompts) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]bool, len(confirmPrompts))
+	for _, name := range confirmPrompts {
+		known[name] = true
+	}
+	parsed := make(map[string]promptTemplates)
+	var problems []string
+	for name, pc := range prompts {
+		if !known[name] {
+			problems = append(problems, "unknown prompt "+name)
+			continue
+		}
+		var t promptTemplates
+		for _, f := range []struct {
+			key  string
+			text string
+			dst  **template.Template
+		}{
+			{"title", pc.Title, &t.title},
+			{"note", pc.Note, &t.note},
+			{"action", pc.Action, &t.action},
+		} {
+			tmpl, err := parsePrompt(name+"."+f.key, f.text)
+			if err != nil {
+				problems = append(problems, err.Error())
+				continue
+			}
+			*f.dst = tmpl
+		}
+		parsed[name] = t
+	}
+	if len(problems) == 0 {
+		return parsed, nil
+	}
+	sort.Strings(problems)
+	return parsed, fmt.Errorf("confirm_prompts: %s", strings.Join(problems, "; "))
+}
+
+// parsePrompt compiles and test-renders one template. Blank text yields
+// nil, meaning the default.
+func parsePrompt(name, text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(new(strings.Builder), promptData{}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// renderPrompt renders tmpl with data, returning def when tmpl is nil or
+// fails.
+func renderPrompt(tmpl *template.Template, data promptData, def string) string {
+	if tmpl == nil {
+		return def
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return def
+	}
+	return b.String()
+}
+
+// applyPrompt rewords d with the configured templates for its prompt.
+func (m *Model) applyPrompt(d *confirmDialog) {
+	t, ok := m.prompts[d.prompt]
+	if !ok {
+		return
+	}
+	d.title = renderPrompt(t.title, d.data, d.title)
+	d.note = renderPrompt(t.note, d.data, d.note)
+	d.action = renderPrompt(t.action, d.data, d.action)
+}
@@ -0,0 +1,104 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestConfirmPromptTemplate tests a configured kill prompt is rendered with
+// the session and window count
+func TestConfirmPromptTemplate(t *testing.T) {
+	p := Project{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}
+	m, _ := newTestModel(t, []Project{p})
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		if len(args) > 0 && args[0] == "display-message" {
+			return exec.CommandContext(ctx, "printf", "1\t0\tshell\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+	prompts, err := parseConfirmPrompts(map[string]promptConfig{
+		"kill": {
+			Title:  "Sitzung {{.Session}} beenden?",
+			Note:   "{{.Project}}: {{.Windows}} Fenster",
+			Action: "Beenden",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.prompts = prompts
+
+	m.startKill(p)
+	d := m.confirm
+	if d == nil {
+		t.Fatal("no confirmation opened")
+	}
+	if d.title != "Sitzung api beenden?" {
+		t.Errorf("title = %q", d.title)
+	}
+	if d.note != "api: 1 Fenster" {
+		t.Errorf("note = %q", d.note)
+	}
+	if d.action != "Beenden" {
+		t.Errorf("action = %q", d.action)
+	}
+}
+
+// TestConfirmPromptDefaults tests confirmations keep their English wording
+// without a template, with a partial one and with an unusable one
+func TestConfirmPromptDefaults(t *testing.T) {
+	p := Project{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}
+	def := killDialog(p, nil)
+
+	tests := []struct {
+		name      string
+		prompts   map[string]promptConfig
+		wantTitle string
+		wantNote  string
+		wantErr   string
+	}{
+		{name: "none", wantTitle: def.title, wantNote: def.note},
+		{
+			name:      "title only",
+			prompts:   map[string]promptConfig{"kill": {Title: "{{.Session}} beenden?"}},
+			wantTitle: "api beenden?",
+			wantNote:  def.note,
+		},
+		{
+			name:      "unknown placeholder",
+			prompts:   map[string]promptConfig{"kill": {Title: "{{.Sesion}}?"}},
+			wantTitle: def.title,
+			wantNote:  def.note,
+			wantErr:   "Sesion",
+		},
+		{
+			name:      "other prompt",
+			prompts:   map[string]promptConfig{"adopt": {Title: "Übernehmen?"}, "nuke": {Title: "?"}},
+			wantTitle: def.title,
+			wantNote:  def.note,
+			wantErr:   "unknown prompt nuke",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompts, err := parseConfirmPrompts(tt.prompts)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("parseConfirmPrompts() error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("parseConfirmPrompts() error = %v, want %q", err, tt.wantErr)
+			}
+			m, _ := newTestModel(t, []Project{p})
+			m.prompts = prompts
+			m.openConfirm(killDialog(p, nil))
+			if m.confirm.title != tt.wantTitle || m.confirm.note != tt.wantNote {
+				t.Errorf("dialog = %q / %q, want %q / %q", m.confirm.title, m.confirm.note, tt.wantTitle, tt.wantNote)
+			}
+			if m.confirm.action != "Kill" {
+				t.Errorf("action = %q, want Kill", m.confirm.action)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package peakypanes
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// confirmTimeoutMsg fires when a confirmation dialog has been left idle for
+// the configured timeout. seq identifies the dialog it was scheduled for so a
+// tick from an earlier dialog cannot cancel a newer one.
+type confirmTimeoutMsg struct {
+	seq int
+}
+
+// armConfirmTimeout (re)starts the auto-cancel timer for the open
+// confirmation. It returns nil when the timeout is disabled.
+func (m *Model) armConfirmTimeout() tea.Cmd {
+	m.confirmSeq++
+	if m.confirmTimeout <= 0 {
+		return nil
+	}
+	seq := m.confirmSeq
+	return tea.Tick(m.confirmTimeout, func(time.Time) tea.Msg {
+		return confirmTimeoutMsg{seq: seq}
+	})
+}
+
+// handleConfirmTimeout cancels the open confirmation, or the kill window
+// or session question, if msg belongs to it.
+func (m Model) handleConfirmTimeout(msg confirmTimeoutMsg) (tea.Model, tea.Cmd) {
+	if m.state == StateKillChoice && m.killChoice != nil && msg.seq == m.confirmSeq {
+		m.killChoice = nil
+		m.state = StateHome
+		return m, m.list.NewStatusMessage(FormatStatusInfo("Kill cancelled (timed out)"))
+	}
+	if m.state != StateConfirm || m.confirm == nil || msg.seq != m.confirmSeq {
+		return m, nil
+	}
+	action := m.confirm.action
+	m.confirm = nil
+	m.state = StateHome
+	return m, m.list.NewStatusMessage(FormatStatusInfo(action + " cancelled (timed out)"))
+}
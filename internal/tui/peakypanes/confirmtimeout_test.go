@@ -0,0 +1,51 @@
+package peakypanes
+
+import (
+	"testing"
+	"time"
+)
+
+// TestConfirmKillTimeout tests that an idle kill confirmation auto-cancels
+func TestConfirmKillTimeout(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	m.confirmTimeout = time.Second
+
+	next, cmd := m.Update(keyMsg("K"))
+	model := next.(Model)
+	if model.state != StateConfirm {
+		t.Fatalf("state = %v, want StateConfirm", model.state)
+	}
+	if cmd == nil {
+		t.Fatal("opening the confirmation should schedule a timeout tick")
+	}
+
+	// A tick from an earlier dialog is ignored
+	next, _ = model.Update(confirmTimeoutMsg{seq: model.confirmSeq - 1})
+	if next.(Model).state != StateConfirm {
+		t.Fatal("stale timeout tick should not cancel the confirmation")
+	}
+
+	next, _ = model.Update(confirmTimeoutMsg{seq: model.confirmSeq})
+	model = next.(Model)
+	if model.state != StateHome || model.confirm != nil {
+		t.Errorf("after timeout state = %v, confirm = %v; want StateHome, nil", model.state, model.confirm)
+	}
+	for _, args := range calls.args {
+		if args[0] == "kill-session" {
+			t.Error("timeout must not kill the session")
+		}
+	}
+}
+
+// TestConfirmKillTimeoutDisabled tests that no tick is scheduled by default
+func TestConfirmKillTimeoutDisabled(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+
+	next, cmd := m.Update(keyMsg("K"))
+	if next.(Model).state != StateConfirm {
+		t.Fatalf("state = %v, want StateConfirm", next.(Model).state)
+	}
+	if cmd != nil {
+		t.Error("timeout tick scheduled while disabled")
+	}
+}
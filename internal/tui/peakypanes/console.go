@@ -0,0 +1,237 @@
+package peakypanes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// consoleCommands are the tmux subcommands the console may run. Commands that
+// can execute shell code are left out: run-shell, if-shell and pipe-pane, the
+// ones taking a shell-command (new-window, split-window, respawn-pane),
+// send-keys, which types into a shell, and set-option and set-window-option,
+// which can set hooks. So are commands that load files or act on the whole
+// server. consoleArgs also refuses #() in formats, which tmux runs as shell.
+var consoleCommands = map[string]bool{
+	"break-pane":          true,
+	"capture-pane":        true,
+	"display-message":     true,
+	"has-session":         true,
+	"kill-pane":           true,
+	"kill-window":         true,
+	"list-clients":        true,
+	"list-panes":          true,
+	"list-windows":        true,
+	"rename-session":      true,
+	"rename-window":       true,
+	"resize-pane":         true,
+	"select-layout":       true,
+	"select-pane":         true,
+	"select-window":       true,
+	"show-options":        true,
+	"show-window-options": true,
+}
+
+// console is the state of the tmux command console.
+type console struct {
+	session string
+	output  string
+	err     error
+}
+
+// splitArgs splits input into words like a shell would, honouring single and
+// double quotes and backslash escapes. No expansion is performed.
+func splitArgs(input string) ([]string, error) {
+	var args []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range input {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				args = append(args, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if escaped {
+		return nil, errors.New("trailing backslash")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		args = append(args, word.String())
+	}
+	return args, nil
+}
+
+// consoleArgs turns console input into a tmux argv. A leading "tmux" is
+// dropped, the subcommand must be in consoleCommands, no argument may hold a
+// #() shell command or end in ';', which tmux reads as the start of another
+// command, and session is added as the target unless the input names one
+// with -t.
+func consoleArgs(input, session string) ([]string, error) {
+	args, err := splitArgs(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 0 && args[0] == "tmux" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return nil, errors.New("enter a tmux command")
+	}
+	if !consoleCommands[args[0]] {
+		return nil, fmt.Errorf("tmux %s is not allowed in the console", args[0])
+	}
+	for _, arg := range args[1:] {
+		if strings.Contains(arg, "#(") {
+			return nil, errors.New("#() shell commands are not allowed in the console")
+		}
+		if strings.HasSuffix(arg, ";") {
+			return nil, errors.New("command separators (;) are not allowed in the console")
+		}
+	}
+	if hasTarget(args[1:]) || session == "" {
+		return args, nil
+	}
+	targeted := append([]string{args[0], "-t", session}, args[1:]...)
+	return targeted, nil
+}
+
+// hasTarget reports whether args set a target with -t, as "-t name" or
+// "-tname". Arguments after "--" are not flags.
+func hasTarget(args []string) bool {
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return false
+		case arg == "-t":
+			return i+1 < len(args)
+		case len(arg) > 2 && arg[:2] == "-t":
+			return true
+		}
+	}
+	return false
+}
+
+// startConsole opens the tmux command console for the selected session.
+func (m Model) startConsole() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	if !item.Status.running() {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Session is not running"))
+	}
+	m.console = &console{session: item.Session}
+	m.cmdInput = newCommandInput("list-windows")
+	m.cmdHistory = m.loadHistory(func(s *state.State) []string { return s.ConsoleHistory[item.Session] })
+	m.cmdInput.Prompt = ": "
+	m.state = StateConsole
+	return m, textinput.Blink
+}
+
+// runConsole runs the typed tmux command and keeps its output for display.
+func (m *Model) runConsole(input string) {
+	args, err := consoleArgs(input, m.console.session)
+	if err != nil {
+		m.console.output, m.console.err = "", err
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	m.console.output, m.console.err = m.tmux.Exec(ctx, args)
+}
+
+func (m Model) updateConsole(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.console == nil {
+		m.state = StateHome
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.console = nil
+		m.state = StateHome
+		return m, nil
+	case "enter":
+		input := strings.TrimSpace(m.cmdInput.Value())
+		if input == "" {
+			return m, nil
+		}
+		m.runConsole(input)
+		session := m.console.session
+		m.saveHistory(func(s *state.State) { s.AddConsoleHistory(session, input) })
+		m.cmdHistory.add(input)
+		m.cmdInput.Reset()
+		return m, nil
+	}
+	if recall(m.cmdHistory, &m.cmdInput, msg.String()) {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.cmdInput, cmd = m.cmdInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) viewConsole() string {
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render(": tmux console"))
+	b.WriteString("\n\n")
+	if m.console != nil {
+		b.WriteString(theme.DialogLabel.Render("Session: "))
+		b.WriteString(theme.DialogValue.Render(m.console.session))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(m.cmdInput.View())
+	b.WriteString("\n\n")
+	if m.console != nil {
+		switch {
+		case m.console.err != nil:
+			b.WriteString(theme.StatusError.Render(m.console.err.Error()))
+			b.WriteString("\n\n")
+		case strings.TrimSpace(m.console.output) != "":
+			b.WriteString(theme.DialogValue.Render(strings.TrimRight(m.console.output, "\n")))
+			b.WriteString("\n\n")
+		}
+	}
+	b.WriteString(theme.DialogNote.Render("Targets the session unless -t is given; shell-running commands are not allowed • ↑/↓ history"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogChoiceKey.Render("enter"))
+	b.WriteString(theme.DialogChoiceSep.Render(" run • "))
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" close"))
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
@@ -0,0 +1,120 @@
+package peakypanes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestSplitArgs tests shell-like word splitting of console input
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{input: "list-windows", want: []string{"list-windows"}},
+		{input: "  rename-window   logs  ", want: []string{"rename-window", "logs"}},
+		{input: `display-message -p "#{pane_current_command} now"`, want: []string{"display-message", "-p", "#{pane_current_command} now"}},
+		{input: `send-keys 'echo "hi"' Enter`, want: []string{"send-keys", `echo "hi"`, "Enter"}},
+		{input: `rename-window my\ window`, want: []string{"rename-window", "my window"}},
+		{input: `rename-window ""`, want: []string{"rename-window", ""}},
+		{input: "", want: nil},
+		{input: `send-keys "oops`, wantErr: true},
+		{input: `send-keys oops\`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := splitArgs(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("splitArgs(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitArgs(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestConsoleArgs tests targeting and the subcommand allowlist
+func TestConsoleArgs(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []string
+		wantErr string
+	}{
+		{input: "list-windows", want: []string{"list-windows", "-t", "api"}},
+		{input: "tmux rename-window logs", want: []string{"rename-window", "-t", "api", "logs"}},
+		{input: "select-window -t api:2", want: []string{"select-window", "-t", "api:2"}},
+		{input: "kill-pane -tapi:1.2", want: []string{"kill-pane", "-tapi:1.2"}},
+		{input: "kill-server", wantErr: "not allowed"},
+		{input: "run-shell 'rm -rf ~'", wantErr: "not allowed"},
+		{input: "split-window 'rm -rf ~'", wantErr: "not allowed"},
+		{input: "send-keys 'rm -rf ~' Enter", wantErr: "not allowed"},
+		{input: "set-option session-created[0] 'run-shell x'", wantErr: "not allowed"},
+		{input: "display-message -p '#(rm -rf ~)'", wantErr: "not allowed"},
+		{input: "list-windows ; run-shell 'touch /tmp/x'", wantErr: "separators"},
+		{input: "list-windows \\; run-shell 'touch /tmp/x'", wantErr: "separators"},
+		{input: "list-windows '\\;' run-shell x", wantErr: "separators"},
+		{input: "rename-window logs; run-shell x", wantErr: "separators"},
+		{input: "rename-window -- -tmp", want: []string{"rename-window", "-t", "api", "--", "-tmp"}},
+		{input: "list-windows -F '#{window_name} #(id)'", wantErr: "not allowed"},
+		{input: "display-message -p '#{session_name}'", want: []string{"display-message", "-t", "api", "-p", "#{session_name}"}},
+		{input: "tmux", wantErr: "enter a tmux command"},
+		{input: `send-keys "x`, wantErr: "unterminated"},
+	}
+
+	for _, tt := range tests {
+		got, err := consoleArgs(tt.input, "api")
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("consoleArgs(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("consoleArgs(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	shellCapable := []string{
+		"run-shell", "if-shell", "pipe-pane", "new-window", "split-window",
+		"respawn-pane", "respawn-window", "send-keys", "set-option",
+		"set-window-option", "set-hook", "command-prompt", "source-file", "kill-server",
+	}
+	for _, name := range shellCapable {
+		if consoleCommands[name] {
+			t.Errorf("%s must not be allowed in the console", name)
+		}
+	}
+}
+
+// TestConsoleRunsCommand tests running a command against the selected session
+func TestConsoleRunsCommand(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+
+	model := press(t, *m, ":")
+	if model.state != StateConsole {
+		t.Fatalf("state = %v, want StateConsole", model.state)
+	}
+	model = press(t, model, "list-panes", "enter")
+	if model.state != StateConsole {
+		t.Errorf("console should stay open after running, state = %v", model.state)
+	}
+	if want := [][]string{{"list-panes", "-t", "api"}}; !reflect.DeepEqual(calls.args, want) {
+		t.Errorf("tmux calls = %q, want %q", calls.args, want)
+	}
+
+	model = press(t, model, "kill-server", "enter")
+	if model.console.err == nil || len(calls.args) != 1 {
+		t.Errorf("disallowed command should fail without running tmux: err = %v, calls = %q", model.console.err, calls.args)
+	}
+	if !strings.Contains(model.View(), "not allowed") {
+		t.Error("console view should show the error")
+	}
+
+	model = press(t, model, "esc")
+	if model.state != StateHome || model.console != nil {
+		t.Errorf("esc should close the console, state = %v", model.state)
+	}
+}
@@ -0,0 +1,332 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// createStep is a step in the guided quick-create flow.
+type createStep int
+
+const (
+	createStepName createStep = iota
+	createStepPath
+	createStepLayout
+	createStepDone
+)
+
+// createFlow is a small state machine for the quick-create flow:
+// name -> path -> layout. Each step can go back to the previous one.
+type createFlow struct {
+	step    createStep
+	name    string
+	path    string
+	layouts []string
+	layout  int
+	input   textinput.Model
+}
+
+func newCreateFlow(layouts []string) *createFlow {
+	f := &createFlow{layouts: layouts}
+	for i, name := range layouts {
+		if name == "dev-3" {
+			f.layout = i
+		}
+	}
+	f.input = textinput.New()
+	f.input.Prompt = "› "
+	f.input.CharLimit = 256
+	f.enterStep(createStepName)
+	return f
+}
+
+// enterStep switches to the given step and primes the text input with the
+// value previously entered for it.
+func (f *createFlow) enterStep(step createStep) {
+	f.step = step
+	switch step {
+	case createStepName:
+		f.input.Placeholder = "my-project"
+		f.input.SetValue(f.name)
+		f.input.CursorEnd()
+		f.input.Focus()
+	case createStepPath:
+		f.input.Placeholder = "~/projects/my-project"
+		f.input.SetValue(f.path)
+		f.input.CursorEnd()
+		f.input.Focus()
+	default:
+		f.input.Blur()
+	}
+}
+
+// next commits the current step and advances. It returns false when the
+// current step's value is not acceptable yet.
+func (f *createFlow) next() bool {
+	switch f.step {
+	case createStepName:
+		name := strings.TrimSpace(f.input.Value())
+		if name == "" {
+			return false
+		}
+		f.name = name
+		if f.path == "" {
+			f.path = filepath.Join("~", "projects", name)
+		}
+		f.enterStep(createStepPath)
+	case createStepPath:
+		path := strings.TrimSpace(f.input.Value())
+		if path == "" {
+			return false
+		}
+		f.path = path
+		f.enterStep(createStepLayout)
+	case createStepLayout:
+		f.enterStep(createStepDone)
+	}
+	return true
+}
+
+// back returns to the previous step, saving any input typed so far. It
+// returns false when already on the first step, meaning the flow is cancelled.
+func (f *createFlow) back() bool {
+	switch f.step {
+	case createStepName:
+		return false
+	case createStepPath:
+		f.path = strings.TrimSpace(f.input.Value())
+		f.enterStep(createStepName)
+	case createStepLayout:
+		f.enterStep(createStepPath)
+	case createStepDone:
+		f.enterStep(createStepLayout)
+	}
+	return true
+}
+
+// moveLayout moves the layout selection by delta, clamped to the list.
+func (f *createFlow) moveLayout(delta int) {
+	if len(f.layouts) == 0 {
+		return
+	}
+	f.layout += delta
+	if f.layout < 0 {
+		f.layout = 0
+	}
+	if f.layout >= len(f.layouts) {
+		f.layout = len(f.layouts) - 1
+	}
+}
+
+func (f *createFlow) selectedLayout() string {
+	if f.layout < 0 || f.layout >= len(f.layouts) {
+		return ""
+	}
+	return f.layouts[f.layout]
+}
+
+// project builds the Project described by the flow.
+func (f *createFlow) project() Project {
+	return Project{
+		Name:    f.name,
+		Session: sanitizeSessionName(f.name),
+		Path:    expandPath(f.path),
+		Layout:  f.selectedLayout(),
+		Status:  StatusStopped,
+	}
+}
+
+// completePath completes the last path segment of input against existing
+// directories. A unique match is completed fully (with a trailing slash);
+// multiple matches are completed to their longest common prefix. Tilde
+// prefixes are preserved.
+func completePath(input string) string {
+	if input == "" {
+		return input
+	}
+	expanded := expandPath(input)
+	if input == "~" {
+		return input + string(filepath.Separator)
+	}
+
+	dir, prefix := filepath.Split(expanded)
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return input
+	}
+
+	var matches []string
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		if strings.HasPrefix(e.Name(), ".") && !strings.HasPrefix(prefix, ".") {
+			continue
+		}
+		matches = append(matches, e.Name())
+	}
+	if len(matches) == 0 {
+		return input
+	}
+
+	completion := matches[0]
+	if len(matches) == 1 {
+		completion += string(filepath.Separator)
+	} else {
+		for _, m := range matches[1:] {
+			completion = commonPrefix(completion, m)
+		}
+	}
+
+	_, typed := filepath.Split(input)
+	return strings.TrimSuffix(input, typed) + completion
+}
+
+// commonPrefix returns the longest common prefix of a and b in whole
+// runes, so a completion never ends inside a multi-byte character.
+func commonPrefix(a, b string) string {
+	i := 0
+	for i < len(a) && i < len(b) {
+		ra, na := utf8.DecodeRuneInString(a[i:])
+		rb, nb := utf8.DecodeRuneInString(b[i:])
+		if ra != rb || na != nb || a[i:i+na] != b[i:i+nb] {
+			break
+		}
+		i += na
+	}
+	return a[:i]
+}
+
+func (m Model) updateQuickCreate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := m.createFlow
+	if f == nil {
+		m.state = StateHome
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		m.createFlow = nil
+		m.state = StateHome
+		return m, nil
+	case "esc":
+		if !f.back() {
+			m.createFlow = nil
+			m.state = StateHome
+		}
+		return m, nil
+	case "enter":
+		if !f.next() {
+			return m, nil
+		}
+		if f.step == createStepDone {
+			p := f.project()
+			m.createFlow = nil
+			m.state = StateHome
+			return m.createSession(p)
+		}
+		return m, nil
+	}
+
+	switch f.step {
+	case createStepPath:
+		if msg.String() == "tab" {
+			f.input.SetValue(completePath(f.input.Value()))
+			f.input.CursorEnd()
+			return m, nil
+		}
+	case createStepLayout:
+		switch msg.String() {
+		case "up", "k", "shift+tab":
+			f.moveLayout(-1)
+		case "down", "j", "tab":
+			f.moveLayout(1)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) viewQuickCreate() string {
+	f := m.createFlow
+	if f == nil {
+		return m.viewHome()
+	}
+
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("✨ New Project"))
+	b.WriteString("\n\n")
+
+	if f.step > createStepName {
+		b.WriteString(theme.DialogLabel.Render("Name:   "))
+		b.WriteString(theme.DialogValue.Render(f.name))
+		b.WriteString("\n")
+	}
+	if f.step > createStepPath {
+		b.WriteString(theme.DialogLabel.Render("Path:   "))
+		b.WriteString(theme.DialogValue.Render(f.path))
+		b.WriteString("\n")
+	}
+	if f.step > createStepName {
+		b.WriteString("\n")
+	}
+
+	switch f.step {
+	case createStepName:
+		b.WriteString(theme.DialogLabel.Render("Project name"))
+		b.WriteString("\n")
+		b.WriteString(f.input.View())
+	case createStepPath:
+		b.WriteString(theme.DialogLabel.Render("Project path (tab to complete)"))
+		b.WriteString("\n")
+		b.WriteString(f.input.View())
+	case createStepLayout:
+		b.WriteString(theme.DialogLabel.Render("Layout"))
+		b.WriteString("\n")
+		if len(f.layouts) == 0 {
+			b.WriteString(theme.DialogNote.Render("No layouts found, the default will be used"))
+		}
+		for i, name := range f.layouts {
+			if i == f.layout {
+				b.WriteString(theme.DialogChoiceKey.Render("› " + name))
+			} else {
+				b.WriteString(theme.DialogValue.Render("  " + name))
+			}
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(theme.DialogChoiceKey.Render("enter"))
+	b.WriteString(theme.DialogChoiceSep.Render(" next • "))
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" back"))
+
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
+
+// layoutNames returns the names of all known layouts, sorted.
+func (m Model) layoutNames() []string {
+	if m.loader == nil {
+		return nil
+	}
+	infos := m.loader.ListLayouts()
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+	return names
+}
@@ -0,0 +1,143 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCreateFlowSteps tests advancing through all quick-create steps
+func TestCreateFlowSteps(t *testing.T) {
+	f := newCreateFlow([]string{"dev-2", "dev-3", "simple"})
+
+	if f.step != createStepName {
+		t.Fatalf("initial step = %d, want %d", f.step, createStepName)
+	}
+
+	// Empty name is rejected
+	if f.next() {
+		t.Error("next() with empty name should not advance")
+	}
+
+	f.input.SetValue("My App")
+	if !f.next() || f.step != createStepPath {
+		t.Fatalf("expected path step after name, got %d", f.step)
+	}
+	if f.input.Value() != filepath.Join("~", "projects", "My App") {
+		t.Errorf("path default = %q", f.input.Value())
+	}
+
+	f.input.SetValue("/tmp/my-app")
+	if !f.next() || f.step != createStepLayout {
+		t.Fatalf("expected layout step after path, got %d", f.step)
+	}
+	if f.selectedLayout() != "dev-3" {
+		t.Errorf("default layout = %q, want dev-3", f.selectedLayout())
+	}
+
+	f.moveLayout(1)
+	if !f.next() || f.step != createStepDone {
+		t.Fatalf("expected done step after layout, got %d", f.step)
+	}
+
+	p := f.project()
+	if p.Name != "My App" || p.Session != "my-app" || p.Path != "/tmp/my-app" || p.Layout != "simple" {
+		t.Errorf("project() = %+v", p)
+	}
+}
+
+// TestCreateFlowBack tests back navigation keeps entered values
+func TestCreateFlowBack(t *testing.T) {
+	f := newCreateFlow([]string{"dev-3"})
+
+	if f.back() {
+		t.Error("back() on first step should cancel")
+	}
+
+	f.input.SetValue("api")
+	f.next()
+	f.input.SetValue("/srv/api")
+
+	if !f.back() || f.step != createStepName {
+		t.Fatalf("expected name step after back, got %d", f.step)
+	}
+	if f.input.Value() != "api" {
+		t.Errorf("name input = %q, want api", f.input.Value())
+	}
+
+	f.next()
+	if f.input.Value() != "/srv/api" {
+		t.Errorf("path input = %q, want /srv/api (preserved)", f.input.Value())
+	}
+
+	f.next()
+	if !f.back() || f.step != createStepPath {
+		t.Fatalf("expected path step after back from layout, got %d", f.step)
+	}
+}
+
+// TestCreateFlowMoveLayout tests layout selection clamping
+func TestCreateFlowMoveLayout(t *testing.T) {
+	f := newCreateFlow([]string{"a", "b"})
+	f.moveLayout(-5)
+	if f.selectedLayout() != "a" {
+		t.Errorf("selectedLayout() = %q, want a", f.selectedLayout())
+	}
+	f.moveLayout(5)
+	if f.selectedLayout() != "b" {
+		t.Errorf("selectedLayout() = %q, want b", f.selectedLayout())
+	}
+
+	empty := newCreateFlow(nil)
+	empty.moveLayout(1)
+	if empty.selectedLayout() != "" {
+		t.Errorf("selectedLayout() with no layouts = %q", empty.selectedLayout())
+	}
+}
+
+// TestCompletePath tests directory completion
+func TestCompletePath(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"alpha", "alpine", "beta", "café", "cafè"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: filepath.Join(dir, "b"), want: filepath.Join(dir, "beta") + "/"},
+		{input: filepath.Join(dir, "al"), want: filepath.Join(dir, "alp")},
+		{input: filepath.Join(dir, "zz"), want: filepath.Join(dir, "zz")},
+		// é and è share their first byte
+		{input: filepath.Join(dir, "ca"), want: filepath.Join(dir, "caf")},
+		{input: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		got := completePath(tt.input)
+		if got != tt.want {
+			t.Errorf("completePath(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"alpha", "alpine", "alp"},
+		{"café", "cafè", "caf"},
+		{"日本語", "日本人", "日本"},
+		{"naïve", "naïveté", "naïve"},
+		{"abc", "xyz", ""},
+	}
+	for _, tt := range tests {
+		if got := commonPrefix(tt.a, tt.b); got != tt.want {
+			t.Errorf("commonPrefix(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package peakypanes
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startDetached starts the selected project's session in the background,
+// applying its layout but leaving the list open. Handy for pre-warming
+// several environments before attaching to any of them.
+func (m Model) startDetached() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	switch {
+	case item.Status.running():
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Session already running"))
+	case item.Status == StatusMissing:
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Path not found: " + shortenPath(item.Path)))
+	}
+	return m, tea.Batch(
+		m.list.NewStatusMessage(FormatStatusInfo("Starting "+item.Session+" in background…")),
+		m.startDetachedCmd(item),
+	)
+}
+
+// startedThenMsg reports a background start that continues with then
+// once it succeeded.
+type startedThenMsg struct {
+	SessionStartedMsg
+	then func(Model) tea.Cmd
+}
+
+// startDetachedCmd runs `peakypanes start --detach` for p without handing
+// it the terminal and reports the outcome as a SessionStartedMsg.
+func (m Model) startDetachedCmd(p Project) tea.Cmd {
+	return m.startDetachedRunCmd(p, "")
+}
+
+// startDetachedThen starts p in the background, typing run into its first
+// pane, and calls then on the refreshed model once the start succeeded.
+func (m Model) startDetachedThen(p Project, run string, then func(Model) tea.Cmd) tea.Cmd {
+	start := m.startDetachedRunCmd(p, run)
+	return func() tea.Msg {
+		started, _ := start().(SessionStartedMsg)
+		return startedThenMsg{SessionStartedMsg: started, then: then}
+	}
+}
+
+// handleStartedThen refreshes the list like any background start and
+// continues unless the start failed.
+func (m Model) handleStartedThen(msg startedThenMsg) (tea.Model, tea.Cmd) {
+	next, cmd := m.handleSessionStarted(msg.SessionStartedMsg)
+	if msg.Err != nil || msg.then == nil {
+		return next, cmd
+	}
+	return next, tea.Batch(cmd, msg.then(next.(Model)))
+}
+
+// startDetachedRunCmd is startDetachedCmd typing command into the first
+// pane.
+func (m Model) startDetachedRunCmd(p Project, command string) tea.Cmd {
+	args := startArgs(p, command, true)
+	run := m.startRunner
+	if run == nil {
+		run = runPeakypanes
+	}
+	return func() tea.Msg {
+		out, err := run(args...)
+		if err != nil {
+			if msg := lastLine(string(out)); msg != "" {
+				err = errors.New(msg)
+			}
+		}
+		return SessionStartedMsg{Session: p.Session, Err: err}
+	}
+}
+
+// runPeakypanes runs the peakypanes binary and returns its combined output.
+func runPeakypanes(args ...string) ([]byte, error) {
+	return exec.Command("peakypanes", args...).CombinedOutput()
+}
+
+// lastLine returns the last non-blank line of out, where the start command
+// prints its error.
+func lastLine(out string) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// handleSessionStarted refreshes the list once a background start finished.
+func (m Model) handleSessionStarted(msg SessionStartedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(NewErrorMsg(msg.Err, "start "+msg.Session)))
+	}
+	_ = m.refreshStatuses()
+	m.list.SetItems(m.projectsToItems())
+	return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Started %s in background", msg.Session)))
+}
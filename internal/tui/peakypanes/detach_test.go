@@ -0,0 +1,66 @@
+package peakypanes
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestStartArgsDetach tests that a background start passes --detach
+func TestStartArgsDetach(t *testing.T) {
+	p := Project{Name: "api", Session: "api", Path: "/srv/api"}
+	want := []string{"start", "--session", "api", "--path", "/srv/api", "--detach"}
+	if got := startArgs(p, "", true); !reflect.DeepEqual(got, want) {
+		t.Errorf("startArgs() = %q, want %q", got, want)
+	}
+}
+
+// TestStartDetachedKey tests that D starts in the background without attaching
+func TestStartDetachedKey(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: t.TempDir()}})
+
+	next, cmd := m.Update(keyMsg("D"))
+	model := next.(Model)
+	if model.state != StateHome {
+		t.Errorf("state = %v, want StateHome", model.state)
+	}
+	if cmd == nil {
+		t.Fatal("D on a stopped project should return a start command")
+	}
+	if len(calls.args) != 0 {
+		t.Errorf("D ran tmux commands, want none (no attach): %q", calls.args)
+	}
+}
+
+// TestStartDetachedRunning tests that a running session is not started again
+func TestStartDetachedRunning(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	model := press(t, *m, "D")
+	if model.state != StateHome || len(calls.args) != 0 {
+		t.Errorf("state = %v, tmux calls = %q", model.state, calls.args)
+	}
+}
+
+// TestHandleSessionStarted tests that a finished background start refreshes statuses
+func TestHandleSessionStarted(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api"}})
+
+	next, _ := m.Update(SessionStartedMsg{Session: "api"})
+	if next.(Model).state != StateHome {
+		t.Errorf("state = %v, want StateHome", next.(Model).state)
+	}
+	for _, args := range calls.args {
+		if args[0] == "attach-session" || args[0] == "switch-client" {
+			t.Errorf("background start attached: %q", args)
+		}
+	}
+	if len(calls.args) == 0 || calls.args[0][0] != "list-sessions" {
+		t.Errorf("tmux calls = %q, want a list-sessions refresh", calls.args)
+	}
+
+	calls.args = nil
+	m.Update(SessionStartedMsg{Session: "api", Err: errors.New("boom")})
+	if len(calls.args) != 0 {
+		t.Errorf("failed start should not refresh, got %q", calls.args)
+	}
+}
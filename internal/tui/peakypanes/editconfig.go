@@ -0,0 +1,64 @@
+package peakypanes
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+// defaultEditor is used when $EDITOR is not set.
+const defaultEditor = "vim"
+
+// configEditedMsg is sent when the editor opened on the config exits.
+type configEditedMsg struct{ err error }
+
+// editorArgs returns the command line opening path in editor, the value of
+// $EDITOR. It may carry flags, e.g. "code --wait".
+func editorArgs(editor, path string) []string {
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{defaultEditor}
+	}
+	return append(args, path)
+}
+
+// ensureConfigFile writes the commented config template to path when it
+// does not exist yet, so the editor does not open an empty file.
+func ensureConfigFile(path string) error {
+	if _, err := os.Stat(path); err == nil || !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	template := layout.ConfigTemplate(filepath.Join(filepath.Dir(path), "layouts"))
+	if err := os.WriteFile(path, []byte(template), 0o644); err != nil {
+		return fmt.Errorf("write config %q: %w", path, err)
+	}
+	return nil
+}
+
+// editConfig suspends the TUI and opens the config in $EDITOR; projects
+// are reloaded when it exits.
+func (m Model) editConfig() tea.Cmd {
+	if err := ensureConfigFile(m.configPath); err != nil {
+		return m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	args := editorArgs(os.Getenv("EDITOR"), m.configPath)
+	return tea.ExecProcess(exec.Command(args[0], args[1:]...), func(err error) tea.Msg {
+		return configEditedMsg{err: err}
+	})
+}
+
+// handleConfigEdited reloads projects after the editor exits.
+func (m Model) handleConfigEdited(msg configEditedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(fmt.Errorf("editor: %w", msg.err)))
+	}
+	return m.reloadProjects()
+}
@@ -0,0 +1,77 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestEditorArgs tests the editor command line built from $EDITOR
+func TestEditorArgs(t *testing.T) {
+	tests := []struct {
+		editor string
+		want   []string
+	}{
+		{"", []string{defaultEditor, "/c/config.yml"}},
+		{"  ", []string{defaultEditor, "/c/config.yml"}},
+		{"nvim", []string{"nvim", "/c/config.yml"}},
+		{"code --wait", []string{"code", "--wait", "/c/config.yml"}},
+	}
+	for _, tt := range tests {
+		if got := editorArgs(tt.editor, "/c/config.yml"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("editorArgs(%q) = %q, want %q", tt.editor, got, tt.want)
+		}
+	}
+}
+
+// TestEnsureConfigFile tests that a missing config is created from the
+// template and an existing one is left alone
+func TestEnsureConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "peakypanes", "config.yml")
+	if err := ensureConfigFile(path); err != nil {
+		t.Fatalf("ensureConfigFile() error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "# Peaky Panes") {
+		t.Errorf("template does not start with its header comment:\n%s", data)
+	}
+	if want := filepath.Join(filepath.Dir(path), "layouts"); !strings.Contains(string(data), want) {
+		t.Errorf("template does not list the layouts dir %s", want)
+	}
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Errorf("template is not a valid config: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("projects: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ensureConfigFile(path); err != nil {
+		t.Fatalf("ensureConfigFile() on existing file error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "projects: []\n" {
+		t.Errorf("existing config was overwritten: %q", data)
+	}
+}
+
+// TestConfigEditedReloads tests that projects are reloaded when the editor
+// exits
+func TestConfigEditedReloads(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	cfg := "projects:\n  - name: api\n    path: /src/api\n"
+	if err := os.WriteFile(m.configPath, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, _ := m.Update(configEditedMsg{})
+	if got := projectNames(updated.(Model).projects); got != "api" {
+		t.Errorf("projects after editing = %q, want api", got)
+	}
+}
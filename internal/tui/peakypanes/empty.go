@@ -0,0 +1,36 @@
+package peakypanes
+
+import (
+	"strings"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// defaultEmptyMessage is shown when no projects are configured. {config} is
+// replaced with the config file path.
+const defaultEmptyMessage = `No projects yet.
+
+Press n to open a project from ~/projects,
+or c to create one.
+
+Projects are listed in {config}.`
+
+// emptyMessage returns the empty-state text with placeholders expanded.
+func (m Model) emptyMessage() string {
+	msg := m.emptyText
+	if strings.TrimSpace(msg) == "" {
+		msg = defaultEmptyMessage
+	}
+	return strings.ReplaceAll(strings.TrimSpace(msg), "{config}", shortenPath(m.configPath))
+}
+
+// viewEmpty renders the home screen body when there are no projects.
+func (m Model) viewEmpty() string {
+	var b strings.Builder
+	b.WriteString(m.list.Styles.Title.Render(m.list.Title))
+	b.WriteString("\n")
+	b.WriteString(theme.EmptyState.Render(m.emptyMessage()))
+	b.WriteString("\n\n")
+	b.WriteString(m.list.Help.View(m.list))
+	return b.String()
+}
@@ -0,0 +1,26 @@
+package peakypanes
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestViewHomeEmptyState tests that the empty-state hint renders only without projects
+func TestViewHomeEmptyState(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	m.list.SetSize(80, 30)
+	if view := m.viewHome(); !strings.Contains(view, "No projects yet") {
+		t.Errorf("empty model should render the empty state:\n%s", view)
+	}
+
+	m.emptyText = "Nothing here, edit {config}"
+	if view := m.viewHome(); !strings.Contains(view, "Nothing here, edit "+shortenPath(m.configPath)) {
+		t.Errorf("custom empty message not rendered:\n%s", view)
+	}
+
+	m, _ = newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api"}})
+	m.list.SetSize(80, 30)
+	if view := m.viewHome(); strings.Contains(view, "No projects yet") {
+		t.Errorf("empty state rendered with projects present:\n%s", view)
+	}
+}
@@ -0,0 +1,55 @@
+package peakypanes
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// execMsg asks the TUI to quit so that argv can replace the peakypanes
+// process once the terminal is restored.
+type execMsg struct {
+	argv []string
+}
+
+// lookPath finds the executable of an exec target. Tests replace it.
+var lookPath = exec.LookPath
+
+// execMode reports whether opening a project replaces peakypanes.
+func (m Model) execMode() bool {
+	return m.execAttach || m.execFlag
+}
+
+// execCmd quits the TUI to exec into argv.
+func execCmd(argv []string) tea.Cmd {
+	return func() tea.Msg {
+		return execMsg{argv: argv}
+	}
+}
+
+// handleExec remembers argv for ExecArgv and quits.
+func (m Model) handleExec(msg execMsg) (tea.Model, tea.Cmd) {
+	m.execArgv = msg.argv
+	return m, tea.Quit
+}
+
+// ExecArgv returns the command chosen in exec mode, or nil when the TUI
+// quit without choosing a project. Pass it to Exec after the program ends.
+func (m Model) ExecArgv() []string {
+	return m.execArgv
+}
+
+// Exec replaces the current process with argv, looked up in PATH. It only
+// returns on failure.
+func Exec(argv []string) error {
+	if len(argv) == 0 {
+		return fmt.Errorf("nothing to exec")
+	}
+	path, err := lookPath(argv[0])
+	if err != nil {
+		return err
+	}
+	return execve(path, argv, os.Environ())
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package peakypanes
+
+import "errors"
+
+// execve is unavailable without Unix exec; exec mode reports it instead.
+var execve = func(path string, argv []string, env []string) error {
+	return errors.New("exec mode is only supported on Unix")
+}
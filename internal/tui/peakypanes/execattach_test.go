@@ -0,0 +1,120 @@
+package peakypanes
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// execArgvOf runs cmd and returns the argv of the exec it asks for.
+func execArgvOf(t *testing.T, cmd tea.Cmd) []string {
+	t.Helper()
+	for _, msg := range runCmd(cmd) {
+		if e, ok := msg.(execMsg); ok {
+			return e.argv
+		}
+	}
+	t.Fatal("no exec was requested")
+	return nil
+}
+
+// TestExecArgv tests the command exec mode replaces peakypanes with
+func TestExecArgv(t *testing.T) {
+	running := Project{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}
+	stopped := Project{Name: "web", Session: "web", Path: "/srv/web", Layout: "dev-3", Status: StatusStopped}
+
+	tests := []struct {
+		name       string
+		insideTmux bool
+		cmd        func(m Model) tea.Cmd
+		want       []string
+	}{
+		{
+			name: "attach outside tmux",
+			cmd:  func(m Model) tea.Cmd { return m.attachProject(running) },
+			want: []string{"tmux", "attach-session", "-t", "api"},
+		},
+		{
+			name:       "switch inside tmux",
+			insideTmux: true,
+			cmd:        func(m Model) tea.Cmd { return m.attachProject(running) },
+			want:       []string{"tmux", "switch-client", "-t", "api"},
+		},
+		{
+			name: "start stopped project",
+			cmd:  func(m Model) tea.Cmd { return m.startProject(stopped) },
+			want: []string{"peakypanes", "start", "--session", "web", "--path", "/srv/web", "--layout", "dev-3"},
+		},
+		{
+			name: "run once",
+			cmd:  func(m Model) tea.Cmd { return m.startProjectWith(stopped, "make test") },
+			want: []string{"peakypanes", "start", "--session", "web", "--path", "/srv/web", "--layout", "dev-3", "--run", "make test"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestModel(t, nil)
+			m.execFlag = true
+			m.insideTmux = tt.insideTmux
+			if got := execArgvOf(t, tt.cmd(*m)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("argv = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExecModeQuits tests that choosing a project in exec mode quits with
+// the exec target and that other modes keep the TUI running
+func TestExecModeQuits(t *testing.T) {
+	p := Project{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}
+	m, _ := newTestModel(t, []Project{p})
+	m.execAttach = true
+
+	next, cmd := m.Update(keyMsg("enter"))
+	next, cmd = next.(Model).Update(cmd())
+	model := next.(Model)
+	if want := []string{"tmux", "attach-session", "-t", "api"}; !reflect.DeepEqual(model.ExecArgv(), want) {
+		t.Errorf("ExecArgv() = %q, want %q", model.ExecArgv(), want)
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("exec mode should quit the TUI")
+	}
+
+	m.execAttach = false
+	for _, msg := range runCmd(m.attachProject(p)) {
+		if _, ok := msg.(execMsg); ok {
+			t.Error("attaching without exec mode must not exec")
+		}
+	}
+}
+
+// TestExec tests that Exec looks up the command and hands over argv
+func TestExec(t *testing.T) {
+	oldLook, oldExec := lookPath, execve
+	t.Cleanup(func() { lookPath, execve = oldLook, oldExec })
+
+	lookPath = func(file string) (string, error) { return "/usr/bin/" + file, nil }
+	var gotPath string
+	var gotArgv []string
+	execve = func(path string, argv []string, env []string) error {
+		gotPath, gotArgv = path, argv
+		return errors.New("exec failed")
+	}
+	argv := []string{"tmux", "attach-session", "-t", "api"}
+	if err := Exec(argv); err == nil || err.Error() != "exec failed" {
+		t.Errorf("Exec() error = %v, want the exec failure", err)
+	}
+	if gotPath != "/usr/bin/tmux" || !reflect.DeepEqual(gotArgv, argv) {
+		t.Errorf("exec(%q, %q), want /usr/bin/tmux with argv unchanged", gotPath, gotArgv)
+	}
+
+	lookPath = func(file string) (string, error) { return "", errors.New("not found") }
+	if err := Exec(argv); err == nil {
+		t.Error("Exec() should fail when the command is not in PATH")
+	}
+	if err := Exec(nil); err == nil {
+		t.Error("Exec() should fail without a command")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build unix
+
+package peakypanes
+
+import "syscall"
+
+// execve replaces the process image. Tests replace it.
+var execve = syscall.Exec
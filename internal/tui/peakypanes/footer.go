@@ -0,0 +1,40 @@
+package peakypanes
+
+import "github.com/charmbracelet/bubbles/list"
+
+// footerConfig is the footer section of the config. Every element is shown
+// unless set to false.
+type footerConfig struct {
+	// StatusBar is the line with the item count and status messages.
+	StatusBar *bool `yaml:"status_bar"`
+	// Hints is the key help below the list.
+	Hints *bool `yaml:"hints"`
+	// Pagination is the page indicator shown when the list has pages.
+	Pagination *bool `yaml:"pagination"`
+}
+
+// footer records which footer elements are hidden below the lists; the
+// zero value shows them all.
+type footer struct {
+	hideStatusBar  bool
+	hideHints      bool
+	hidePagination bool
+}
+
+// parseFooter resolves the footer config; unset elements are shown.
+func parseFooter(c footerConfig) footer {
+	hide := func(v *bool) bool { return v != nil && !*v }
+	return footer{
+		hideStatusBar:  hide(c.StatusBar),
+		hideHints:      hide(c.Hints),
+		hidePagination: hide(c.Pagination),
+	}
+}
+
+// apply shows or hides the footer elements of l. The list takes the rows
+// of hidden elements back for items when it computes its page size.
+func (f footer) apply(l *list.Model) {
+	l.SetShowStatusBar(!f.hideStatusBar)
+	l.SetShowHelp(!f.hideHints)
+	l.SetShowPagination(!f.hidePagination)
+}
@@ -0,0 +1,65 @@
+package peakypanes
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestParseFooter tests that unset footer elements stay visible
+func TestParseFooter(t *testing.T) {
+	off := false
+	on := true
+	if got := parseFooter(footerConfig{}); got != (footer{}) {
+		t.Errorf("parseFooter(empty) = %+v, want everything shown", got)
+	}
+	got := parseFooter(footerConfig{StatusBar: &off, Hints: &on})
+	if want := (footer{hideStatusBar: true}); got != want {
+		t.Errorf("parseFooter() = %+v, want %+v", got, want)
+	}
+}
+
+// TestFooterListHeight tests that the rows of hidden footer elements are
+// given to the list
+func TestFooterListHeight(t *testing.T) {
+	// Each row is a title and description line plus one line of spacing.
+	const rowHeight = 3
+	// Frame height 40 minus the two title lines leaves 38 rows; the status
+	// bar and hints take two each and the pagination line one.
+	tests := []struct {
+		name   string
+		footer string
+		want   int
+	}{
+		{"everything", "", (38 - 2 - 2 - 1) / rowHeight},
+		{"no status bar", "status_bar: false", (38 - 2 - 1) / rowHeight},
+		{"no hints", "hints: false", (38 - 2 - 1) / rowHeight},
+		{"no pagination", "pagination: false", (38 - 2 - 2) / rowHeight},
+		{"minimal", "status_bar: false\n  hints: false\n  pagination: false", 38 / rowHeight},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestModel(t, nil)
+			cfg := "show_logo: false\nprojects:\n"
+			for i := 0; i < 30; i++ {
+				cfg += fmt.Sprintf("  - name: p%d\n    path: /p%d\n", i, i)
+			}
+			if tt.footer != "" {
+				cfg += "footer:\n  " + tt.footer + "\n"
+			}
+			if err := os.WriteFile(m.configPath, []byte(cfg), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := m.loadConfig(); err != nil {
+				t.Fatalf("loadConfig() error: %v", err)
+			}
+			_, v := appStyle.GetFrameSize()
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40 + v})
+			if got := updated.(Model).list.Paginator.PerPage; got != tt.want {
+				t.Errorf("rows per page = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
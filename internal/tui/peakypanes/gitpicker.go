@@ -0,0 +1,124 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// pickerKeyMap holds the extra bindings of the git project picker.
+type pickerKeyMap struct {
+	toggleRegistered key.Binding
+	sort             key.Binding
+}
+
+func newPickerKeyMap() *pickerKeyMap {
+	return &pickerKeyMap{
+		toggleRegistered: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "show/hide added"),
+		),
+		sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort"),
+		),
+	}
+}
+
+// GitSortMode controls the order repos are listed in the git picker.
+type GitSortMode int
+
+const (
+	// SortByName keeps the scan order, alphabetical by relative path.
+	SortByName GitSortMode = iota
+	// SortByModified lists the most recently modified repo first.
+	SortByModified
+)
+
+func (s GitSortMode) String() string {
+	if s == SortByModified {
+		return "recently modified"
+	}
+	return "name"
+}
+
+// next cycles to the following picker sort mode.
+func (s GitSortMode) next() GitSortMode {
+	if s == SortByModified {
+		return SortByName
+	}
+	return SortByModified
+}
+
+// repoModTime returns when the repo at path last changed, as a cheap proxy
+// for the newest file in it: the latest mtime of .git/HEAD (checkouts),
+// .git/index (staging and commits) and the repo directory itself. Walking
+// the whole tree would be too slow for a picker.
+func repoModTime(path string) time.Time {
+	var latest time.Time
+	for _, p := range []string{
+		filepath.Join(path, ".git", "HEAD"),
+		filepath.Join(path, ".git", "index"),
+		path,
+	} {
+		if info, err := os.Stat(p); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
+// sortGitProjects returns the repos ordered according to mode. The input
+// slice is not modified.
+func sortGitProjects(projects []GitProject, mode GitSortMode) []GitProject {
+	sorted := make([]GitProject, len(projects))
+	copy(sorted, projects)
+	if mode != SortByModified {
+		return sorted
+	}
+	modified := make(map[string]time.Time, len(sorted))
+	for _, gp := range sorted {
+		modified[gp.Path] = repoModTime(gp.Path)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return modified[sorted[i].Path].After(modified[sorted[j].Path])
+	})
+	return sorted
+}
+
+// samePath reports whether two paths refer to the same location once tilde
+// expansion and cleaning are applied.
+func samePath(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return filepath.Clean(expandPath(a)) == filepath.Clean(expandPath(b))
+}
+
+// isRegistered reports whether gp is already configured as a project.
+func isRegistered(gp GitProject, projects []Project) bool {
+	for _, p := range projects {
+		if samePath(gp.Path, p.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleGitProjects returns the scanned repos to offer in the picker,
+// leaving out registered ones unless showAll is set.
+func visibleGitProjects(gitProjects []GitProject, projects []Project, showAll bool) []GitProject {
+	if showAll {
+		return gitProjects
+	}
+	var visible []GitProject
+	for _, gp := range gitProjects {
+		if !isRegistered(gp, projects) {
+			visible = append(visible, gp)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,147 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestIsRegistered tests matching git repos against project paths
+func TestIsRegistered(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	projects := []Project{
+		{Name: "api", Path: filepath.Join(home, "projects", "api")},
+		{Name: "web", Path: "~/projects/web"},
+		{Name: "live"}, // running session without a path
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{"absolute vs absolute", filepath.Join(home, "projects", "api"), true},
+		{"tilde vs absolute", "~/projects/api", true},
+		{"absolute vs tilde", filepath.Join(home, "projects", "web"), true},
+		{"trailing slash", filepath.Join(home, "projects", "api") + "/", true},
+		{"unregistered", "~/projects/cli", false},
+		{"prefix only", filepath.Join(home, "projects", "ap"), false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRegistered(GitProject{Path: tt.path}, projects); got != tt.want {
+				t.Errorf("isRegistered(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPickerToggleRegistered tests hiding and showing registered repos in the picker
+func TestPickerToggleRegistered(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api"}})
+	m.gitProjects = []GitProject{{Name: "api", Path: "/srv/api"}, {Name: "cli", Path: "/srv/cli"}}
+	m.projectPicker.SetItems(m.gitProjectsToItems())
+	m.state = StateProjectPicker
+
+	if n := len(m.projectPicker.Items()); n != 1 {
+		t.Fatalf("picker shows %d repos, want 1 (registered hidden)", n)
+	}
+	model := press(t, *m, "a")
+	if n := len(model.projectPicker.Items()); n != 2 {
+		t.Errorf("after toggle picker shows %d repos, want 2", n)
+	}
+	model = press(t, model, "a")
+	if n := len(model.projectPicker.Items()); n != 1 {
+		t.Errorf("after second toggle picker shows %d repos, want 1", n)
+	}
+}
+
+// makeRepo creates a repo fixture whose HEAD, index and directory were all
+// last modified at mtime.
+func makeRepo(t *testing.T, root, name string, mtime time.Time) GitProject {
+	t.Helper()
+	path := filepath.Join(root, name)
+	gitDir := filepath.Join(path, ".git")
+	if err := os.MkdirAll(gitDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"HEAD", "index"} {
+		if err := os.WriteFile(filepath.Join(gitDir, f), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, p := range []string{filepath.Join(gitDir, "HEAD"), filepath.Join(gitDir, "index"), path} {
+		if err := os.Chtimes(p, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return GitProject{Name: name, Path: path}
+}
+
+// TestSortGitProjectsByModified tests that recently modified repos come
+// first and that name order is kept otherwise
+func TestSortGitProjectsByModified(t *testing.T) {
+	root := t.TempDir()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	repos := []GitProject{
+		makeRepo(t, root, "api", base),
+		makeRepo(t, root, "cli", base.Add(48*time.Hour)),
+		makeRepo(t, root, "web", base.Add(24*time.Hour)),
+	}
+	// A commit touches only the index
+	index := filepath.Join(repos[0].Path, ".git", "index")
+	if err := os.Chtimes(index, base.Add(72*time.Hour), base.Add(72*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(gps []GitProject) string {
+		var out []string
+		for _, gp := range gps {
+			out = append(out, gp.Name)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := names(sortGitProjects(repos, SortByModified)); got != "api,cli,web" {
+		t.Errorf("SortByModified = %s, want api,cli,web", got)
+	}
+	if got := names(sortGitProjects(repos, SortByName)); got != "api,cli,web" {
+		t.Errorf("SortByName = %s, want scan order", got)
+	}
+
+	if err := os.Chtimes(index, base, base); err != nil {
+		t.Fatal(err)
+	}
+	if got := names(sortGitProjects(repos, SortByModified)); got != "cli,web,api" {
+		t.Errorf("SortByModified = %s, want cli,web,api", got)
+	}
+	if names(repos) != "api,cli,web" {
+		t.Error("sortGitProjects() modified its input")
+	}
+}
+
+// TestPickerSortKey tests that s toggles the picker order
+func TestPickerSortKey(t *testing.T) {
+	root := t.TempDir()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	m, _ := newTestModel(t, nil)
+	m.gitProjects = []GitProject{
+		makeRepo(t, root, "old", base),
+		makeRepo(t, root, "new", base.Add(time.Hour)),
+	}
+	m.projectPicker.SetItems(m.gitProjectsToItems())
+	m.state = StateProjectPicker
+
+	model := press(t, *m, "s")
+	if model.gitSortMode != SortByModified {
+		t.Fatalf("gitSortMode = %v, want SortByModified", model.gitSortMode)
+	}
+	if first := model.projectPicker.Items()[0].(GitProject); first.Name != "new" {
+		t.Errorf("first repo = %s, want new", first.Name)
+	}
+}
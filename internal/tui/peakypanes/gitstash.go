@@ -0,0 +1,40 @@
+package peakypanes
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// readStashLog returns the refs/stash reflog of the repo at path, the file
+// `git stash list` reads. Reading it directly keeps the picker scan free of
+// a git process per repo. Tests replace it.
+var readStashLog = func(repo string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(repo, ".git", "logs", "refs", "stash"))
+}
+
+// stashCount returns how many stash entries the repo at path has, one per
+// reflog line. A repo without stashes, or one that cannot be read, has none.
+func stashCount(repo string) int {
+	data, err := readStashLog(repo)
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// stashMarker formats a stash count for a description, e.g. "≡3". No
+// stashes show nothing.
+func stashMarker(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return "≡" + strconv.Itoa(n)
+}
@@ -0,0 +1,73 @@
+package peakypanes
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestStashCount tests counting stash reflog entries
+func TestStashCount(t *testing.T) {
+	old := readStashLog
+	t.Cleanup(func() { readStashLog = old })
+
+	tests := []struct {
+		name string
+		log  string
+		err  error
+		want int
+	}{
+		{name: "no reflog", err: os.ErrNotExist, want: 0},
+		{name: "empty reflog", log: "", want: 0},
+		{name: "one", log: "0000 1111 A <a@b> 1700000000 +0000\tWIP on main: abc\n", want: 1},
+		{name: "three", log: "a\nb\nc\n", want: 3},
+		{name: "unreadable", err: errors.New("permission denied"), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readStashLog = func(string) ([]byte, error) { return []byte(tt.log), tt.err }
+			if got := stashCount("/srv/api"); got != tt.want {
+				t.Errorf("stashCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGitProjectStashMarker tests that stashes show in the description and
+// that none show nothing
+func TestGitProjectStashMarker(t *testing.T) {
+	if got := (GitProject{Name: "api", Path: "/srv/api"}).Description(); strings.Contains(got, "≡") {
+		t.Errorf("Description() = %q, want no marker without stashes", got)
+	}
+	if got := (GitProject{Name: "api", Path: "/srv/api", Stashes: 3}).Description(); !strings.HasSuffix(got, "≡3") {
+		t.Errorf("Description() = %q, want the ≡3 marker", got)
+	}
+}
+
+// TestScanGitProjectsStashes tests that the scan reads each repo's stash
+// reflog
+func TestScanGitProjectsStashes(t *testing.T) {
+	root := t.TempDir()
+	for _, repo := range []string{"api", "web"} {
+		if err := os.MkdirAll(filepath.Join(root, repo, ".git"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	logs := filepath.Join(root, "api", ".git", "logs", "refs")
+	if err := os.MkdirAll(logs, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(logs, "stash"), []byte("a\nb\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]int{}
+	for _, gp := range ScanGitProjects(root) {
+		got[gp.Name] = gp.Stashes
+	}
+	if got["api"] != 2 || got["web"] != 0 {
+		t.Errorf("stashes = %v, want api=2 web=0", got)
+	}
+}
@@ -0,0 +1,52 @@
+package peakypanes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GlyphSet selects the characters used for status icons. Not every font
+// renders the default symbols well.
+type GlyphSet string
+
+const (
+	// GlyphsSymbol is the default set of geometric symbols.
+	GlyphsSymbol GlyphSet = "symbol"
+	// GlyphsASCII works in any terminal font.
+	GlyphsASCII GlyphSet = "ascii"
+	// GlyphsEmoji uses colored emoji.
+	GlyphsEmoji GlyphSet = "emoji"
+)
+
+// glyphs are the status icons of one set.
+type glyphs struct {
+	current, running, stopped, missing, unknown string
+}
+
+var glyphSets = map[GlyphSet]glyphs{
+	GlyphsSymbol: {current: "◆", running: "●", stopped: "○", missing: "⚠", unknown: "?"},
+	GlyphsASCII:  {current: "*", running: "o", stopped: ".", missing: "!", unknown: "?"},
+	GlyphsEmoji:  {current: "🔷", running: "🟢", stopped: "⚪", missing: "⚠️", unknown: "❔"},
+}
+
+// GlyphSets lists the available glyph set names.
+func GlyphSets() []GlyphSet {
+	return []GlyphSet{GlyphsSymbol, GlyphsASCII, GlyphsEmoji}
+}
+
+// ParseGlyphSet resolves a glyph set name case-insensitively. An empty name
+// is the default symbol set.
+func ParseGlyphSet(name string) (GlyphSet, error) {
+	set := GlyphSet(strings.ToLower(strings.TrimSpace(name)))
+	if set == "" {
+		return GlyphsSymbol, nil
+	}
+	if _, ok := glyphSets[set]; !ok {
+		names := make([]string, 0, len(glyphSets))
+		for _, s := range GlyphSets() {
+			names = append(names, string(s))
+		}
+		return GlyphsSymbol, fmt.Errorf("unknown glyph set %q (want %s)", name, strings.Join(names, ", "))
+	}
+	return set, nil
+}
@@ -0,0 +1,64 @@
+package peakypanes
+
+import "testing"
+
+// TestGlyphSetsDistinct tests that every set has a distinct glyph for each
+// status, including unknown ones
+func TestGlyphSetsDistinct(t *testing.T) {
+	statuses := []Status{StatusCurrent, StatusRunning, StatusStopped, StatusMissing, Status(99)}
+	for _, set := range GlyphSets() {
+		t.Run(string(set), func(t *testing.T) {
+			seen := make(map[string]Status)
+			for _, s := range statuses {
+				icon := statusIcon(s, set)
+				if icon == "" {
+					t.Errorf("statusIcon(%d) is empty", s)
+				}
+				if prev, dup := seen[icon]; dup {
+					t.Errorf("statuses %d and %d share %q", prev, s, icon)
+				}
+				seen[icon] = s
+			}
+		})
+	}
+}
+
+// TestASCIIGlyphs tests the plain ASCII set
+func TestASCIIGlyphs(t *testing.T) {
+	want := map[Status]string{StatusCurrent: "*", StatusRunning: "o", StatusStopped: ".", Status(99): "?"}
+	for s, icon := range want {
+		if got := statusIcon(s, GlyphsASCII); got != icon {
+			t.Errorf("statusIcon(%d, ascii) = %q, want %q", s, got, icon)
+		}
+	}
+}
+
+// TestParseGlyphSet tests glyph set names from flags and config
+func TestParseGlyphSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    GlyphSet
+		wantErr bool
+	}{
+		{name: "", want: GlyphsSymbol},
+		{name: "ascii", want: GlyphsASCII},
+		{name: " Emoji ", want: GlyphsEmoji},
+		{name: "nerd", want: GlyphsSymbol, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseGlyphSet(tt.name)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseGlyphSet(%q) = %q, %v", tt.name, got, err)
+		}
+	}
+}
+
+// TestTitleUsesGlyphSet tests that list titles use the active set
+func TestTitleUsesGlyphSet(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/api", Status: StatusRunning}})
+	m.glyphs = GlyphsASCII
+	item := m.projectsToItems()[0].(Project)
+	if got := item.Title(); got != "o api" {
+		t.Errorf("Title() = %q, want %q", got, "o api")
+	}
+}
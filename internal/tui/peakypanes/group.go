@@ -0,0 +1,107 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+)
+
+// groupHeader is the list row shown above the sessions of a tmux session
+// group. It carries no project, so per-project actions ignore it.
+type groupHeader struct {
+	Name     string
+	Sessions int
+}
+
+func (h groupHeader) Title() string { return "▾ " + h.Name }
+
+func (h groupHeader) Description() string {
+	if h.Sessions == 1 {
+		return "session group · 1 session"
+	}
+	return fmt.Sprintf("session group · %d sessions", h.Sessions)
+}
+
+// FilterValue is empty so headers drop out while filtering.
+func (h groupHeader) FilterValue() string { return "" }
+
+// group returns the configured session group, or the group tmux reports for
+// the running session.
+func (p Project) group() string {
+	if p.Group != "" {
+		return p.Group
+	}
+	return p.tmuxGroup
+}
+
+// groupProjects moves the members of each session group next to the first
+// member, keeping the order of everything else. A project whose session
+// another project joins belongs to the group named after it. The input
+// slice is not modified.
+func groupProjects(projects []Project) []Project {
+	anchors := make(map[string]bool)
+	for _, p := range projects {
+		if p.Group != "" {
+			anchors[p.Group] = true
+		}
+	}
+	projects = append([]Project(nil), projects...)
+	for i := range projects {
+		if p := &projects[i]; p.group() == "" && anchors[p.Session] {
+			p.tmuxGroup = p.Session
+		}
+	}
+
+	return clusterBy(projects, Project.group)
+}
+
+// clusterBy moves the projects sharing a non-empty key next to the first
+// of them, keeping the order of everything else.
+func clusterBy(projects []Project, key func(Project) string) []Project {
+	grouped := make([]Project, 0, len(projects))
+	placed := make(map[string]bool)
+	for i, p := range projects {
+		k := key(p)
+		if k == "" {
+			grouped = append(grouped, p)
+			continue
+		}
+		if placed[k] {
+			continue
+		}
+		placed[k] = true
+		for _, q := range projects[i:] {
+			if key(q) == k {
+				grouped = append(grouped, q)
+			}
+		}
+	}
+	return grouped
+}
+
+// groupSize counts the leading projects of group g.
+func groupSize(projects []Project, g string) int {
+	n := 0
+	for n < len(projects) && projects[n].group() == g {
+		n++
+	}
+	return n
+}
+
+// refreshGroups records the session group tmux reports for each running
+// session.
+func (m *Model) refreshGroups(ctx context.Context) {
+	groups, _ := m.tmux.SessionGroups(ctx)
+	for i := range m.projects {
+		m.projects[i].tmuxGroup = groups[m.projects[i].Session]
+	}
+}
+
+// selectSession selects the list row of session, if it is shown.
+func (m *Model) selectSession(session string) {
+	for i, item := range m.list.Items() {
+		if p, ok := item.(Project); ok && p.Session == session {
+			m.list.Select(i)
+			return
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package peakypanes
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGroupProjects tests that group members are listed together at the
+// first member's position, including the session they join
+func TestGroupProjects(t *testing.T) {
+	projects := []Project{
+		{Name: "api", Session: "api", Path: "/api"},
+		{Name: "web", Session: "web", Path: "/web"},
+		{Name: "api-review", Session: "api-review", Path: "/api", Group: "api"},
+		{Name: "docs", Session: "docs", Path: "/docs"},
+		{Name: "pair", Session: "pair", tmuxGroup: "ops"},
+		{Name: "ops", Session: "ops", tmuxGroup: "ops"},
+	}
+
+	got := groupProjects(projects)
+	if names := projectNames(got); names != "api,api-review,web,docs,pair,ops" {
+		t.Errorf("groupProjects() = %s", names)
+	}
+	if got[0].group() != "api" {
+		t.Errorf("joined session group = %q, want api", got[0].group())
+	}
+	if projects[0].tmuxGroup != "" {
+		t.Error("groupProjects() modified its input")
+	}
+}
+
+// TestGroupedDisplay tests that each group gets a header above its members
+func TestGroupedDisplay(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/api"},
+		{Name: "web", Session: "web", Path: "/web"},
+		{Name: "api-review", Session: "api-review", Path: "/api", Group: "api"},
+	})
+
+	var rows []string
+	for _, item := range m.list.Items() {
+		switch it := item.(type) {
+		case groupHeader:
+			rows = append(rows, "["+it.Name+"]")
+		case Project:
+			rows = append(rows, it.Name)
+		}
+	}
+	if got := strings.Join(rows, ","); got != "[api],api,api-review,web" {
+		t.Errorf("rows = %s, want [api],api,api-review,web", got)
+	}
+
+	header := m.list.Items()[0].(groupHeader)
+	if header.Sessions != 2 || !strings.Contains(header.Description(), "2 sessions") {
+		t.Errorf("header = %+v, description %q", header, header.Description())
+	}
+	if header.FilterValue() != "" {
+		t.Error("headers should not match filters")
+	}
+}
+
+// TestSelectSessionSkipsHeaders tests selecting a session by name when group
+// headers shift the row indices
+func TestSelectSessionSkipsHeaders(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/api"},
+		{Name: "api-review", Session: "api-review", Path: "/api", Group: "api"},
+	})
+	m.selectSession("api-review")
+	if p, ok := m.list.SelectedItem().(Project); !ok || p.Session != "api-review" {
+		t.Errorf("selected %#v, want api-review", m.list.SelectedItem())
+	}
+}
@@ -0,0 +1,150 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// healthcheckTimeout bounds how long a single healthcheck may run.
+const healthcheckTimeout = 2 * time.Second
+
+// Health is the result of a project's healthcheck.
+type Health int
+
+const (
+	HealthUnknown Health = iota
+	HealthOK
+	HealthFailing
+)
+
+// commandRunner runs a shell command. A non-nil error means it failed.
+type commandRunner func(ctx context.Context, command string) error
+
+// shellRunner runs the command through sh -c.
+func shellRunner(ctx context.Context, command string) error {
+	return exec.CommandContext(ctx, "sh", "-c", command).Run()
+}
+
+// checkHealth runs command with a timeout. A non-zero exit or a timeout is
+// reported as failing.
+func checkHealth(run commandRunner, command string, timeout time.Duration) Health {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- run(ctx, command) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return HealthFailing
+		}
+		return HealthOK
+	case <-ctx.Done():
+		return HealthFailing
+	}
+}
+
+// healthJob is a queued healthcheck of a project's session.
+type healthJob struct {
+	session string
+	command string
+}
+
+// healthMsg reports the results of healthchecks by session.
+type healthMsg struct {
+	results map[string]Health
+}
+
+// refreshHealth queues the healthchecks of all running projects. Stopped
+// projects are reset to HealthUnknown.
+func (m *Model) refreshHealth() {
+	all := make([]int, len(m.projects))
+	for i := range all {
+		all[i] = i
+	}
+	m.refreshHealthOf(all)
+}
+
+// refreshHealthOf queues the healthchecks of the projects at indices,
+// resetting stopped ones to HealthUnknown. Running projects keep their
+// last result until the check reports.
+func (m *Model) refreshHealthOf(indices []int) {
+	for _, i := range indices {
+		p := &m.projects[i]
+		if p.Healthcheck == "" || !p.Status.running() {
+			p.Health = HealthUnknown
+			continue
+		}
+		m.healthJobs = append(m.healthJobs, healthJob{session: p.Session, command: p.Healthcheck})
+	}
+}
+
+// takeHealthCmd returns a command running the queued healthchecks in
+// parallel, or nil when none are queued, and clears the queue.
+func (m *Model) takeHealthCmd() tea.Cmd {
+	jobs := m.healthJobs
+	m.healthJobs = nil
+	if len(jobs) == 0 {
+		return nil
+	}
+	run := m.healthRunner
+	if run == nil {
+		run = shellRunner
+	}
+	return func() tea.Msg {
+		results := make(map[string]Health, len(jobs))
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, job := range jobs {
+			wg.Add(1)
+			go func(job healthJob) {
+				defer wg.Done()
+				h := checkHealth(run, job.command, healthcheckTimeout)
+				mu.Lock()
+				results[job.session] = h
+				mu.Unlock()
+			}(job)
+		}
+		wg.Wait()
+		return healthMsg{results: results}
+	}
+}
+
+// applyHealth records the results in msg on the projects that still run a
+// healthcheck.
+func (m *Model) applyHealth(msg healthMsg) {
+	for i := range m.projects {
+		p := &m.projects[i]
+		if h, ok := msg.results[p.Session]; ok && p.Healthcheck != "" && p.Status.running() {
+			p.Health = h
+		}
+	}
+}
+
+// handleHealth shows the healthcheck results in msg.
+func (m Model) handleHealth(msg healthMsg) (tea.Model, tea.Cmd) {
+	m.applyHealth(msg)
+	index := m.list.Index()
+	m.list.SetItems(m.projectsToItems())
+	m.list.Select(index)
+	return m, nil
+}
+
+// healthDot renders the colored health indicator, or "" when unknown.
+func healthDot(h Health) string {
+	switch h {
+	case HealthOK:
+		return theme.HealthOK.Render("●")
+	case HealthFailing:
+		return theme.HealthFailing.Render("●")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,157 @@
+package peakypanes
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestCheckHealth tests healthy, unhealthy and timed out checks
+func TestCheckHealth(t *testing.T) {
+	healthy := func(ctx context.Context, command string) error { return nil }
+	unhealthy := func(ctx context.Context, command string) error { return errors.New("exit status 22") }
+	hanging := func(ctx context.Context, command string) error {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}
+
+	tests := []struct {
+		name string
+		run  commandRunner
+		want Health
+	}{
+		{name: "healthy", run: healthy, want: HealthOK},
+		{name: "unhealthy", run: unhealthy, want: HealthFailing},
+		{name: "timeout", run: hanging, want: HealthFailing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkHealth(tt.run, "curl -sf localhost:3000", 20*time.Millisecond)
+			if got != tt.want {
+				t.Errorf("checkHealth() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// checkQueuedHealth runs the healthchecks queued on m and applies their
+// results.
+func checkQueuedHealth(m *Model) {
+	if cmd := m.takeHealthCmd(); cmd != nil {
+		m.applyHealth(cmd().(healthMsg))
+	}
+}
+
+// healthResults runs cmd and feeds its healthcheck results back through
+// Update.
+func healthResults(t *testing.T, m Model, cmd tea.Cmd) Model {
+	t.Helper()
+	for _, msg := range runCmd(cmd) {
+		if msg, ok := msg.(healthMsg); ok {
+			next, _ := m.Update(msg)
+			m = next.(Model)
+		}
+	}
+	return m
+}
+
+// TestHealthchecksInBackground tests that a refresh returns the
+// healthchecks as a command instead of running them in Update
+func TestHealthchecksInBackground(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Healthcheck: "api"}})
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		if len(args) == 3 && args[0] == "list-sessions" && args[2] == "#{session_name}" {
+			return exec.CommandContext(ctx, "printf", "api\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+	m.list.StatusMessageLifetime = time.Millisecond
+	var ran atomic.Int32
+	m.healthRunner = func(ctx context.Context, command string) error {
+		ran.Add(1)
+		return nil
+	}
+
+	next, cmd := m.Update(keyMsg("r"))
+	model := next.(Model)
+	if ran.Load() != 0 {
+		t.Fatal("Update ran the healthcheck itself")
+	}
+	if model.projects[0].Health != HealthUnknown {
+		t.Errorf("health before the check reports = %d, want HealthUnknown", model.projects[0].Health)
+	}
+	model = healthResults(t, model, cmd)
+	if ran.Load() != 1 {
+		t.Errorf("ran %d checks, want 1", ran.Load())
+	}
+	if model.projects[0].Health != HealthOK {
+		t.Errorf("health after the check reports = %d, want HealthOK", model.projects[0].Health)
+	}
+}
+
+// TestRefreshHealth tests only running projects with a healthcheck are checked
+func TestRefreshHealth(t *testing.T) {
+	var ran []string
+	m := &Model{
+		healthRunner: func(ctx context.Context, command string) error {
+			ran = append(ran, command)
+			if command == "fail" {
+				return errors.New("down")
+			}
+			return nil
+		},
+		projects: []Project{
+			{Name: "up", Status: StatusRunning, Healthcheck: "ok"},
+			{Name: "stopped", Status: StatusStopped, Healthcheck: "ok", Health: HealthOK},
+			{Name: "none", Status: StatusRunning},
+		},
+	}
+	m.refreshHealth()
+	if len(ran) != 0 {
+		t.Fatalf("ran %v before the queued checks were run", ran)
+	}
+	checkQueuedHealth(m)
+
+	if len(ran) != 1 {
+		t.Errorf("ran %d checks, want 1: %v", len(ran), ran)
+	}
+	if m.projects[0].Health != HealthOK {
+		t.Errorf("running project health = %d, want HealthOK", m.projects[0].Health)
+	}
+	if m.projects[1].Health != HealthUnknown {
+		t.Errorf("stopped project health = %d, want HealthUnknown", m.projects[1].Health)
+	}
+
+	m.projects[0].Healthcheck = "fail"
+	m.refreshHealth()
+	if m.projects[0].Health != HealthOK {
+		t.Errorf("health while the check runs = %d, want the last HealthOK", m.projects[0].Health)
+	}
+	checkQueuedHealth(m)
+	if m.projects[0].Health != HealthFailing {
+		t.Errorf("failing project health = %d, want HealthFailing", m.projects[0].Health)
+	}
+}
+
+// TestHealthDotInTitle tests the dot is only rendered for running projects
+func TestHealthDotInTitle(t *testing.T) {
+	p := Project{Name: "api", Status: StatusRunning, Health: HealthOK}
+	if !strings.HasPrefix(p.Title(), "● api ") {
+		t.Errorf("Title() = %q, want health dot suffix", p.Title())
+	}
+	p.Status = StatusStopped
+	if p.Title() != "○ api" {
+		t.Errorf("stopped Title() = %q, want no dot", p.Title())
+	}
+	if healthDot(HealthUnknown) != "" {
+		t.Error("healthDot(HealthUnknown) should be empty")
+	}
+}
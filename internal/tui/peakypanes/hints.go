@@ -0,0 +1,87 @@
+package peakypanes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// hintActions returns the bindings whose footer hint can be relabeled with
+// hint_labels, by action name.
+func hintActions(k *listKeyMap, d *delegateKeyMap, p *pickerKeyMap) map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"attach":         &d.choose,
+		"kill":           &d.kill,
+		"project_picker": &k.picker,
+		"open_project":   &k.openProject,
+		"switcher":       &k.switcher,
+		"windows":        &k.windows,
+		"quick_create":   &k.quickCreate,
+		"quick_add":      &k.quickAdd,
+		"clone":          &k.clone,
+		"move_up":        &k.moveUp,
+		"move_down":      &k.moveDown,
+		"sort":           &k.sort,
+		"fold":           &k.fold,
+		"fold_all":       &k.foldAll,
+		"tree_view":      &k.treeView,
+		"usage":          &k.usage,
+		"broadcast":      &k.broadcast,
+		"layout":         &k.layout,
+		"run_once":       &k.runOnce,
+		"detach":         &k.detach,
+		"start_edit":     &k.startEdit,
+		"peek":           &k.peek,
+		"undo":           &k.undo,
+		"adopt":          &k.adopt,
+		"shortcuts":      &k.shortcuts,
+		"console":        &k.console,
+		"preview":        &k.preview,
+		"readme":         &k.readme,
+		"prune":          &k.prune,
+		"bulk_rename":    &k.bulkRename,
+		"clear_history":  &k.clearHistory,
+		"copy_script":    &k.copyScript,
+		"capture_layout": &k.capture,
+		"archive":        &k.archive,
+		"show_archived":  &k.showArchived,
+		"hide_stopped":   &k.hideStopped,
+		"refresh":        &k.refresh,
+		"edit_config":    &k.editConfig,
+		"help":           &k.toggleHelp,
+		"picker_added":   &p.toggleRegistered,
+		"picker_sort":    &p.sort,
+	}
+}
+
+// applyHintLabels sets the hint of every action to its label in labels,
+// or back to the English default when it has none. Unknown action names
+// are reported but do not stop the others from applying.
+func (m *Model) applyHintLabels(labels map[string]string) error {
+	if m.keys == nil || m.delegateKeys == nil || m.pickerKeys == nil {
+		return nil
+	}
+	defaults := hintActions(newListKeyMap(), newDelegateKeyMap(), newPickerKeyMap())
+	actions := hintActions(m.keys, m.delegateKeys, m.pickerKeys)
+	for action, b := range actions {
+		label := defaults[action].Help().Desc
+		if l := labels[action]; l != "" {
+			label = l
+		}
+		b.SetHelp(b.Help().Key, label)
+	}
+
+	var unknown []string
+	for action := range labels {
+		if _, ok := actions[action]; !ok {
+			unknown = append(unknown, action)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown hint_labels action(s): %s", strings.Join(unknown, ", "))
+}
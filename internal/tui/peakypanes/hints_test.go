@@ -0,0 +1,64 @@
+package peakypanes
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// loadHintConfig writes cfg and loads it into m.
+func loadHintConfig(t *testing.T, m *Model, cfg string) {
+	t.Helper()
+	if err := os.WriteFile(m.configPath, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.loadConfig(); err != nil {
+		t.Fatalf("loadConfig() error: %v", err)
+	}
+	m.list.SetSize(200, 40)
+}
+
+// TestHintLabels tests that configured labels replace the footer hints and
+// the English defaults come back once they are removed
+func TestHintLabels(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	loadHintConfig(t, m, "hint_labels:\n  refresh: aktualisieren\n  kill: beenden\n  picker_sort: sortieren\nprojects:\n  - name: api\n    path: /api\n")
+
+	view := m.list.View()
+	for _, want := range []string{"aktualisieren", "beenden", "open project"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("footer does not show %q:\n%s", want, view)
+		}
+	}
+	if got := m.keys.refresh.Help(); got.Key != "r" || got.Desc != "aktualisieren" {
+		t.Errorf("refresh help = %+v, want key r labeled aktualisieren", got)
+	}
+	if got := m.pickerKeys.sort.Help().Desc; got != "sortieren" {
+		t.Errorf("picker sort hint = %q, want sortieren", got)
+	}
+	if m.hintWarning != nil {
+		t.Errorf("hintWarning = %v, want nil", m.hintWarning)
+	}
+
+	loadHintConfig(t, m, "projects:\n  - name: api\n    path: /api\n")
+	view = m.list.View()
+	for _, want := range []string{"refresh", "kill session"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("footer does not show default %q after removing labels:\n%s", want, view)
+		}
+	}
+}
+
+// TestHintLabelsUnknownAction tests that unknown actions are reported while
+// the known ones still apply
+func TestHintLabelsUnknownAction(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	loadHintConfig(t, m, "hint_labels:\n  refrseh: oops\n  zap: x\n  refresh: reload\n")
+
+	if m.hintWarning == nil || !strings.Contains(m.configWarning(), "refrseh, zap") {
+		t.Errorf("configWarning() = %q, want unknown actions listed", m.configWarning())
+	}
+	if got := m.keys.refresh.Help().Desc; got != "reload" {
+		t.Errorf("refresh hint = %q, want reload", got)
+	}
+}
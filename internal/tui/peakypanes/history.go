@@ -0,0 +1,108 @@
+package peakypanes
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+)
+
+// cmdHistory recalls earlier commands in a command input, shell style: up
+// steps to older entries, down back to newer ones and finally to the text
+// that was being typed.
+type cmdHistory struct {
+	// entries are the remembered commands, most recent first.
+	entries []string
+	// pos is the recalled entry, or -1 while editing a new command.
+	pos int
+	// draft is the unsent input saved when recall starts.
+	draft string
+}
+
+func newCmdHistory(entries []string) *cmdHistory {
+	return &cmdHistory{entries: entries, pos: -1}
+}
+
+// older returns the entry before the current one. current is kept as the
+// draft when recall starts. ok is false at the oldest entry.
+func (h *cmdHistory) older(current string) (string, bool) {
+	if h == nil || h.pos+1 >= len(h.entries) {
+		return "", false
+	}
+	if h.pos == -1 {
+		h.draft = current
+	}
+	h.pos++
+	return h.entries[h.pos], true
+}
+
+// newer returns the entry after the current one, or the draft when stepping
+// past the most recent entry. ok is false while not recalling.
+func (h *cmdHistory) newer() (string, bool) {
+	if h == nil || h.pos < 0 {
+		return "", false
+	}
+	h.pos--
+	if h.pos == -1 {
+		return h.draft, true
+	}
+	return h.entries[h.pos], true
+}
+
+// add puts command in front of the in-memory history and ends recall, for
+// inputs that stay open after running a command.
+func (h *cmdHistory) add(command string) {
+	if h == nil {
+		return
+	}
+	entries := []string{command}
+	for _, c := range h.entries {
+		if c != command {
+			entries = append(entries, c)
+		}
+	}
+	h.entries, h.pos, h.draft = entries, -1, ""
+}
+
+// recall handles up/down in a command input. It reports whether key was a
+// history key.
+func recall(h *cmdHistory, in *textinput.Model, key string) bool {
+	var (
+		value string
+		ok    bool
+	)
+	switch key {
+	case "up":
+		value, ok = h.older(in.Value())
+	case "down":
+		value, ok = h.newer()
+	default:
+		return false
+	}
+	if ok {
+		in.SetValue(value)
+		in.CursorEnd()
+	}
+	return true
+}
+
+// loadHistory returns the remembered commands picked by pick from the state
+// file. A missing or unreadable state yields an empty history.
+func (m Model) loadHistory(pick func(*state.State) []string) *cmdHistory {
+	if m.statePath == "" {
+		return newCmdHistory(nil)
+	}
+	st, err := state.Load(m.statePath)
+	if err != nil {
+		return newCmdHistory(nil)
+	}
+	return newCmdHistory(pick(st))
+}
+
+// saveHistory records a command in the state file. Failures are ignored:
+// history is a convenience, not critical state.
+func (m Model) saveHistory(add func(*state.State)) {
+	if m.statePath == "" {
+		return
+	}
+	_ = state.Update(m.statePath, add)
+}
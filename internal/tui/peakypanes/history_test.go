@@ -0,0 +1,81 @@
+package peakypanes
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+)
+
+// TestCmdHistoryRecall tests that up walks to older entries and down back
+// to newer ones and then to the draft
+func TestCmdHistoryRecall(t *testing.T) {
+	h := newCmdHistory([]string{"make lint", "npm test", "ls"})
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		if v, ok := h.older("npm r"); ok {
+			got = append(got, v)
+		}
+	}
+	if want := []string{"make lint", "npm test", "ls"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("up recall = %q, want %q", got, want)
+	}
+
+	got = nil
+	for i := 0; i < 4; i++ {
+		if v, ok := h.newer(); ok {
+			got = append(got, v)
+		}
+	}
+	if want := []string{"npm test", "make lint", "npm r"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("down recall = %q, want %q", got, want)
+	}
+
+	h.add("npm test")
+	if want := []string{"npm test", "make lint", "ls"}; !reflect.DeepEqual(h.entries, want) {
+		t.Errorf("entries after add = %q, want %q", h.entries, want)
+	}
+	if v, _ := h.older(""); v != "npm test" {
+		t.Errorf("first recall after add = %q, want npm test", v)
+	}
+}
+
+// TestRunOnceHistory tests that run-once commands are remembered per
+// project and recalled with up
+func TestRunOnceHistory(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: t.TempDir(), Status: StatusRunning},
+		{Name: "web", Session: "web", Path: t.TempDir(), Status: StatusRunning},
+	})
+	m.statePath = filepath.Join(t.TempDir(), "state.yml")
+	if err := state.Update(m.statePath, func(s *state.State) {
+		s.AddRunHistory("api", "npm test")
+		s.AddRunHistory("api", "make lint")
+		s.AddRunHistory("web", "yarn dev")
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	model := press(t, *m, "x", "up", "up")
+	if got := model.cmdInput.Value(); got != "npm test" {
+		t.Errorf("recalled %q, want npm test", got)
+	}
+	model = press(t, model, "down")
+	if got := model.cmdInput.Value(); got != "make lint" {
+		t.Errorf("recalled %q, want make lint", got)
+	}
+
+	press(t, model, "enter")
+	st, err := state.Load(m.statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"make lint", "npm test"}; !reflect.DeepEqual(st.RunHistory["api"], want) {
+		t.Errorf("RunHistory[api] = %q, want %q", st.RunHistory["api"], want)
+	}
+	if want := []string{"yarn dev"}; !reflect.DeepEqual(st.RunHistory["web"], want) {
+		t.Errorf("RunHistory[web] = %q, want %q", st.RunHistory["web"], want)
+	}
+}
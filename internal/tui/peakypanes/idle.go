@@ -0,0 +1,41 @@
+package peakypanes
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// idleTimeoutMsg fires when the TUI has had no input for the configured
+// idle timeout. seq identifies the input it was scheduled after; a tick
+// from before the latest key is ignored.
+type idleTimeoutMsg struct {
+	seq int
+}
+
+// idleTick schedules the idle timeout for the current input. It returns
+// nil when the timeout is disabled.
+func (m Model) idleTick() tea.Cmd {
+	if m.idleTimeout <= 0 {
+		return nil
+	}
+	seq := m.idleSeq
+	return tea.Tick(m.idleTimeout, func(time.Time) tea.Msg {
+		return idleTimeoutMsg{seq: seq}
+	})
+}
+
+// resetIdleTimeout restarts the idle timeout after input.
+func (m *Model) resetIdleTimeout() tea.Cmd {
+	m.idleSeq++
+	return m.idleTick()
+}
+
+// handleIdleTimeout quits when msg is the tick for the latest input.
+// Unsaved config changes keep the TUI open so they are not lost.
+func (m Model) handleIdleTimeout(msg idleTimeoutMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.idleSeq || m.state == StateConfirmSave {
+		return m, nil
+	}
+	return m, tea.Quit
+}
@@ -0,0 +1,72 @@
+package peakypanes
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func isQuit(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+// TestIdleTimeoutQuits tests that the tick scheduled at startup quits when
+// no key was pressed since
+func TestIdleTimeoutQuits(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api"}})
+	m.idleTimeout = time.Second
+
+	if m.idleTick() == nil {
+		t.Fatal("an enabled idle timeout should be scheduled")
+	}
+	_, cmd := m.Update(idleTimeoutMsg{seq: m.idleSeq})
+	if !isQuit(cmd) {
+		t.Error("the idle timeout should quit the TUI")
+	}
+}
+
+// TestIdleTimeoutResetByInput tests that a key press makes earlier ticks
+// stale and schedules a new one
+func TestIdleTimeoutResetByInput(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api"},
+		{Name: "web", Session: "web", Path: "/srv/web"},
+	})
+	m.idleTimeout = time.Second
+	before := m.idleSeq
+
+	next, cmd := m.Update(keyMsg("down"))
+	model := next.(Model)
+	if model.idleSeq != before+1 || cmd == nil {
+		t.Fatalf("a key should restart the idle timeout (seq %d → %d)", before, model.idleSeq)
+	}
+
+	_, cmd = model.Update(idleTimeoutMsg{seq: before})
+	if isQuit(cmd) {
+		t.Error("a tick from before the key press must not quit")
+	}
+	_, cmd = model.Update(idleTimeoutMsg{seq: model.idleSeq})
+	if !isQuit(cmd) {
+		t.Error("the tick after the last key should quit")
+	}
+}
+
+// TestIdleTimeoutDisabled tests that nothing is scheduled by default and
+// that unsaved config changes keep the TUI open
+func TestIdleTimeoutDisabled(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	if m.idleTick() != nil {
+		t.Error("no idle timeout should be scheduled by default")
+	}
+
+	m.idleTimeout = time.Second
+	m.state = StateConfirmSave
+	if _, cmd := m.Update(idleTimeoutMsg{seq: m.idleSeq}); isQuit(cmd) {
+		t.Error("the idle timeout must not drop unsaved config changes")
+	}
+}
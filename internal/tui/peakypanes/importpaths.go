@@ -0,0 +1,51 @@
+package peakypanes
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImportPaths reads a plain list of project directories, one per line, as
+// produced by e.g. `find ~/code -maxdepth 2 -name .git -printf '%h\n'`.
+// Blank lines and lines starting with # are skipped, ~ is expanded and
+// relative paths are resolved against the working directory. Each project
+// is named after its directory; a path listed twice is imported once.
+func ImportPaths(path string) ([]Project, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var projects []Project
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dir, err := filepath.Abs(expandPath(line))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		name := filepath.Base(dir)
+		projects = append(projects, Project{
+			Name:    name,
+			Session: sanitizeSessionName(name),
+			Path:    dir,
+			Status:  StatusStopped,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return projects, nil
+}
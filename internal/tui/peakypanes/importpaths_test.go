@@ -0,0 +1,62 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestImportPaths tests comment/blank skipping and name derivation
+func TestImportPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	file := filepath.Join(t.TempDir(), "paths.txt")
+	content := "# my repos\n\n/srv/api\n  /srv/My Web App/  \r\n   # indented comment\n~/code/cli\n/srv/api\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ImportPaths(file)
+	if err != nil {
+		t.Fatalf("ImportPaths() error: %v", err)
+	}
+	want := []Project{
+		{Name: "api", Session: "api", Path: "/srv/api"},
+		{Name: "My Web App", Session: "my-web-app", Path: "/srv/My Web App"},
+		{Name: "cli", Session: "cli", Path: filepath.Join(home, "code", "cli")},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ImportPaths() = %+v, want %d projects", got, len(want))
+	}
+	for i, p := range got {
+		if p.Name != want[i].Name || p.Session != want[i].Session || p.Path != want[i].Path {
+			t.Errorf("project %d = %+v, want %+v", i, p, want[i])
+		}
+	}
+}
+
+// TestImportPathsRelative tests that relative paths resolve against the working directory
+func TestImportPathsRelative(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "paths.txt")
+	if err := os.WriteFile(file, []byte("./repo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ImportPaths(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Path != filepath.Join(wd, "repo") || got[0].Name != "repo" {
+		t.Errorf("ImportPaths() = %+v", got)
+	}
+}
+
+// TestImportPathsMissingFile tests that an unreadable file is an error
+func TestImportPathsMissingFile(t *testing.T) {
+	if _, err := ImportPaths(filepath.Join(t.TempDir(), "nope.txt")); err == nil {
+		t.Error("ImportPaths() on a missing file should fail")
+	}
+}
@@ -0,0 +1,115 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// killChoice is the pending "window or session?" question for a session
+// with more than one window.
+type killChoice struct {
+	project Project
+	window  tmuxctl.ActiveWindow
+	busy    []string
+}
+
+// startKill asks what to kill for the running project p. Sessions with
+// several windows choose between the active window and the whole session;
+// single-window sessions, or ones whose windows cannot be listed, go
+// straight to the kill-session confirmation. Either way the user is warned
+// when panes run something other than an idle shell, and the question
+// auto-cancels after the confirm timeout like any other confirmation.
+func (m *Model) startKill(p Project) tea.Cmd {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	// Best effort: if the panes cannot be listed, the normal confirm is shown
+	commands, _ := m.tmux.PaneCommands(ctx, p.Session)
+	busy := busyCommands(commands)
+	win, err := m.tmux.SessionActiveWindow(ctx, p.Session)
+	if err != nil || win.Windows <= 1 {
+		d := killDialog(p, busy)
+		d.data.Windows = win.Windows
+		return m.openConfirm(d)
+	}
+	m.killChoice = &killChoice{project: p, window: win, busy: busy}
+	m.state = StateKillChoice
+	return m.armConfirmTimeout()
+}
+
+func (m Model) updateKillChoice(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	choice := m.killChoice
+	if choice == nil {
+		m.state = StateHome
+		return m, nil
+	}
+	switch msg.String() {
+	case "w":
+		m.killChoice = nil
+		m.state = StateHome
+		return m.killWindow(choice.project, choice.window)
+	case "s":
+		m.killChoice = nil
+		m.state = StateHome
+		return m.killSession(choice.project)
+	case "n", "esc":
+		m.killChoice = nil
+		m.state = StateHome
+		return m, nil
+	}
+	// Any other key counts as activity and restarts the timeout
+	return m, m.armConfirmTimeout()
+}
+
+// killWindow kills the active window of p's session, leaving the rest of
+// the session running.
+func (m Model) killWindow(p Project, win tmuxctl.ActiveWindow) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := m.tmux.KillWindow(ctx, p.Session, win.Index); err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	_ = m.refreshStatuses()
+	m.list.SetItems(m.projectsToItems())
+	return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Killed window %s in %s", win.Name, p.Session)))
+}
+
+func (m Model) viewKillChoice() string {
+	listView := theme.ListDimmed.Render(m.list.View())
+
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("⚠️  Kill Window or Session?"))
+	b.WriteString("\n\n")
+	if c := m.killChoice; c != nil {
+		b.WriteString(theme.DialogLabel.Render("Session: "))
+		b.WriteString(theme.DialogValue.Render(fmt.Sprintf("%s (%d windows)", c.project.Session, c.window.Windows)))
+		b.WriteString("\n")
+		b.WriteString(theme.DialogLabel.Render("Active window: "))
+		b.WriteString(theme.DialogValue.Render(c.window.Index + ":" + c.window.Name))
+		b.WriteString("\n")
+		note := "Notice this won't delete your project"
+		if len(c.busy) > 0 {
+			b.WriteString(theme.DialogLabel.Render("Running: "))
+			b.WriteString(theme.DialogValue.Render(strings.Join(c.busy, ", ")))
+			b.WriteString("\n")
+			note = "Panes are running programs, not idle shells: unsaved work in them will be lost"
+		}
+		b.WriteString("\n")
+		b.WriteString(theme.DialogNote.Render(note))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(theme.DialogChoiceKey.Render("w"))
+	b.WriteString(theme.DialogChoiceSep.Render(" kill window • "))
+	b.WriteString(theme.DialogChoiceKey.Render("s"))
+	b.WriteString(theme.DialogChoiceSep.Render(" kill session • "))
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" cancel"))
+
+	return appStyle.Render(listView + "\n\n" + dialogStyle.Render(b.String()))
+}
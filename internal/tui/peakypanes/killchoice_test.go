@@ -0,0 +1,116 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// windowsClient makes the test model report windows for the kill window query.
+func windowsClient(m *Model, calls *tmuxCalls, windows string) {
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls.args = append(calls.args, args)
+		if args[0] == "display-message" {
+			return exec.CommandContext(ctx, "printf", windows+"\t1\tlogs\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+}
+
+// TestKillBranchesOnWindowCount tests that only multi-window sessions ask window or session
+func TestKillBranchesOnWindowCount(t *testing.T) {
+	tests := []struct {
+		windows string
+		want    ViewState
+	}{
+		{"1", StateConfirm},
+		{"3", StateKillChoice},
+	}
+	for _, tt := range tests {
+		m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+		windowsClient(m, calls, tt.windows)
+
+		model := press(t, *m, "K")
+		if model.state != tt.want {
+			t.Errorf("%s windows: state = %v, want %v", tt.windows, model.state, tt.want)
+		}
+	}
+}
+
+// TestKillChoiceCommands tests the tmux command issued for each choice
+func TestKillChoiceCommands(t *testing.T) {
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		{"w", []string{"kill-window", "-t", "api:1"}},
+		{"s", []string{"kill-session", "-t", "api"}},
+	}
+	for _, tt := range tests {
+		m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+		windowsClient(m, calls, "2")
+
+		model := press(t, *m, "K")
+		calls.args = nil
+		model = press(t, model, tt.key)
+		if model.state != StateHome || model.killChoice != nil {
+			t.Errorf("%s: state = %v, killChoice = %v", tt.key, model.state, model.killChoice)
+		}
+		if len(calls.args) == 0 || !reflect.DeepEqual(calls.args[0], tt.want) {
+			t.Errorf("%s: tmux calls = %q, want first %q", tt.key, calls.args, tt.want)
+		}
+	}
+}
+
+// TestKillChoiceCancel tests that esc kills nothing
+func TestKillChoiceCancel(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	windowsClient(m, calls, "2")
+
+	model := press(t, *m, "K")
+	calls.args = nil
+	model = press(t, model, "esc")
+	if model.state != StateHome || len(calls.args) != 0 {
+		t.Errorf("state = %v, tmux calls = %q", model.state, calls.args)
+	}
+}
+
+// TestKillChoiceTimeout tests that an idle window-or-session question
+// auto-cancels after the confirm timeout without killing anything
+func TestKillChoiceTimeout(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	windowsClient(m, calls, "2")
+	m.confirmTimeout = time.Second
+
+	next, cmd := m.Update(keyMsg("K"))
+	model := next.(Model)
+	if model.state != StateKillChoice {
+		t.Fatalf("state = %v, want StateKillChoice", model.state)
+	}
+	if cmd == nil {
+		t.Fatal("opening the question should schedule a timeout tick")
+	}
+	next, cmd = model.Update(keyMsg("x"))
+	model = next.(Model)
+	if cmd == nil {
+		t.Error("other keys should restart the timeout")
+	}
+
+	next, _ = model.Update(confirmTimeoutMsg{seq: model.confirmSeq - 1})
+	if next.(Model).state != StateKillChoice {
+		t.Fatal("stale timeout tick should not cancel the question")
+	}
+	calls.args = nil
+	next, _ = model.Update(confirmTimeoutMsg{seq: model.confirmSeq})
+	model = next.(Model)
+	if model.state != StateHome || model.killChoice != nil {
+		t.Errorf("after timeout state = %v, killChoice = %v; want StateHome, nil", model.state, model.killChoice)
+	}
+	for _, args := range calls.args {
+		if args[0] == "kill-session" || args[0] == "kill-window" {
+			t.Errorf("timeout ran %v", args)
+		}
+	}
+}
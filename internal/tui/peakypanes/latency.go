@@ -0,0 +1,38 @@
+package peakypanes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// formatLatency renders the duration of the last refresh for the debug
+// footer: milliseconds below a second, tenths of seconds above. Zero means
+// nothing was refreshed yet and renders empty.
+func formatLatency(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return ""
+	case d < time.Second:
+		return fmt.Sprintf("refresh %dms", d.Round(time.Millisecond).Milliseconds())
+	default:
+		return fmt.Sprintf("refresh %.1fs", d.Seconds())
+	}
+}
+
+// debugRows is the number of rows the debug footer takes below the list.
+func (m Model) debugRows() int {
+	if m.debug {
+		return 1
+	}
+	return 0
+}
+
+// viewDebug renders the debug footer, or nothing outside debug mode.
+func (m Model) viewDebug() string {
+	if !m.debug {
+		return ""
+	}
+	return "\n" + theme.StatusDebug.Render(formatLatency(m.lastRefresh))
+}
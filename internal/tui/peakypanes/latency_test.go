@@ -0,0 +1,63 @@
+package peakypanes
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestFormatLatency tests the debug footer text for refresh durations
+func TestFormatLatency(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, ""},
+		{820 * time.Millisecond, "refresh 820ms"},
+		{1500 * time.Microsecond, "refresh 2ms"},
+		{999 * time.Millisecond, "refresh 999ms"},
+		{1250 * time.Millisecond, "refresh 1.2s"},
+		{12 * time.Second, "refresh 12.0s"},
+	}
+	for _, tt := range tests {
+		if got := formatLatency(tt.d); got != tt.want {
+			t.Errorf("formatLatency(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+// TestRefreshRecordsLatency tests that a refresh records its duration and
+// only debug mode shows it
+func TestRefreshRecordsLatency(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: t.TempDir()}})
+	// The clock jumps 410ms on every reading; uptimes read it too, so the
+	// refresh spans from the first reading to the last
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var readings []time.Time
+	m.now = func() time.Time {
+		readings = append(readings, start.Add(time.Duration(len(readings))*410*time.Millisecond))
+		return readings[len(readings)-1]
+	}
+	if err := m.refreshStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	want := readings[len(readings)-1].Sub(readings[0])
+	if m.lastRefresh != want || want == 0 {
+		t.Errorf("lastRefresh = %v, want %v", m.lastRefresh, want)
+	}
+	m.now = func() time.Time { return start }
+	label := formatLatency(want)
+
+	size := tea.WindowSizeMsg{Width: 100, Height: 40}
+	updated, _ := m.Update(size)
+	if view := updated.(Model).View(); strings.Contains(view, label) {
+		t.Error("refresh latency shown outside debug mode")
+	}
+	m.debug = true
+	updated, _ = m.Update(size)
+	if view := updated.(Model).View(); !strings.Contains(view, label) {
+		t.Errorf("debug view does not show the refresh latency:\n%s", view)
+	}
+}
@@ -0,0 +1,43 @@
+package peakypanes
+
+import "fmt"
+
+// unknownLayout reports whether p names a layout that is not in known. An
+// empty layout means the default and is always known.
+func unknownLayout(p Project, known map[string]bool) bool {
+	return p.Layout != "" && !known[p.Layout]
+}
+
+// ValidateProjects returns a problem for every project whose layout is not
+// one of layouts. Starting such a project fails instead of using it.
+func ValidateProjects(projects []Project, layouts []string) []error {
+	known := make(map[string]bool, len(layouts))
+	for _, name := range layouts {
+		known[name] = true
+	}
+	var problems []error
+	for _, p := range projects {
+		if unknownLayout(p, known) {
+			problems = append(problems, fmt.Errorf("project %s: layout %q not found", p.Name, p.Layout))
+		}
+	}
+	return problems
+}
+
+// checkLayouts validates the projects against the loaded layouts and marks
+// the ones with an unknown layout. Without a loader nothing is checked.
+func (m *Model) checkLayouts() {
+	m.layoutWarnings = nil
+	if m.loader == nil {
+		return
+	}
+	layouts := m.layoutNames()
+	m.layoutWarnings = ValidateProjects(m.projects, layouts)
+	known := make(map[string]bool, len(layouts))
+	for _, name := range layouts {
+		known[name] = true
+	}
+	for i := range m.projects {
+		m.projects[i].UnknownLayout = unknownLayout(m.projects[i], known)
+	}
+}
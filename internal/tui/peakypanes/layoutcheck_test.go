@@ -0,0 +1,53 @@
+package peakypanes
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+// TestValidateProjects tests that projects naming an unknown layout are reported
+func TestValidateProjects(t *testing.T) {
+	projects := []Project{
+		{Name: "api", Layout: "dev-3"},
+		{Name: "web", Layout: "dev-4"},
+		{Name: "cli"},
+	}
+	problems := ValidateProjects(projects, []string{"dev-3", "simple"})
+	if len(problems) != 1 {
+		t.Fatalf("ValidateProjects() = %v, want one problem", problems)
+	}
+	if got, want := problems[0].Error(), `project web: layout "dev-4" not found`; got != want {
+		t.Errorf("problem = %q, want %q", got, want)
+	}
+	if problems := ValidateProjects(projects[:1], []string{"dev-3"}); problems != nil {
+		t.Errorf("ValidateProjects(known) = %v, want nil", problems)
+	}
+}
+
+// TestCheckLayoutsMarksProjects tests the list marker and warning for unknown layouts
+func TestCheckLayoutsMarksProjects(t *testing.T) {
+	dir := t.TempDir()
+	loader := layout.NewLoaderWithPaths(filepath.Join(dir, "config.yml"), filepath.Join(dir, "layouts"), "")
+	if err := loader.LoadAll(); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Layout: "dev-3"},
+		{Name: "web", Session: "web", Path: "/srv/web", Layout: "typo"},
+	})
+	m.loader = loader
+	m.checkLayouts()
+
+	if m.projects[0].UnknownLayout || !m.projects[1].UnknownLayout {
+		t.Errorf("UnknownLayout = %v, %v; want false, true", m.projects[0].UnknownLayout, m.projects[1].UnknownLayout)
+	}
+	if desc := m.projects[1].Description(); !strings.Contains(desc, "layout typo?") {
+		t.Errorf("Description() = %q, want the unknown layout marked", desc)
+	}
+	if w := m.configWarning(); !strings.Contains(w, `project web: layout "typo" not found`) {
+		t.Errorf("configWarning() = %q", w)
+	}
+}
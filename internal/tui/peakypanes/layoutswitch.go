@@ -0,0 +1,127 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// layoutSwitch holds the state of the layout picker for a running session.
+type layoutSwitch struct {
+	session string
+	layouts []string
+	cursor  int
+}
+
+// selected returns the highlighted layout name.
+func (s *layoutSwitch) selected() string {
+	if s == nil || len(s.layouts) == 0 {
+		return ""
+	}
+	return s.layouts[s.cursor]
+}
+
+// move shifts the cursor by delta, clamped to the list.
+func (s *layoutSwitch) move(delta int) {
+	s.cursor += delta
+	if s.cursor < 0 {
+		s.cursor = 0
+	}
+	if s.cursor >= len(s.layouts) {
+		s.cursor = len(s.layouts) - 1
+	}
+}
+
+// startLayoutSwitch opens the layout picker for the selected running project.
+func (m Model) startLayoutSwitch() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	if !item.Status.running() {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Session not running"))
+	}
+	layouts := m.layoutNames()
+	if len(layouts) == 0 {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("No layouts found"))
+	}
+	s := &layoutSwitch{session: item.Session, layouts: layouts}
+	for i, name := range layouts {
+		if name == item.Layout {
+			s.cursor = i
+		}
+	}
+	m.layoutSwitch = s
+	m.state = StateLayoutSwitch
+	return m, nil
+}
+
+// applyLayout reshapes the active window of session to the first window of
+// the named layout.
+func (m Model) applyLayout(session, name string) error {
+	cfg, _, err := m.loader.GetLayout(name)
+	if err != nil {
+		return err
+	}
+	if len(cfg.Windows) == 0 {
+		return fmt.Errorf("layout %q has no windows", name)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return m.tmux.ReshapeWindow(ctx, session+":", cfg.Windows[0])
+}
+
+func (m Model) updateLayoutSwitch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.layoutSwitch.move(-1)
+	case "down", "j":
+		m.layoutSwitch.move(1)
+	case "enter":
+		session, name := m.layoutSwitch.session, m.layoutSwitch.selected()
+		m.layoutSwitch = nil
+		m.state = StateHome
+		if err := m.applyLayout(session, name); err != nil {
+			return m, m.list.NewStatusMessage(FormatStatusError(err))
+		}
+		return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Applied layout %s to %s", name, session)))
+	case "esc", "q":
+		m.layoutSwitch = nil
+		m.state = StateHome
+	}
+	return m, nil
+}
+
+func (m Model) viewLayoutSwitch() string {
+	listView := theme.ListDimmed.Render(m.list.View())
+	s := m.layoutSwitch
+
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("🧩 Switch Layout"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogLabel.Render("Session: "))
+	b.WriteString(theme.DialogValue.Render(s.session))
+	b.WriteString("\n\n")
+	for i, name := range s.layouts {
+		if i == s.cursor {
+			b.WriteString(theme.DialogChoiceKey.Render("› " + name))
+		} else {
+			b.WriteString(theme.DialogValue.Render("  " + name))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(theme.DialogNote.Render("Rearranges the current window; panes beyond the layout are closed"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogChoiceKey.Render("enter"))
+	b.WriteString(theme.DialogChoiceSep.Render(" apply • "))
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" cancel"))
+
+	return appStyle.Render(listView + "\n\n" + dialogStyle.Render(b.String()))
+}
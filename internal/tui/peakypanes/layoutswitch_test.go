@@ -0,0 +1,73 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+)
+
+// TestLayoutSwitch tests that the picker selection dispatches the layout's tmux commands
+func TestLayoutSwitch(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning, Layout: "dev-3"}})
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls.args = append(calls.args, args)
+		if args[0] == "list-panes" {
+			return exec.CommandContext(ctx, "printf", "%%1\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+	m.loader = layout.NewLoaderWithPaths("", "", "")
+	if err := m.loader.LoadBuiltins(); err != nil {
+		t.Fatal(err)
+	}
+
+	model := press(t, *m, "L")
+	if model.state != StateLayoutSwitch {
+		t.Fatalf("state = %v, want StateLayoutSwitch", model.state)
+	}
+	if got := model.layoutSwitch.selected(); got != "dev-3" {
+		t.Errorf("picker should preselect the project's layout, got %q", got)
+	}
+
+	// Move to split-h: the builtins are sorted, so it is a few entries down
+	for model.layoutSwitch.selected() != "split-h" {
+		before := model.layoutSwitch.cursor
+		model = press(t, model, "down")
+		if model.layoutSwitch.cursor == before {
+			t.Fatal("split-h not found in layout picker")
+		}
+	}
+	model = press(t, model, "enter")
+	if model.state != StateHome {
+		t.Errorf("state = %v, want StateHome", model.state)
+	}
+
+	want := [][]string{
+		{"list-panes", "-t", "api:", "-F", "#{pane_id}"},
+		{"split-window", "-h", "-d", "-t", "api:", "-c", "#{pane_current_path}"},
+		{"select-layout", "-t", "api:", "even-vertical"},
+	}
+	if !reflect.DeepEqual(calls.args, want) {
+		t.Errorf("tmux calls = %q, want %q", calls.args, want)
+	}
+}
+
+// TestLayoutSwitchRequiresRunning tests that stopped projects cannot be reshaped
+func TestLayoutSwitchRequiresRunning(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api"}})
+	model := press(t, *m, "L")
+	if model.state != StateHome || len(calls.args) != 0 {
+		t.Errorf("state = %v, calls = %v; want StateHome and no tmux calls", model.state, calls.args)
+	}
+
+	m, calls = newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	m.loader = layout.NewLoaderWithPaths("", "", "")
+	_ = m.loader.LoadBuiltins()
+	model = press(t, *m, "L", "esc")
+	if model.state != StateHome || len(calls.args) != 0 {
+		t.Errorf("cancel: state = %v, calls = %v; want StateHome and no tmux calls", model.state, calls.args)
+	}
+}
@@ -0,0 +1,107 @@
+package peakypanes
+
+// Session state comes from a handful of tmux calls that cover every
+// session at once, but checking a project's path and running its
+// healthcheck costs a stat and a process per project. With hundreds of
+// projects those per-project checks are deferred until the row is about to
+// be shown: refreshStatuses only checks the visible page plus a lookahead,
+// and scrolling checks more.
+
+// statusLookahead is how many rows past the visible page are checked ahead
+// of scrolling.
+const statusLookahead = 5
+
+// defaultStatusPage is the page size assumed before the list knows its
+// height.
+const defaultStatusPage = 20
+
+// visibleRange returns the half-open range [start, end) of rows to check:
+// the page of perPage rows starting at first, extended by lookahead rows
+// and clamped to total.
+func visibleRange(first, perPage, total, lookahead int) (int, int) {
+	if perPage <= 0 {
+		perPage = defaultStatusPage
+	}
+	if first < 0 {
+		first = 0
+	}
+	end := first + perPage + lookahead
+	if end > total {
+		end = total
+	}
+	if first > end {
+		first = end
+	}
+	return first, end
+}
+
+// visibleSessions returns the sessions of the rows on the list's current
+// page plus the lookahead, in display order. The page is taken over all
+// rows, so group and directory headers count like they do on screen.
+func (m *Model) visibleSessions() []string {
+	items := m.list.VisibleItems()
+	if len(items) == 0 {
+		// The list is not populated yet, e.g. during NewModel
+		for _, p := range sortProjects(m.projects, m.sortMode) {
+			items = append(items, p)
+		}
+	}
+	perPage := m.list.Paginator.PerPage
+	if m.list.Height() == 0 {
+		perPage = 0
+	}
+	start, end := visibleRange(m.list.Paginator.Page*perPage, perPage, len(items), statusLookahead)
+	var sessions []string
+	for _, item := range items[start:end] {
+		if p, ok := item.(Project); ok {
+			sessions = append(sessions, p.Session)
+		}
+	}
+	return sessions
+}
+
+// refreshVisible runs the per-project checks for visible projects that
+// have not been checked since the last refreshStatuses. Projects marked
+// no_auto_refresh are left to a manual refresh. It reports whether any
+// project was checked.
+func (m *Model) refreshVisible() bool {
+	return m.refreshVisibleWhere(func(p *Project) bool { return !p.NoAutoRefresh })
+}
+
+// refreshVisibleWhere is refreshVisible limited to the projects include
+// accepts.
+func (m *Model) refreshVisibleWhere(include func(*Project) bool) bool {
+	if m.checked == nil {
+		m.checked = make(map[string]bool)
+	}
+	visible := make(map[string]bool)
+	for _, s := range m.visibleSessions() {
+		if !m.checked[s] {
+			visible[s] = true
+		}
+	}
+	var indices []int
+	for i := range m.projects {
+		p := &m.projects[i]
+		if !visible[p.Session] || p.Archived || !include(p) {
+			continue
+		}
+		m.checked[p.Session] = true
+		if p.Status == StatusStopped && p.Path != "" && !pathExists(p.Path) {
+			p.Status = StatusMissing
+		}
+		indices = append(indices, i)
+	}
+	m.refreshHealthOf(indices)
+	return len(indices) > 0
+}
+
+// refreshScrolled checks rows that scrolled into view and updates the list
+// items when any were checked.
+func (m *Model) refreshScrolled() {
+	if m.refreshVisible() {
+		index := m.list.Index()
+		m.list.SetItems(m.projectsToItems())
+		m.list.Select(index)
+	}
+}
@@ -0,0 +1,114 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestVisibleRange tests the page plus lookahead computation
+func TestVisibleRange(t *testing.T) {
+	tests := []struct {
+		name                  string
+		first, perPage, total int
+		wantStart, wantEnd    int
+	}{
+		{"first page", 0, 10, 100, 0, 15},
+		{"middle page", 30, 10, 100, 30, 45},
+		{"last page clamps", 90, 10, 100, 90, 100},
+		{"short list", 0, 10, 3, 0, 3},
+		{"unknown height", 0, 0, 100, 0, defaultStatusPage + 5},
+		{"empty", 0, 10, 0, 0, 0},
+		{"past the end", 120, 10, 100, 100, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := visibleRange(tt.first, tt.perPage, tt.total, 5)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("visibleRange(%d, %d, %d) = [%d, %d), want [%d, %d)",
+					tt.first, tt.perPage, tt.total, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+// TestLazyHealthchecks tests that off-screen projects are not checked until scrolled to
+func TestLazyHealthchecks(t *testing.T) {
+	var projects []Project
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("p%03d", i)
+		projects = append(projects, Project{Name: name, Session: name, Path: "/srv/" + name, Healthcheck: name})
+	}
+	m, _ := newTestModel(t, projects)
+	var mu sync.Mutex
+	ran := make(map[string]bool)
+	m.healthRunner = func(ctx context.Context, command string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		ran[command] = true
+		return nil
+	}
+	// Every project reports a running session
+	for i := range m.projects {
+		m.projects[i].Status = StatusRunning
+	}
+	next, cmd := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	model := healthResults(t, next.(Model), cmd)
+
+	perPage := model.list.Paginator.PerPage
+	if perPage <= 0 || perPage >= 50 {
+		t.Fatalf("PerPage = %d, want a small page", perPage)
+	}
+	if len(ran) != perPage+statusLookahead {
+		t.Errorf("checked %d projects, want %d (page + lookahead)", len(ran), perPage+statusLookahead)
+	}
+	if ran["p099"] {
+		t.Error("off-screen project p099 was checked")
+	}
+
+	// Jump to the last page
+	next, cmd = model.Update(keyMsg("G"))
+	healthResults(t, next.(Model), cmd)
+	if !ran["p099"] {
+		t.Error("p099 not checked after scrolling to it")
+	}
+	if len(ran) >= len(projects) {
+		t.Errorf("checked all %d projects, want skipped-over pages left alone", len(ran))
+	}
+}
+
+// TestVisibleSessionsWithHeaders tests that header rows count towards the
+// page, so the checked projects are the ones on screen
+func TestVisibleSessionsWithHeaders(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	var items []list.Item
+	for g := 0; g < 20; g++ {
+		items = append(items, groupHeader{Name: fmt.Sprintf("g%02d", g), Sessions: 3})
+		for i := 0; i < 3; i++ {
+			name := fmt.Sprintf("g%02d-%d", g, i)
+			items = append(items, Project{Name: name, Session: name, Path: "/srv/" + name})
+		}
+	}
+	m.list.SetItems(items)
+	m.list.SetSize(100, 30)
+	perPage := m.list.Paginator.PerPage
+	if perPage <= 0 || perPage*2+statusLookahead > len(items) {
+		t.Fatalf("PerPage = %d, want a small page", perPage)
+	}
+	m.list.Paginator.Page = 1
+
+	var want []string
+	for _, item := range items[perPage : 2*perPage+statusLookahead] {
+		if p, ok := item.(Project); ok {
+			want = append(want, p.Session)
+		}
+	}
+	if got := m.visibleSessions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("visibleSessions() = %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package peakypanes
 
 import "strings"
 
+// Logo is the ASCII art header shown above the project list.
 var Logo = strings.Split(strings.TrimSpace(logoASCII), "\n")
 
 const logoASCII = `
@@ -9,3 +10,22 @@ const logoASCII = `
 █████   ████    █████   ████     ███     █████    █████   ███ █    ████    ████ 
 █       █████   █   █   █  ██     █      █        █   █   █  ██    █████   █████
 `
+
+// minListHeight is the number of rows the project list needs to stay usable;
+// the logo is collapsed when it would leave less than that.
+const minListHeight = 10
+
+// logoHeight is the number of rows the logo header occupies, including the
+// blank line below it.
+func logoHeight() int {
+	return len(Logo) + 2
+}
+
+// logoVisible reports whether the logo header fits a terminal of the given
+// height. A height of zero means the size is not known yet.
+func logoVisible(enabled bool, height int) bool {
+	if !enabled {
+		return false
+	}
+	return height == 0 || height-logoHeight() >= minListHeight
+}
@@ -0,0 +1,48 @@
+package peakypanes
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestLogoVisible tests the logo toggle and collapse threshold
+func TestLogoVisible(t *testing.T) {
+	threshold := logoHeight() + minListHeight
+	tests := []struct {
+		name    string
+		enabled bool
+		height  int
+		want    bool
+	}{
+		{"unknown size", true, 0, true},
+		{"tall terminal", true, threshold + 5, true},
+		{"exact threshold", true, threshold, true},
+		{"short terminal", true, threshold - 1, false},
+		{"disabled", false, threshold + 5, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := logoVisible(tt.enabled, tt.height); got != tt.want {
+				t.Errorf("logoVisible(%v, %d) = %v, want %v", tt.enabled, tt.height, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestViewHomeCollapsesLogo tests that the header is dropped on short terminals
+func TestViewHomeCollapsesLogo(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	m.showLogo = true
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 60})
+	if view := updated.(Model).View(); !strings.Contains(view, Logo[0]) {
+		t.Error("logo should be shown on a tall terminal")
+	}
+
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 12})
+	if view := updated.(Model).View(); strings.Contains(view, Logo[0]) {
+		t.Error("logo should collapse on a short terminal")
+	}
+}
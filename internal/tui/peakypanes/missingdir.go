@@ -0,0 +1,76 @@
+package peakypanes
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Values of create_missing_dirs: what opening a project whose path does
+// not exist does.
+const (
+	// createDirsNever only warns that the path is missing.
+	createDirsNever = "never"
+	// createDirsAsk offers to create the directory first.
+	createDirsAsk = "ask"
+	// createDirsAlways creates the directory without asking.
+	createDirsAlways = "always"
+)
+
+// parseCreateDirs validates a create_missing_dirs value; empty and unknown
+// values fall back to never.
+func parseCreateDirs(s string) (string, error) {
+	switch s {
+	case "", createDirsNever:
+		return createDirsNever, nil
+	case createDirsAsk, createDirsAlways:
+		return s, nil
+	}
+	return createDirsNever, fmt.Errorf("unknown create_missing_dirs %q", s)
+}
+
+// openMissing handles opening p while its path does not exist, according
+// to create_missing_dirs.
+func (m *Model) openMissing(p Project) tea.Cmd {
+	switch {
+	case p.Path != "" && m.createDirs == createDirsAsk:
+		return m.openConfirm(createDirDialog(p))
+	case p.Path != "" && m.createDirs == createDirsAlways:
+		return m.createDirAndStart(p)
+	}
+	return m.list.NewStatusMessage(FormatStatusWarning(fmt.Sprintf("Path not found: %s", shortenPath(p.Path))))
+}
+
+// createDirDialog asks before creating p's missing directory.
+func createDirDialog(p Project) *confirmDialog {
+	return &confirmDialog{
+		title:  "📁 Create Project Directory?",
+		action: "Create",
+		fields: []confirmField{{"Project", p.Name}, {"Path", shortenPath(p.Path)}},
+		note:   "The directory does not exist; it is created with its parents before the session starts",
+		prompt: "create_dir",
+		data:   promptDataFor(p),
+		result: func(m Model, confirmed bool) (tea.Model, tea.Cmd) {
+			if !confirmed {
+				return m, nil
+			}
+			cmd := m.createDirAndStart(p)
+			return m, cmd
+		},
+	}
+}
+
+// createDirAndStart creates p's directory, then starts its session. The
+// session is not started when the directory cannot be created.
+func (m *Model) createDirAndStart(p Project) tea.Cmd {
+	if err := os.MkdirAll(p.Path, 0o755); err != nil {
+		return m.list.NewStatusMessage(FormatStatusError(fmt.Errorf("create %s: %w", shortenPath(p.Path), err)))
+	}
+	_ = m.refreshStatuses()
+	m.list.SetItems(m.projectsToItems())
+	return tea.Batch(
+		m.list.NewStatusMessage(FormatStatusSuccess("Created "+shortenPath(p.Path))),
+		m.startProject(p),
+	)
+}
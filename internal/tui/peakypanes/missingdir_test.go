@@ -0,0 +1,102 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseCreateDirs tests create_missing_dirs values
+func TestParseCreateDirs(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", createDirsNever, false},
+		{"never", createDirsNever, false},
+		{"ask", createDirsAsk, false},
+		{"always", createDirsAlways, false},
+		{"yes", createDirsNever, true},
+	}
+	for _, tt := range tests {
+		got, err := parseCreateDirs(tt.in)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("parseCreateDirs(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// missingDirModel returns a model with one project whose path is missing,
+// selected.
+func missingDirModel(t *testing.T, mode, path string) Model {
+	t.Helper()
+	m, _ := newTestModel(t, []Project{{Name: "slot", Session: "slot", Path: path}})
+	m.createDirs = mode
+	if err := m.refreshStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	m.list.SetItems(m.projectsToItems())
+	m.list.SetSize(100, 30)
+	m.list.StatusMessageLifetime = time.Millisecond
+	return *m
+}
+
+// TestOpenMissingDecision tests what opening a missing project does in
+// each mode
+func TestOpenMissingDecision(t *testing.T) {
+	for _, mode := range []string{createDirsNever, createDirsAsk, createDirsAlways} {
+		path := filepath.Join(t.TempDir(), "planned", "slot")
+		m := missingDirModel(t, mode, path)
+		model := press(t, m, "enter")
+
+		_, err := os.Stat(path)
+		created := err == nil
+		asked := model.state == StateConfirm
+		if wantCreated := mode == createDirsAlways; created != wantCreated {
+			t.Errorf("%s: directory created = %v, want %v", mode, created, wantCreated)
+		}
+		if wantAsked := mode == createDirsAsk; asked != wantAsked {
+			t.Errorf("%s: asked = %v, want %v", mode, asked, wantAsked)
+		}
+		if mode == createDirsNever && !strings.Contains(model.list.View(), "Path not found") {
+			t.Errorf("never: opening should only warn:\n%s", model.list.View())
+		}
+	}
+}
+
+// TestCreateDirBeforeStart tests that confirming creates the directory
+// before the session is started, and that nothing starts when it fails
+func TestCreateDirBeforeStart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "planned", "slot")
+	m := press(t, missingDirModel(t, createDirsAsk, path), "enter")
+
+	next, cmd := m.Update(keyMsg("y"))
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("directory not created when the start command is returned: %v", err)
+	}
+	if msgs := runCmd(cmd); len(msgs) != 2 {
+		t.Errorf("got %d messages, want a status message and the session start", len(msgs))
+	}
+	if got := next.(Model).projects[0].Status; got != StatusStopped {
+		t.Errorf("status after creating = %v, want stopped", got)
+	}
+
+	// A dangling symlink as parent: the path counts as missing but cannot
+	// be created
+	dir := t.TempDir()
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink(filepath.Join(dir, "nowhere"), link); err != nil {
+		t.Fatal(err)
+	}
+	m = press(t, missingDirModel(t, createDirsAsk, filepath.Join(link, "slot")), "enter")
+	next, cmd = m.Update(keyMsg("y"))
+	if msgs := runCmd(cmd); len(msgs) != 1 {
+		t.Errorf("got %d messages after a failed mkdir, want only the error", len(msgs))
+	}
+	if view := next.(Model).list.View(); !strings.Contains(view, "create") {
+		t.Errorf("failed mkdir not reported:\n%s", view)
+	}
+}
@@ -3,19 +3,26 @@ package peakypanes
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 	"gopkg.in/yaml.v3"
 
 	"github.com/kregenrek/tmuxman/internal/layout"
+	"github.com/kregenrek/tmuxman/internal/state"
 	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+	"github.com/kregenrek/tmuxman/internal/tui/ghosttyhelp"
 	"github.com/kregenrek/tmuxman/internal/tui/theme"
 )
 
@@ -25,17 +32,43 @@ type ViewState int
 const (
 	StateHome ViewState = iota
 	StateProjectPicker
-	StateConfirmKill
+	StateConfirm
+	StateQuickCreate
+	StateBroadcastInput
+	StateConfirmBroadcast
+	StateLayoutSwitch
+	StateRunOnceInput
+	StateConfirmCollision
+	StateConfirmSave
+	StateConsole
+	StateKillChoice
+	StateQuickAdd
+	StateShortcuts
+	StateBulkRename
+	StateSwitcher
+	StateCloneInput
+	StateWindowList
+	StateCaptureLayout
 )
 
 // GitProject represents a project directory with .git
 type GitProject struct {
 	Name string
 	Path string
+	// Stashes is how many stash entries the repo has.
+	Stashes int
+}
+
+func (g GitProject) Title() string { return "📁 " + g.Name }
+
+func (g GitProject) Description() string {
+	var extras []string
+	if marker := stashMarker(g.Stashes); marker != "" {
+		extras = append(extras, marker)
+	}
+	return fitDescription(shortenPath(g.Path), extras, 0)
 }
 
-func (g GitProject) Title() string       { return "📁 " + g.Name }
-func (g GitProject) Description() string { return shortenPath(g.Path) }
 func (g GitProject) FilterValue() string { return g.Name }
 
 // Status describes the tmux lifecycle state of a project.
@@ -45,8 +78,15 @@ const (
 	StatusStopped Status = iota
 	StatusRunning
 	StatusCurrent
+	// StatusMissing marks a stopped project whose path no longer exists.
+	StatusMissing
 )
 
+// running reports whether the project has a live tmux session.
+func (s Status) running() bool {
+	return s == StatusRunning || s == StatusCurrent
+}
+
 // Project represents a configured project.
 type Project struct {
 	Name    string
@@ -54,29 +94,167 @@ type Project struct {
 	Path    string
 	Layout  string
 	Status  Status
+	// Aliases are extra names accepted by `peakypanes open <name>`.
+	Aliases []string
+	// Tags group projects; the first one colors the name in the list.
+	Tags []string
+	// Group is the session group the session joins when it starts, sharing
+	// windows with the group's other sessions. It names a running session
+	// or an existing group; tmux names a new group after that session.
+	Group string
+	// Scratch marks the pinned, long-lived scratch project.
+	Scratch bool
+	// Archived hides the project from the list and from status refreshes
+	// while keeping it in the config.
+	Archived bool
+	// SnapshotLayout saves window layouts on kill so they are restored on
+	// the next start.
+	SnapshotLayout bool
+	// Healthcheck is a shell command run on refresh while the session is
+	// running; exit status 0 means healthy.
+	Healthcheck string
+	Health      Health
+	// NoAutoRefresh skips the project's path check and healthcheck on
+	// automatic refreshes; only the refresh key runs them.
+	NoAutoRefresh bool
+	// Color tints the status icon, e.g. "#ff8800" or an ANSI number;
+	// invalid colors are ignored.
+	Color string
+	// LogFile is followed in an extra pane when the session is created.
+	// Relative paths are taken from Path.
+	LogFile string
+	// Options are tmux session options set when the session is created.
+	Options map[string]string
+	// Order places the project in the default sort; projects without one
+	// follow those with one, by name.
+	Order *int
+	// UnknownLayout is set when Layout names no loaded layout.
+	UnknownLayout bool
+	// Command is the command running in the session's active pane.
+	Command string
+	// Window is the session's active window, where an attach lands.
+	Window tmuxctl.ActiveWindow
+	// Uptime is how long the session has been running.
+	Uptime time.Duration
+	// Clients is how many tmux clients are attached to the session.
+	Clients int
+	// Usage is the CPU and memory of the session's processes; nil unless
+	// usage is shown and the session is running.
+	Usage *usage
+	// Adoptable names a live session created under another name that works
+	// in this stopped project's path.
+	Adoptable string
+
+	// tmuxGroup is the session group tmux reports for the running session.
+	tmuxGroup string
+	// pathGroup is the directory the project is listed under when
+	// group_by_path is on or in the tree view.
+	pathGroup string
+	// depth indents the project in the tree view.
+	depth int
+	// tempRoot is the temp dir holding a transient project's clone.
+	tempRoot string
+	// tempSeen records that the transient project's session has been seen
+	// running, so its end can be told apart from a start still under way.
+	tempSeen bool
+
+	// descWidth is the column budget for Description; zero means unlimited.
+	descWidth int
+	// nameColor colors the name in Title; empty leaves it unstyled.
+	nameColor lipgloss.Color
+	// titleTmpl renders Title; nil uses the default "● Name" format.
+	titleTmpl *template.Template
+	// glyphs is the icon set for the status in Title.
+	glyphs GlyphSet
+	// sessionPrefix is left out of the session name shown in Title.
+	sessionPrefix string
+	// configSession is the configured session name while Session holds a
+	// live session matched by path.
+	configSession string
 }
 
 // Implement list.Item interface for Project
 func (p Project) Title() string {
-	icon := statusIcon(p.Status)
-	return fmt.Sprintf("%s %s", icon, p.Name)
+	title := renderTitle(p, p.titleTmpl)
+	if dot := healthDot(p.Health); dot != "" && p.Status.running() {
+		title += " " + dot
+	}
+	return indent(p.depth) + title
 }
 
 func (p Project) Description() string {
-	if p.Path != "" {
-		return shortenPath(p.Path)
+	if p.depth > 0 {
+		q := p
+		q.depth = 0
+		if q.descWidth > 0 {
+			q.descWidth = max(q.descWidth-len(indent(p.depth)), 1)
+		}
+		return indent(p.depth) + q.Description()
+	}
+	if p.Path == "" {
+		return "No path configured"
+	}
+	var extras []string
+	if label := windowLabel(p.Window); label != "" {
+		extras = append(extras, label)
+	}
+	if label := commandLabel(p.Command); label != "" {
+		extras = append(extras, label)
+	}
+	if up := formatUptime(p.Uptime); up != "" && p.Status.running() {
+		extras = append(extras, up)
+	}
+	if clients := formatClients(p.Clients); clients != "" && p.Status.running() {
+		extras = append(extras, clients)
+	}
+	if p.Usage != nil {
+		extras = append(extras, formatUsage(*p.Usage))
+	}
+	if p.Status == StatusMissing {
+		extras = append(extras, "missing")
+	}
+	if p.UnknownLayout {
+		extras = append(extras, "⚠ layout "+p.Layout+"?")
+	}
+	if p.Adoptable != "" {
+		extras = append(extras, "adopt "+p.Adoptable+"?")
+	}
+	if p.Scratch {
+		extras = append(extras, "scratch")
 	}
-	return "No path configured"
+	if p.Archived {
+		extras = append(extras, "archived")
+	}
+	if p.transient() {
+		extras = append(extras, "temp clone")
+	}
+	return fitDescription(shortenPath(p.Path), extras, p.descWidth)
 }
 
-func (p Project) FilterValue() string { return p.Name }
+func (p Project) FilterValue() string {
+	if len(p.Aliases) == 0 {
+		return p.Name
+	}
+	return p.Name + " " + strings.Join(p.Aliases, " ")
+}
 
 // Config structures for YAML.
 type projectConfig struct {
-	Name    string `yaml:"name"`
-	Session string `yaml:"session"`
-	Path    string `yaml:"path"`
-	Layout  string `yaml:"layout"`
+	Name           string            `yaml:"name"`
+	Session        string            `yaml:"session"`
+	Path           string            `yaml:"path"`
+	Layout         string            `yaml:"layout"`
+	SnapshotLayout bool              `yaml:"snapshot_layout"`
+	Healthcheck    string            `yaml:"healthcheck"`
+	Aliases        []string          `yaml:"aliases"`
+	Tags           []string          `yaml:"tags"`
+	Group          string            `yaml:"group"`
+	LogFile        string            `yaml:"log_file"`
+	Archived       bool              `yaml:"archived"`
+	Options        map[string]string `yaml:"options"`
+	Order          *int              `yaml:"order"`
+	NoAutoRefresh  bool              `yaml:"no_auto_refresh"`
+	Color          string            `yaml:"color"`
 }
 
 type toolConfig struct {
@@ -96,9 +274,73 @@ type config struct {
 	Ghostty struct {
 		Config string `yaml:"config"`
 	} `yaml:"ghostty"`
-	Projects   []projectConfig `yaml:"projects"`
-	Tools      toolsConfig     `yaml:"tools"`
-	LayoutDirs []string        `yaml:"layout_dirs"`
+	Theme struct {
+		// Preset names the color palette: default, dracula, gruvbox or nord.
+		Preset string `yaml:"preset"`
+		// TagColors overrides the hashed color of specific tags.
+		TagColors map[string]string `yaml:"tag_colors"`
+	} `yaml:"theme"`
+	Projects       []projectConfig `yaml:"projects"`
+	Tools          toolsConfig     `yaml:"tools"`
+	LayoutDirs     []string        `yaml:"layout_dirs"`
+	Scratch        scratchConfig   `yaml:"scratch"`
+	ReopenLast     bool            `yaml:"reopen_last"`
+	OpenMode       string          `yaml:"open_mode"`
+	ConfirmTimeout int             `yaml:"confirm_timeout"` // seconds, 0 disables
+	IdleTimeout    int             `yaml:"idle_timeout"`    // seconds, 0 disables
+	ShowLogo       *bool           `yaml:"show_logo"`
+	// ProjectsRoot is scanned for git repos by the picker and quick-add.
+	ProjectsRoot string `yaml:"projects_root"`
+	// ProjectsDir holds additional project files, one project per file.
+	ProjectsDir         string `yaml:"projects_dir"`
+	ProjectsDirOverride bool   `yaml:"projects_dir_override"`
+	// EmptyMessage replaces the hint shown when no projects are configured.
+	EmptyMessage string `yaml:"empty_message"`
+	// ConfirmSave shows a diff and asks before the TUI rewrites the config.
+	ConfirmSave bool `yaml:"confirm_save"`
+	// TitleFormat is a text/template for list rows, e.g. "{{.Icon}} {{.Name}}".
+	TitleFormat string `yaml:"title_format"`
+	// WrapNavigation makes up on the first row select the last one and
+	// down on the last row the first one.
+	WrapNavigation bool `yaml:"wrap_navigation"`
+	// WatchConfig reloads projects when the config or projects_dir changes.
+	WatchConfig bool `yaml:"watch_config"`
+	// Glyphs is the status icon set: symbol, ascii or emoji.
+	Glyphs string `yaml:"glyphs"`
+	// PostAttachLocal runs on this machine after an attach returns.
+	PostAttachLocal string `yaml:"post_attach_local"`
+	// GroupByPath puts projects sharing a parent directory under a
+	// foldable header.
+	GroupByPath bool `yaml:"group_by_path"`
+	// CreateMissingDirs is what opening a project whose path does not
+	// exist does: never (warn), ask or always create it first.
+	CreateMissingDirs string `yaml:"create_missing_dirs"`
+	// ClearScreen also types clear into every pane when a session's
+	// scrollback is cleared.
+	ClearScreen bool `yaml:"clear_screen"`
+	// ExecAttach replaces peakypanes with the tmux client when a project
+	// is opened, instead of returning to the list afterwards.
+	ExecAttach bool `yaml:"exec_attach"`
+	// SessionPrefix is put in front of the session names derived from
+	// project names, e.g. "pp-", and left out when they are shown.
+	SessionPrefix string `yaml:"session_prefix"`
+	// MatchByPath also treats a project as running when a session of
+	// another name was started in its path.
+	MatchByPath bool `yaml:"match_by_path"`
+	// ReadmeLines is how many lines of a README the README panel shows.
+	ReadmeLines int `yaml:"readme_lines"`
+	// PeekSeconds is how long a peek stays attached without input.
+	PeekSeconds int `yaml:"peek_seconds"`
+	// OpenCommand opens sessions instead of attaching, e.g. in a new
+	// terminal window. {session}, {path} and {name} are filled in.
+	OpenCommand string `yaml:"open_command"`
+	// HintLabels renames footer key hints by action, e.g. refresh: "neu laden".
+	HintLabels map[string]string `yaml:"hint_labels"`
+	// ConfirmPrompts rewords confirmations by name, e.g. kill, with
+	// templates such as "{{.Session}} beenden?".
+	ConfirmPrompts map[string]promptConfig `yaml:"confirm_prompts"`
+	// Footer hides status bar, key hints or pagination below the lists.
+	Footer footerConfig `yaml:"footer"`
 }
 
 // Styles - using centralized theme for consistency
@@ -138,18 +380,183 @@ func (d delegateKeyMap) FullHelp() [][]key.Binding {
 }
 
 type listKeyMap struct {
-	openProject key.Binding
-	refresh     key.Binding
-	editConfig  key.Binding
-	toggleHelp  key.Binding
+	picker       key.Binding
+	openProject  key.Binding
+	switcher     key.Binding
+	quickCreate  key.Binding
+	quickAdd     key.Binding
+	moveUp       key.Binding
+	moveDown     key.Binding
+	sort         key.Binding
+	fold         key.Binding
+	foldAll      key.Binding
+	treeView     key.Binding
+	usage        key.Binding
+	windows      key.Binding
+	clone        key.Binding
+	broadcast    key.Binding
+	layout       key.Binding
+	runOnce      key.Binding
+	detach       key.Binding
+	peek         key.Binding
+	startEdit    key.Binding
+	undo         key.Binding
+	adopt        key.Binding
+	shortcuts    key.Binding
+	console      key.Binding
+	preview      key.Binding
+	readme       key.Binding
+	prune        key.Binding
+	bulkRename   key.Binding
+	clearHistory key.Binding
+	copyScript   key.Binding
+	capture      key.Binding
+	archive      key.Binding
+	showArchived key.Binding
+	hideStopped  key.Binding
+	refresh      key.Binding
+	editConfig   key.Binding
+	toggleHelp   key.Binding
 }
 
 func newListKeyMap() *listKeyMap {
 	return &listKeyMap{
+		picker: key.NewBinding(
+			key.WithKeys("ctrl+g"),
+			key.WithHelp("ctrl+g", "project picker"),
+		),
 		openProject: key.NewBinding(
 			key.WithKeys("o", "n"),
 			key.WithHelp("o/n", "open project"),
 		),
+		switcher: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "switch session"),
+		),
+		quickCreate: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "new project"),
+		),
+		quickAdd: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "add repos"),
+		),
+		moveUp: key.NewBinding(
+			key.WithKeys("ctrl+up", "alt+k"),
+			key.WithHelp("ctrl+↑", "move up"),
+		),
+		moveDown: key.NewBinding(
+			key.WithKeys("ctrl+down", "alt+j"),
+			key.WithHelp("ctrl+↓", "move down"),
+		),
+		sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort"),
+		),
+		fold: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "fold group"),
+		),
+		foldAll: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "fold all groups"),
+		),
+		treeView: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "tree view"),
+		),
+		usage: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "cpu/mem"),
+		),
+		windows: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "windows"),
+		),
+		capture: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "capture layout"),
+		),
+		clone: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "temp clone"),
+		),
+		broadcast: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "send to all"),
+		),
+		layout: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "switch layout"),
+		),
+		runOnce: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "open & run"),
+		),
+		detach: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "start in background"),
+		),
+		peek: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "peek"),
+		),
+		startEdit: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "start & open editor"),
+		),
+		undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo kill"),
+		),
+		adopt: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "adopt session"),
+		),
+		shortcuts: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "terminal shortcuts"),
+		),
+		console: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "tmux console"),
+		),
+		preview: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "toggle preview"),
+		),
+		readme: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "toggle README"),
+		),
+		prune: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "prune state"),
+		),
+		copyScript: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy start commands"),
+		),
+		clearHistory: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "clear scrollback"),
+		),
+		archive: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "archive/restore"),
+		),
+		showArchived: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "show archived"),
+		),
+		hideStopped: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "running only"),
+		),
+		bulkRename: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "rename listed sessions"),
+		),
 		refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
@@ -179,28 +586,241 @@ type Model struct {
 	keys         *listKeyMap
 	delegateKeys *delegateKeyMap
 	projects     []Project
+	sortMode     SortMode
 
 	// Project picker view
-	projectPicker list.Model
-	gitProjects   []GitProject
+	projectPicker  list.Model
+	pickerKeys     *pickerKeyMap
+	showRegistered bool
+	gitProjects    []GitProject
+	gitSortMode    GitSortMode
+
+	// Open yes/no confirmation, e.g. before killing a session
+	confirm *confirmDialog
 
-	// Confirm kill dialog
-	confirmProject *Project
+	// Window-or-session question before killing a multi-window session
+	killChoice *killChoice
+
+	// Quick-create flow
+	createFlow *createFlow
+
+	// Multi-select of repos to add from the projects root
+	quickAdd *quickAdd
+
+	// Terminal shortcut help shown over the list
+	shortcuts *ghosttyhelp.Model
+
+	// Bulk rename: the listed projects and the session names in use
+	renameProjects []Project
+	renameTaken    []string
+
+	// Running sessions offered by the one-key switcher
+	switcher []switchEntry
+
+	// Windows of the session drilled into
+	windowList *windowList
+
+	// clipboard receives the OSC 52 copy sequence; nil means stdout.
+	clipboard io.Writer
+
+	// Command prompts
+	cmdInput     textinput.Model
+	broadcastCmd string
+	// cmdHistory recalls earlier commands in the run-once and console
+	// inputs.
+	cmdHistory *cmdHistory
+
+	// Layout switching
+	layoutSwitch *layoutSwitch
+
+	// One-off command for the selected project
+	runOnceProject *Project
+
+	// Running project whose layout is being captured
+	captureProject *Project
+
+	// Session name collision dialog
+	collision     *Project
+	collisionLive []string
+	// collisionRecreate marks a collision found while recreating a killed
+	// session, which may be replaced.
+	collisionRecreate bool
+
+	// tmux command console
+	console *console
+
+	// Config changes waiting for confirmation
+	pendingSave *pendingSave
 
 	// Config
 	configPath string
-	tools      toolsConfig
+	statePath  string
+	// configWarnings are non-fatal problems found while loading the config,
+	// such as unparsable files in projects_dir.
+	configWarnings []error
+	tools          toolsConfig
+	reopenLast     bool
+	showLogo       bool
+	openMode       string
+	emptyText      string
+	confirmSave    bool
+	tagColors      map[string]string
+	projectsRoot   string
+	titleTmpl      *template.Template
+	// titleWarning reports an unusable title_format; the default is used.
+	titleWarning error
+	glyphs       GlyphSet
+	// glyphsWarning reports an unknown glyph set; symbols are used.
+	glyphsWarning error
+	// themeName is the configured theme preset; themeWarning reports an
+	// unknown one, for which the default palette is used.
+	themeName    string
+	themeWarning error
+	// hintWarning reports hint_labels entries for unknown actions.
+	hintWarning error
+	// prompts are the confirm_prompts templates by confirmation;
+	// promptWarning reports unusable entries, which keep the default.
+	prompts       map[string]promptTemplates
+	promptWarning error
+	// createDirs is the create_missing_dirs mode; createDirsWarning
+	// reports an unknown value.
+	createDirs        string
+	createDirsWarning error
+	// sessionPrefix (session_prefix) namespaces derived session names;
+	// prefixWarning reports an unusable one.
+	sessionPrefix string
+	prefixWarning error
+	// matchByPath matches projects to live sessions by start directory.
+	matchByPath bool
+	// layoutWarnings names projects whose layout is not loaded.
+	layoutWarnings []error
+	projectsDir    string
+	watchEnabled   bool
+	wrapNavigation bool
+	// watcher follows config changes when watch_config is on; watchErr
+	// reports why it could not start.
+	watcher   *configWatcher
+	watchErr  error
+	reloadSeq int
+	// postAttachLocal is a shell command run locally after an attach.
+	postAttachLocal string
+	// footer lists the footer elements hidden below the lists.
+	footer footer
+	// groupByPath lists projects under headers by parent directory;
+	// collapsed holds the folded ones.
+	groupByPath bool
+	collapsed   map[string]bool
+	// treeView lists projects under their directory tree instead.
+	treeView bool
+	// showArchived lists archived projects too.
+	showArchived bool
+	// hideStopped lists only projects with a running session.
+	hideStopped bool
+	// showUsage adds session CPU and memory to the descriptions.
+	showUsage bool
+	// processLister reads the process table for usage; nil means ps.
+	processLister processLister
+	// clearScreen types clear into each pane along with clear-history.
+	clearScreen bool
+	// execAttach (exec_attach) and execFlag (--exec) quit into the opened
+	// project; execArgv is the command chosen to replace the process.
+	execAttach bool
+	execFlag   bool
+	execArgv   []string
+
+	// Confirmation auto-cancel
+	confirmTimeout time.Duration
+	confirmSeq     int
+
+	// Auto-quit after idleTimeout without input
+	idleTimeout time.Duration
+	idleSeq     int
+	// peekDuration ends a peek attach without input; peekRunner starts the
+	// detach in the background, nil runs it with sh.
+	peekDuration time.Duration
+	peekRunner   func(argv []string) error
+	// openCommand (open_command) replaces attaching; openRunner starts it,
+	// nil runs it in the background.
+	openCommand string
+	openRunner  func(argv []string) error
+
+	// Undo of the last kill
+	undo    *killUndo
+	undoSeq int
+	// startRunner runs peakypanes for background starts; nil runs the
+	// binary.
+	startRunner func(args ...string) ([]byte, error)
+	// editorRunner opens the editor in a project dir; nil runs $EDITOR.
+	editorRunner func(dir string) tea.Cmd
+	// cloneRunner makes temporary clones; nil runs git clone.
+	cloneRunner cloneRunner
 
 	// Status
 	insideTmux bool
+	// noTmux simulates starts and attaches on a mock client (--no-tmux).
+	noTmux       bool
+	healthRunner commandRunner
+	// healthJobs are the healthchecks queued by the last refresh; Update
+	// runs them in the background.
+	healthJobs []healthJob
+	// initHealth runs the healthchecks queued by NewModel.
+	initHealth tea.Cmd
+	// localRunner runs post_attach_local; nil means sh -c.
+	localRunner commandRunner
+	// now is the clock used for session uptimes; nil means time.Now.
+	now func() time.Time
+	// debug shows lastRefresh, the duration of the last status refresh.
+	debug       bool
+	lastRefresh time.Duration
+	// checked holds the sessions whose path and healthcheck were checked
+	// since the last refreshStatuses.
+	checked map[string]bool
+
+	// Live preview of the selected session's active pane
+	preview        bool
+	previewSeq     int
+	previewSession string
+	previewContent string
+
+	// README panel of the selected project or repo. readmeWant is the
+	// directory being shown, readmeLoaded the one readmeContent is from.
+	readme        bool
+	readmeLines   int
+	readmeSeq     int
+	readmeWant    string
+	readmeLoaded  string
+	readmeContent string
 
 	// Snapshot for selected project
 	snapshot        tmuxctl.SessionSnapshot
 	snapshotSession string
 }
 
+// Options configures a Model beyond what the config file provides.
+type Options struct {
+	// ReopenLast attaches to the most recent running session on startup
+	// instead of showing the list. It is also enabled by reopen_last in the
+	// config file.
+	ReopenLast bool
+	// Glyphs overrides the glyph set from the config file when set.
+	Glyphs GlyphSet
+	// Theme overrides the theme preset from the config file when set.
+	Theme string
+	// Debug shows how long the last status refresh took below the list.
+	Debug bool
+	// Exec quits into the opened project, replacing the peakypanes process.
+	// It is also enabled by exec_attach in the config file.
+	Exec bool
+	// Running starts with stopped projects hidden, so the list only offers
+	// running sessions.
+	Running bool
+	// NoTmux marks client as a tmuxctl mock: starts and attaches are
+	// simulated on it instead of running peakypanes or tmux.
+	NoTmux bool
+}
+
 // NewModel creates a new peakypanes TUI model.
-func NewModel(client *tmuxctl.Client) (*Model, error) {
+func NewModel(client *tmuxctl.Client, opts Options) (*Model, error) {
 	if client == nil {
 		return nil, fmt.Errorf("tmux client is required")
 	}
@@ -210,6 +830,11 @@ func NewModel(client *tmuxctl.Client) (*Model, error) {
 		return nil, err
 	}
 
+	statePath, err := state.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create loader for layouts
 	loader, err := layout.NewLoader()
 	if err != nil {
@@ -224,10 +849,14 @@ func NewModel(client *tmuxctl.Client) (*Model, error) {
 		tmux:         client,
 		loader:       loader,
 		configPath:   configPath,
+		statePath:    statePath,
 		state:        StateHome,
-		insideTmux:   os.Getenv("TMUX") != "",
+		showLogo:     true,
+		insideTmux:   os.Getenv("TMUX") != "" && !opts.NoTmux,
+		noTmux:       opts.NoTmux,
 		keys:         newListKeyMap(),
 		delegateKeys: newDelegateKeyMap(),
+		pickerKeys:   newPickerKeyMap(),
 	}
 
 	// Load config and projects
@@ -235,8 +864,27 @@ func NewModel(client *tmuxctl.Client) (*Model, error) {
 		// Non-fatal, continue with empty projects
 	}
 
+	m.loadCollapsed()
+	m.loadPreferences()
+
+	// Colors are fixed for the run: the lists copy styles when set up
+	if opts.Theme != "" {
+		m.themeName = opts.Theme
+	}
+	var palette theme.Theme
+	palette, m.themeWarning = themePreset(m.themeName)
+	applyTheme(palette)
+
+	if m.noTmux {
+		m.startRunner = mockStartRunner(client)
+	}
+
 	// Refresh tmux session statuses
 	_ = m.refreshStatuses()
+	m.initHealth = m.takeHealthCmd()
+	if opts.Running {
+		m.hideStopped = true
+	}
 
 	// Setup list
 	m.setupList()
@@ -244,12 +892,24 @@ func NewModel(client *tmuxctl.Client) (*Model, error) {
 	// Setup project picker
 	m.setupProjectPicker()
 
+	if opts.ReopenLast {
+		m.reopenLast = true
+	}
+	if m.watchEnabled {
+		m.watcher, m.watchErr = newConfigWatcher(m.configPath, m.projectsDir)
+	}
+	m.debug = opts.Debug
+	m.execFlag = opts.Exec
+	if opts.Glyphs != "" {
+		m.glyphs, m.glyphsWarning = opts.Glyphs, nil
+		m.list.SetItems(m.projectsToItems())
+	}
+
 	return m, nil
 }
 
 func (m *Model) setupList() {
 	delegate := list.NewDefaultDelegate()
-	delegate.UpdateFunc = m.delegateUpdate
 	delegate.ShortHelpFunc = func() []key.Binding {
 		return []key.Binding{m.delegateKeys.choose, m.delegateKeys.kill}
 	}
@@ -269,11 +929,45 @@ func (m *Model) setupList() {
 	l := list.New(items, delegate, 0, 0)
 	l.Title = "🎩 Peaky Panes"
 	l.Styles.Title = titleStyle
-	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(true)
+	l.InfiniteScrolling = m.wrapNavigation
+	m.footer.apply(&l)
 	l.AdditionalFullHelpKeys = func() []key.Binding {
 		return []key.Binding{
 			m.keys.openProject,
+			m.keys.switcher,
+			m.keys.windows,
+			m.keys.picker,
+			m.keys.quickCreate,
+			m.keys.quickAdd,
+			m.keys.clone,
+			m.keys.moveUp,
+			m.keys.moveDown,
+			m.keys.sort,
+			m.keys.fold,
+			m.keys.foldAll,
+			m.keys.treeView,
+			m.keys.usage,
+			m.keys.broadcast,
+			m.keys.layout,
+			m.keys.runOnce,
+			m.keys.detach,
+			m.keys.startEdit,
+			m.keys.peek,
+			m.keys.undo,
+			m.keys.adopt,
+			m.keys.shortcuts,
+			m.keys.console,
+			m.keys.preview,
+			m.keys.readme,
+			m.keys.prune,
+			m.keys.bulkRename,
+			m.keys.clearHistory,
+			m.keys.copyScript,
+			m.keys.capture,
+			m.keys.archive,
+			m.keys.showArchived,
+			m.keys.hideStopped,
 			m.keys.refresh,
 			m.keys.editConfig,
 		}
@@ -287,7 +981,10 @@ func (m *Model) setupList() {
 
 	// Set status bar info
 	modeLabel := "outside tmux"
-	if m.insideTmux {
+	switch {
+	case m.noTmux:
+		modeLabel = "no tmux"
+	case m.insideTmux:
 		modeLabel = "inside tmux"
 	}
 	l.SetStatusBarItemName("session", "sessions")
@@ -313,34 +1010,54 @@ func (m *Model) setupProjectPicker() {
 	l := list.New(items, delegate, 0, 0)
 	l.Title = "📁 Open Project"
 	l.Styles.Title = theme.TitleAlt
-	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(true)
 	l.SetStatusBarItemName("project", "projects")
+	m.footer.apply(&l)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{m.pickerKeys.toggleRegistered, m.pickerKeys.sort, m.keys.readme}
+	}
 
 	m.projectPicker = l
 }
 
 func (m *Model) scanGitProjects() {
-	m.gitProjects = nil
+	m.gitProjects = ScanGitProjects(m.projectsRootDir())
+}
 
+// projectsRootDir is the directory scanned for git repos: projects_root
+// from the config, or ~/projects.
+func (m *Model) projectsRootDir() string {
+	if m.projectsRoot != "" {
+		return expandPath(m.projectsRoot)
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return
+		return ""
 	}
+	return filepath.Join(home, "projects")
+}
 
-	projectsDir := filepath.Join(home, "projects")
-	if _, err := os.Stat(projectsDir); os.IsNotExist(err) {
-		return
+// ScanGitProjects walks root for git repositories, naming each by its path
+// relative to root. Hidden and dependency directories are skipped, and
+// nested repos below a repo are not reported. A missing root yields no
+// projects.
+func ScanGitProjects(root string) []GitProject {
+	if root == "" {
+		return nil
+	}
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil
 	}
 
+	var projects []GitProject
 	// Walk through all directories recursively
-	_ = filepath.WalkDir(projectsDir, func(path string, d os.DirEntry, err error) error {
+	_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			return nil // Skip errors, continue walking
 		}
 
 		// Skip hidden directories entirely
-		if d.IsDir() && strings.HasPrefix(d.Name(), ".") {
+		if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != root {
 			return filepath.SkipDir
 		}
 
@@ -356,11 +1073,12 @@ func (m *Model) scanGitProjects() {
 		if d.IsDir() && d.Name() != ".git" {
 			gitPath := filepath.Join(path, ".git")
 			if _, err := os.Stat(gitPath); err == nil {
-				// Get relative path from projects dir for a nicer name
-				relPath, _ := filepath.Rel(projectsDir, path)
-				m.gitProjects = append(m.gitProjects, GitProject{
-					Name: relPath,
-					Path: path,
+				// Get relative path from the root for a nicer name
+				relPath, _ := filepath.Rel(root, path)
+				projects = append(projects, GitProject{
+					Name:    relPath,
+					Path:    path,
+					Stashes: stashCount(path),
 				})
 				// Don't descend into this directory's subdirectories
 				// (nested git repos are handled by git submodules, not separate projects)
@@ -370,30 +1088,86 @@ func (m *Model) scanGitProjects() {
 
 		return nil
 	})
+	return projects
 }
 
 func (m *Model) gitProjectsToItems() []list.Item {
-	items := make([]list.Item, len(m.gitProjects))
-	for i, p := range m.gitProjects {
+	visible := sortGitProjects(visibleGitProjects(m.gitProjects, m.projects, m.showRegistered), m.gitSortMode)
+	items := make([]list.Item, len(visible))
+	for i, p := range visible {
 		items[i] = p
 	}
 	return items
 }
 
 func (m *Model) projectsToItems() []list.Item {
-	items := make([]list.Item, len(m.projects))
-	for i, p := range m.projects {
-		items[i] = p
+	if m.treeView {
+		return m.treeItems()
+	}
+	projects := groupProjects(sortProjects(m.listed(), m.sortMode))
+	if m.groupByPath {
+		projects = groupByPath(projects)
+	}
+	items := make([]list.Item, 0, len(projects))
+	for i, p := range projects {
+		if g := p.pathGroup; g != "" {
+			if i == 0 || projects[i-1].pathGroup != g {
+				items = append(items, pathHeader{Prefix: g, Projects: pathGroupSize(projects[i:], g), Collapsed: m.collapsed[g]})
+			}
+			if m.collapsed[g] {
+				continue
+			}
+		}
+		if g := p.group(); g != "" && (i == 0 || projects[i-1].group() != g) {
+			items = append(items, groupHeader{Name: g, Sessions: groupSize(projects[i:], g)})
+		}
+		items = append(items, m.listProject(p))
 	}
 	return items
 }
 
+// treeItems lists the projects under their directory tree, keeping the
+// sort order within each directory.
+func (m *Model) treeItems() []list.Item {
+	projects := sortProjects(m.listed(), m.sortMode)
+	rows := treeRows(projects, m.collapsed)
+	items := make([]list.Item, 0, len(rows))
+	for _, row := range rows {
+		if row.header != nil {
+			items = append(items, *row.header)
+			continue
+		}
+		p := projects[row.project]
+		p.pathGroup, p.depth = row.dir, row.depth
+		items = append(items, m.listProject(p))
+	}
+	return items
+}
+
+// listProject sets the display settings of p for the project list.
+func (m *Model) listProject(p Project) Project {
+	p.descWidth = m.list.Width() - listItemPadding
+	if len(p.Tags) > 0 {
+		p.nameColor = theme.TagColor(p.Tags[0], m.tagColors)
+	}
+	p.titleTmpl = m.titleTmpl
+	p.glyphs = m.glyphs
+	p.sessionPrefix = m.sessionPrefix
+	return p
+}
+
+// delegateUpdate handles the per-item actions. It is called from updateHome
+// rather than installed as the delegate's UpdateFunc: a closure bound at setup
+// would mutate the original Model instead of the copy Bubble Tea is holding.
 func (m *Model) delegateUpdate(msg tea.Msg, lm *list.Model) tea.Cmd {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.delegateKeys.choose):
 			if item, ok := lm.SelectedItem().(Project); ok {
+				if item.Status == StatusMissing {
+					return m.openMissing(item)
+				}
 				if item.Status == StatusStopped {
 					// Start the session
 					return m.startProject(item)
@@ -404,9 +1178,8 @@ func (m *Model) delegateUpdate(msg tea.Msg, lm *list.Model) tea.Cmd {
 
 		case key.Matches(msg, m.delegateKeys.kill):
 			if item, ok := lm.SelectedItem().(Project); ok {
-				if item.Status != StatusStopped {
-					m.confirmProject = &item
-					m.state = StateConfirmKill
+				if item.Status.running() {
+					return m.startKill(item)
 				} else {
 					return lm.NewStatusMessage(FormatStatusWarning("Session not running"))
 				}
@@ -431,29 +1204,91 @@ func (m *Model) loadConfig() error {
 	}
 
 	m.tools = cfg.Tools
-	m.projects = nil
+	m.reopenLast = cfg.ReopenLast
+	m.openMode = cfg.OpenMode
+	m.emptyText = cfg.EmptyMessage
+	m.confirmTimeout = time.Duration(cfg.ConfirmTimeout) * time.Second
+	m.idleTimeout = time.Duration(cfg.IdleTimeout) * time.Second
+	m.peekDuration = time.Duration(cfg.PeekSeconds) * time.Second
+	m.openCommand = strings.TrimSpace(cfg.OpenCommand)
+	m.showLogo = cfg.ShowLogo == nil || *cfg.ShowLogo
+	m.confirmSave = cfg.ConfirmSave
+	m.tagColors = cfg.Theme.TagColors
+	m.themeName = cfg.Theme.Preset
+	m.projectsRoot = cfg.ProjectsRoot
+	m.titleTmpl, m.titleWarning = parseTitleFormat(cfg.TitleFormat)
+	m.glyphs, m.glyphsWarning = ParseGlyphSet(cfg.Glyphs)
+	m.postAttachLocal = cfg.PostAttachLocal
+	m.projectsDir = expandPath(cfg.ProjectsDir)
+	m.watchEnabled = cfg.WatchConfig
+	m.wrapNavigation = cfg.WrapNavigation
+	m.list.InfiniteScrolling = cfg.WrapNavigation
+	m.footer = parseFooter(cfg.Footer)
+	m.groupByPath = cfg.GroupByPath
+	m.clearScreen = cfg.ClearScreen
+	m.execAttach = cfg.ExecAttach
+	m.readmeLines = cfg.ReadmeLines
+	m.hintWarning = m.applyHintLabels(cfg.HintLabels)
+	m.prompts, m.promptWarning = parseConfirmPrompts(cfg.ConfirmPrompts)
+	m.createDirs, m.createDirsWarning = parseCreateDirs(cfg.CreateMissingDirs)
+	m.sessionPrefix, m.prefixWarning = parseSessionPrefix(cfg.SessionPrefix)
+	m.matchByPath = cfg.MatchByPath
+	transient := transientProjects(m.projects)
+	m.projects, m.configWarnings = configProjects(cfg)
+	m.projects = append(m.projects, transient...)
+	m.checkLayouts()
+	return nil
+}
+
+// configProjects builds the project list described by cfg, including
+// projects_dir entries and the scratch project. Problems with individual
+// project files are returned as warnings. Session names derived from
+// project names get the session prefix; explicit ones are used as written.
+func configProjects(cfg config) ([]Project, []error) {
+	prefix, _ := parseSessionPrefix(cfg.SessionPrefix)
+	var warnings []error
+	projectConfigs := cfg.Projects
+	if cfg.ProjectsDir != "" {
+		extra, errs := loadProjectDir(expandPath(cfg.ProjectsDir))
+		projectConfigs = mergeProjectConfigs(projectConfigs, extra, cfg.ProjectsDirOverride)
+		warnings = errs
+	}
 
-	for _, pc := range cfg.Projects {
+	var projects []Project
+	for _, pc := range projectConfigs {
 		p := Project{
-			Name:    pc.Name,
-			Session: pc.Session,
-			Path:    expandPath(pc.Path),
-			Layout:  pc.Layout,
-			Status:  StatusStopped,
+			Name:           pc.Name,
+			Session:        pc.Session,
+			Path:           expandPath(pc.Path),
+			Layout:         pc.Layout,
+			Status:         StatusStopped,
+			SnapshotLayout: pc.SnapshotLayout,
+			Healthcheck:    pc.Healthcheck,
+			Aliases:        pc.Aliases,
+			Tags:           pc.Tags,
+			Group:          pc.Group,
+			LogFile:        expandPath(pc.LogFile),
+			Archived:       pc.Archived,
+			Options:        pc.Options,
+			Order:          pc.Order,
+			NoAutoRefresh:  pc.NoAutoRefresh,
+			Color:          pc.Color,
 		}
 		if p.Name == "" && p.Session != "" {
 			p.Name = p.Session
 		}
 		if p.Session == "" && p.Name != "" {
-			p.Session = sanitizeSessionName(p.Name)
+			p.Session = prefixSession(prefix, sanitizeSessionName(p.Name))
 		}
-		m.projects = append(m.projects, p)
+		projects = append(projects, p)
 	}
-
-	return nil
+	return withScratch(projects, cfg.Scratch), warnings
 }
 
 func (m *Model) refreshStatuses() error {
+	start := m.clock()
+	defer func() { m.lastRefresh = m.clock().Sub(start) }()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
@@ -478,27 +1313,45 @@ func (m *Model) refreshStatuses() error {
 		// Keep only projects that have a Path (configured) or are still running
 		if p.Path != "" {
 			// This is a configured project - always keep it
+			p.unmatchSession()
 			configuredProjects = append(configuredProjects, p)
-			configuredSessions[p.Session] = true
 		}
 		// Dynamically discovered projects (no Path) will be re-added if still running
 	}
+	if m.matchByPath {
+		if paths, err := m.tmux.ListSessionPaths(ctx); err == nil {
+			matchSessionsByPath(configuredProjects, paths)
+		}
+	}
+	for _, p := range configuredProjects {
+		configuredSessions[p.Session] = true
+	}
 
 	// Start fresh with configured projects
 	m.projects = configuredProjects
 
-	// Update status for configured projects
+	// Update status for configured projects. Missing paths are flagged
+	// lazily by refreshVisible.
 	for i := range m.projects {
 		p := &m.projects[i]
+		health := p.Health
 		p.Status = StatusStopped
+		p.Health = HealthUnknown
+		if p.Archived {
+			continue
+		}
 		if runningSessions[p.Session] {
 			if p.Session == current {
 				p.Status = StatusCurrent
 			} else {
 				p.Status = StatusRunning
 			}
+			// Keep the last result until the next check reports, which
+			// for no_auto_refresh projects is the next manual refresh
+			p.Health = health
 		}
 	}
+	m.removeEndedClones()
 
 	// Add running sessions that are NOT in configured projects
 	for _, s := range sessions {
@@ -508,7 +1361,7 @@ func (m *Model) refreshStatuses() error {
 				status = StatusCurrent
 			}
 			m.projects = append(m.projects, Project{
-				Name:    s,
+				Name:    stripSessionPrefix(m.sessionPrefix, s),
 				Session: s,
 				Path:    "", // Unknown path for unconfigured sessions
 				Layout:  "",
@@ -517,34 +1370,161 @@ func (m *Model) refreshStatuses() error {
 		}
 	}
 
+	m.refreshCommands(ctx)
+	m.refreshWindows(ctx)
+	m.refreshUptimes(ctx)
+	m.refreshClients(ctx)
+	m.refreshUsage(ctx)
+	m.refreshAdoptions(ctx)
+	m.refreshGroups(ctx)
+	m.checked = nil
+	m.refreshVisible()
+
 	return nil
 }
 
 func (m Model) Init() tea.Cmd {
-	return nil
+	var cmds []tea.Cmd
+	if w := m.configWarning(); w != "" {
+		cmds = append(cmds, NewWarningCmd(w))
+	}
+	if m.watchErr != nil {
+		cmds = append(cmds, NewWarningCmd("Config watch unavailable: "+m.watchErr.Error()))
+	}
+	cmds = append(cmds, m.watchConfig(), m.idleTick(), m.initHealth)
+	return tea.Batch(cmds...)
+}
+
+// configWarning summarizes configWarnings, titleWarning and glyphsWarning
+// for the status bar.
+func (m Model) configWarning() string {
+	var parts []string
+	if len(m.configWarnings) > 0 {
+		msgs := make([]string, len(m.configWarnings))
+		for i, err := range m.configWarnings {
+			msgs[i] = err.Error()
+		}
+		parts = append(parts, "Skipped project files: "+strings.Join(msgs, "; "))
+	}
+	if m.titleWarning != nil {
+		parts = append(parts, m.titleWarning.Error()+" (using default)")
+	}
+	if m.glyphsWarning != nil {
+		parts = append(parts, m.glyphsWarning.Error()+" (using symbol)")
+	}
+	if m.themeWarning != nil {
+		parts = append(parts, m.themeWarning.Error()+" (using default)")
+	}
+	if m.hintWarning != nil {
+		parts = append(parts, m.hintWarning.Error())
+	}
+	if m.promptWarning != nil {
+		parts = append(parts, m.promptWarning.Error()+" (using default)")
+	}
+	if m.createDirsWarning != nil {
+		parts = append(parts, m.createDirsWarning.Error()+" (using never)")
+	}
+	if m.prefixWarning != nil {
+		parts = append(parts, m.prefixWarning.Error()+" (ignored)")
+	}
+	for _, w := range m.layoutWarnings {
+		parts = append(parts, w.Error())
+	}
+	if w := optionsWarning(m.projects); w != nil {
+		parts = append(parts, w.Error()+" (ignored)")
+	}
+	if w := colorWarning(m.projects); w != nil {
+		parts = append(parts, w.Error()+" (ignored)")
+	}
+	return strings.Join(parts, "; ")
 }
 
+// Update handles msg and runs the healthchecks its refreshes queued.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	if model, ok := next.(Model); ok && len(model.healthJobs) > 0 {
+		health := model.takeHealthCmd()
+		return model, tea.Batch(cmd, health)
+	}
+	return next, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		h, v := appStyle.GetFrameSize()
-		// Reserve space for logo (4 lines) at the top
-		logoHeight := len(Logo) + 2
-		m.list.SetSize(msg.Width-h, msg.Height-v-logoHeight)
-		m.projectPicker.SetSize(msg.Width-h, msg.Height-v)
+		m.resize()
 		return m, nil
 
+	case previewTickMsg:
+		return m.handlePreviewTick(msg)
+	case previewMsg:
+		return m.handlePreview(msg)
+	case readmeTickMsg:
+		return m.handleReadmeTick(msg)
+	case readmeMsg:
+		return m.handleReadme(msg)
+
+	case healthMsg:
+		return m.handleHealth(msg)
+	case confirmTimeoutMsg:
+		return m.handleConfirmTimeout(msg)
+	case idleTimeoutMsg:
+		return m.handleIdleTimeout(msg)
+
+	case configChangedMsg:
+		return m.handleConfigChanged()
+	case configReloadMsg:
+		return m.handleConfigReload(msg)
+
+	case undoExpiredMsg:
+		return m.handleUndoExpired(msg)
+
+	case execMsg:
+		return m.handleExec(msg)
+
+	case mockAttachedMsg:
+		return m.handleMockAttached(msg)
+
+	case attachDoneMsg:
+		// Time spent attached does not count as idle
+		idle := m.resetIdleTimeout()
+		return m, tea.Batch(m.postAttachCmd(), idle)
+
+	case configEditedMsg:
+		return m.handleConfigEdited(msg)
+
+	case SessionStartedMsg:
+		return m.handleSessionStarted(msg)
+
+	case clonedMsg:
+		return m.handleCloned(msg)
+
+	case startedThenMsg:
+		return m.handleStartedThen(msg)
+
+	case editorOpenedMsg:
+		return m.handleEditorOpened(msg)
+
+	case ErrorMsg:
+		return m, m.list.NewStatusMessage(FormatStatusError(msg))
+	case WarningMsg:
+		return m, m.list.NewStatusMessage(FormatStatusWarning(msg.Message))
+	case SuccessMsg:
+		return m, m.list.NewStatusMessage(FormatStatusSuccess(msg.Message))
+	case InfoMsg:
+		return m, m.list.NewStatusMessage(FormatStatusInfo(msg.Message))
+
 	case tea.KeyMsg:
-		switch m.state {
-		case StateHome:
-			return m.updateHome(msg)
-		case StateProjectPicker:
-			return m.updateProjectPicker(msg)
-		case StateConfirmKill:
-			return m.updateConfirmKill(msg)
+		idle := m.resetIdleTimeout()
+		next, cmd := m.updateKey(msg)
+		if nm, ok := next.(Model); ok {
+			// Keys may move the selection the README panel follows
+			follow := nm.followReadme()
+			return nm, tea.Batch(cmd, follow, idle)
 		}
+		return next, tea.Batch(cmd, idle)
 	}
 
 	// Pass other messages to the appropriate component
@@ -562,30 +1542,196 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateKey hands a key to the view of the current state.
+func (m Model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The picker key works from every view, except while a filter is
+	// being typed and keys belong to the filter input, or while unsaved
+	// config changes wait for an answer.
+	if key.Matches(msg, m.keys.picker) && !m.filtering() && m.state != StateConfirmSave {
+		return m.openProjectPicker()
+	}
+	switch m.state {
+	case StateHome:
+		return m.updateHome(msg)
+	case StateProjectPicker:
+		return m.updateProjectPicker(msg)
+	case StateConfirm:
+		return m.updateConfirm(msg)
+	case StateQuickCreate:
+		return m.updateQuickCreate(msg)
+	case StateBroadcastInput:
+		return m.updateBroadcastInput(msg)
+	case StateConfirmBroadcast:
+		return m.updateConfirmBroadcast(msg)
+	case StateLayoutSwitch:
+		return m.updateLayoutSwitch(msg)
+	case StateRunOnceInput:
+		return m.updateRunOnceInput(msg)
+	case StateConfirmCollision:
+		return m.updateConfirmCollision(msg)
+	case StateConfirmSave:
+		return m.updateConfirmSave(msg)
+	case StateConsole:
+		return m.updateConsole(msg)
+	case StateKillChoice:
+		return m.updateKillChoice(msg)
+	case StateQuickAdd:
+		return m.updateQuickAdd(msg)
+	case StateShortcuts:
+		return m.updateShortcuts(msg)
+	case StateBulkRename:
+		return m.updateBulkRename(msg)
+	case StateSwitcher:
+		return m.updateSwitcher(msg)
+	case StateCloneInput:
+		return m.updateCloneInput(msg)
+	case StateWindowList:
+		return m.updateWindowList(msg)
+	case StateCaptureLayout:
+		return m.updateCaptureLayout(msg)
+	}
+	return m, nil
+}
+
 func (m Model) updateHome(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Don't process keys while filtering
 	if m.list.FilterState() == list.Filtering {
+		before := m.selectedSession()
 		var cmd tea.Cmd
 		m.list, cmd = m.list.Update(msg)
-		return m, cmd
+		return m, tea.Batch(cmd, m.previewSelectionChanged(before))
+	}
+
+	// Any other key closes the undo window of the last kill
+	if key.Matches(msg, m.keys.undo) && m.undo != nil {
+		return m.undoKill()
 	}
+	m.undo = nil
 
 	switch {
+	case key.Matches(msg, m.keys.fold):
+		return m.toggleFold()
+
+	case key.Matches(msg, m.keys.foldAll):
+		return m.toggleFoldAll()
+
+	case key.Matches(msg, m.keys.treeView):
+		return m.toggleTreeView()
+
+	case key.Matches(msg, m.keys.usage):
+		return m.toggleUsage()
+
+	case key.Matches(msg, m.delegateKeys.choose) && isPathHeader(m.list.SelectedItem()):
+		return m.toggleFold()
+
+	case key.Matches(msg, m.delegateKeys.choose), key.Matches(msg, m.delegateKeys.kill):
+		return m, m.delegateUpdate(msg, &m.list)
+
 	case key.Matches(msg, m.keys.openProject):
-		// Open project picker
-		m.scanGitProjects()
-		m.projectPicker.SetItems(m.gitProjectsToItems())
-		m.state = StateProjectPicker
-		return m, nil
+		return m.openProjectPicker()
+
+	case key.Matches(msg, m.keys.switcher):
+		return m.openSwitcher()
+
+	case key.Matches(msg, m.keys.windows):
+		return m.openWindowList()
+
+	case key.Matches(msg, m.keys.quickCreate):
+		m.createFlow = newCreateFlow(m.layoutNames())
+		m.state = StateQuickCreate
+		return m, textinput.Blink
+
+	case key.Matches(msg, m.keys.quickAdd):
+		return m.startQuickAdd()
+
+	case key.Matches(msg, m.keys.clone):
+		return m.startClone()
+
+	case key.Matches(msg, m.keys.moveUp):
+		return m, m.moveSelected(-1)
+
+	case key.Matches(msg, m.keys.moveDown):
+		return m, m.moveSelected(1)
+
+	case key.Matches(msg, m.keys.sort):
+		m.sortMode = m.sortMode.next()
+		m.savePreferences()
+		m.list.SetItems(m.projectsToItems())
+		return m, m.list.NewStatusMessage(FormatStatusInfo("Sort: " + m.sortMode.String()))
+
+	case key.Matches(msg, m.keys.broadcast):
+		return m.startBroadcast()
+
+	case key.Matches(msg, m.keys.bulkRename):
+		return m.startBulkRename()
+
+	case key.Matches(msg, m.keys.clearHistory):
+		return m.startClearHistory()
+
+	case key.Matches(msg, m.keys.copyScript):
+		return m.copyStartScript()
+
+	case key.Matches(msg, m.keys.capture):
+		return m.startCaptureLayout()
+
+	case key.Matches(msg, m.keys.archive):
+		return m.toggleArchive()
+
+	case key.Matches(msg, m.keys.showArchived):
+		return m.toggleShowArchived()
+
+	case key.Matches(msg, m.keys.hideStopped):
+		return m.toggleHideStopped()
+
+	case key.Matches(msg, m.keys.layout):
+		return m.startLayoutSwitch()
+
+	case key.Matches(msg, m.keys.runOnce):
+		return m.startRunOnce()
+
+	case key.Matches(msg, m.keys.detach):
+		return m.startDetached()
+
+	case key.Matches(msg, m.keys.startEdit):
+		return m.startAndEdit()
+
+	case key.Matches(msg, m.keys.peek):
+		return m.peekProject()
+
+	case key.Matches(msg, m.keys.adopt):
+		return m.startAdopt()
+
+	case key.Matches(msg, m.keys.shortcuts):
+		return m.openShortcuts()
+
+	case key.Matches(msg, m.keys.console):
+		return m.startConsole()
+
+	case key.Matches(msg, m.keys.preview):
+		return m.togglePreview()
+
+	case key.Matches(msg, m.keys.readme):
+		return m.toggleReadme()
+
+	case key.Matches(msg, m.keys.prune):
+		removed, err := m.PruneState()
+		if err != nil {
+			return m, m.list.NewStatusMessage(FormatStatusError(err))
+		}
+		return m, m.list.NewStatusMessage(FormatStatusSuccess(pruneMessage(removed)))
 
 	case key.Matches(msg, m.keys.refresh):
 		if err := m.loadConfig(); err != nil {
 			return m, m.list.NewStatusMessage(FormatStatusError(err))
 		}
-		if err := m.refreshStatuses(); err != nil {
+		m.resize() // footer or logo settings may have changed
+		if err := m.refreshManually(); err != nil {
 			return m, m.list.NewStatusMessage(FormatStatusError(err))
 		}
 		m.list.SetItems(m.projectsToItems())
+		if w := m.configWarning(); w != "" {
+			return m, m.list.NewStatusMessage(FormatStatusWarning(w))
+		}
 		return m, m.list.NewStatusMessage(FormatStatusSuccess("Refreshed"))
 
 	case key.Matches(msg, m.keys.editConfig):
@@ -596,9 +1742,44 @@ func (m Model) updateHome(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Pass to list
+	before := m.selectedSession()
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
-	return m, cmd
+	m.refreshScrolled()
+	return m, tea.Batch(cmd, m.previewSelectionChanged(before))
+}
+
+// filtering reports whether a list filter is currently being typed.
+func (m Model) filtering() bool {
+	return m.list.FilterState() == list.Filtering || m.projectPicker.FilterState() == list.Filtering
+}
+
+// openProjectPicker switches to the project picker, abandoning any dialog or
+// input in progress.
+func (m Model) openProjectPicker() (tea.Model, tea.Cmd) {
+	m.confirm = nil
+	m.confirmSeq++ // invalidate a pending confirm timeout
+	m.createFlow = nil
+	m.broadcastCmd = ""
+	m.layoutSwitch = nil
+	m.runOnceProject = nil
+	m.captureProject = nil
+	m.collision, m.collisionLive, m.collisionRecreate = nil, nil, false
+	m.console = nil
+	m.killChoice = nil
+	m.quickAdd = nil
+	m.shortcuts = nil
+	m.renameProjects, m.renameTaken = nil, nil
+	m.switcher = nil
+	m.windowList = nil
+	m.cmdInput.Blur()
+	m.cmdInput.Reset()
+
+	m.scanGitProjects()
+	m.projectPicker.SetItems(m.gitProjectsToItems())
+	m.projectPicker.ResetFilter()
+	m.state = StateProjectPicker
+	return m, nil
 }
 
 func (m Model) updateProjectPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -618,7 +1799,8 @@ func (m Model) updateProjectPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Select the project and start a session
 		if item, ok := m.projectPicker.SelectedItem().(GitProject); ok {
 			m.state = StateHome
-			return m, m.startSessionAtPath(item.Path)
+			name := filepath.Base(item.Path)
+			return m.createSession(Project{Name: name, Session: sanitizeSessionName(name), Path: item.Path})
 		}
 		m.state = StateHome
 		return m, nil
@@ -629,63 +1811,96 @@ func (m Model) updateProjectPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if key.Matches(msg, m.pickerKeys.toggleRegistered) {
+		m.showRegistered = !m.showRegistered
+		m.projectPicker.SetItems(m.gitProjectsToItems())
+		label := "Hiding repos already added as projects"
+		if m.showRegistered {
+			label = "Showing all repos"
+		}
+		return m, m.projectPicker.NewStatusMessage(FormatStatusInfo(label))
+	}
+
+	if key.Matches(msg, m.keys.readme) {
+		return m.toggleReadme()
+	}
+
+	if key.Matches(msg, m.pickerKeys.sort) {
+		m.gitSortMode = m.gitSortMode.next()
+		m.projectPicker.SetItems(m.gitProjectsToItems())
+		m.projectPicker.Select(0)
+		return m, m.projectPicker.NewStatusMessage(FormatStatusInfo("Sorted by " + m.gitSortMode.String()))
+	}
+
 	var cmd tea.Cmd
 	m.projectPicker, cmd = m.projectPicker.Update(msg)
 	return m, cmd
 }
 
-func (m Model) updateConfirmKill(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "enter":
-		if m.confirmProject != nil {
-			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-			defer cancel()
-			session := m.confirmProject.Session
-			if err := m.tmux.KillSession(ctx, session); err != nil {
-				m.state = StateHome
-				return m, m.list.NewStatusMessage(FormatStatusError(err))
+// killDialog asks before killing p's session. busy lists foreground
+// commands that are not idle shells; when there are any the dialog warns
+// that their work may be lost.
+func killDialog(p Project, busy []string) *confirmDialog {
+	d := &confirmDialog{
+		title:  "⚠️  Kill Session?",
+		action: "Kill",
+		fields: []confirmField{{"Session", p.Session}, {"Project", p.Name}},
+		note:   "Kill the session: Notice this won't delete your project",
+		prompt: "kill",
+		data:   promptDataFor(p),
+		result: func(m Model, confirmed bool) (tea.Model, tea.Cmd) {
+			if !confirmed {
+				return m, nil
 			}
-			_ = m.refreshStatuses()
-			m.list.SetItems(m.projectsToItems())
-			m.confirmProject = nil
-			m.state = StateHome
-			return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Killed session %s", session)))
-		}
-		m.state = StateHome
-		return m, nil
-
-	case "n", "esc":
-		m.confirmProject = nil
-		m.state = StateHome
-		return m, nil
+			return m.killSession(p)
+		},
 	}
-
-	return m, nil
+	if len(busy) > 0 {
+		d.title = "🛑 Kill Busy Session?"
+		d.fields = append(d.fields, confirmField{"Running", strings.Join(busy, ", ")})
+		d.note = "Panes are running programs, not idle shells: unsaved work in them will be lost"
+		d.prompt = "kill_busy"
+	}
+	return d
 }
 
-func (m Model) attachProject(p Project) tea.Cmd {
+// killSession kills p's session, saving its window layouts first when the
+// project asks for it.
+func (m Model) killSession(p Project) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
 	session := p.Session
-
-	// If inside tmux, use switch-client; otherwise use attach
-	if m.insideTmux {
-		return tea.ExecProcess(
-			exec.Command("tmux", "switch-client", "-t", session),
-			func(err error) tea.Msg {
-				return nil
-			},
-		)
+	var snapshotErr error
+	if p.SnapshotLayout {
+		snapshotErr = m.snapshotLayouts(ctx, session)
 	}
-
-	return tea.ExecProcess(
-		exec.Command("tmux", "attach-session", "-t", session),
-		func(err error) tea.Msg {
-			return nil
-		},
-	)
+	if err := m.tmux.KillSession(ctx, session); err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	if p.transient() {
+		return m.killTransient(p)
+	}
+	_ = m.refreshStatuses()
+	m.list.SetItems(m.projectsToItems())
+	expire := m.offerUndo(p)
+	hint := ""
+	if expire != nil {
+		hint = " · u to undo"
+	}
+	if snapshotErr != nil {
+		return m, tea.Batch(expire, m.list.NewStatusMessage(FormatStatusWarning(fmt.Sprintf("Killed session %s (layout not saved: %v)%s", session, snapshotErr, hint))))
+	}
+	return m, tea.Batch(expire, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Killed session %s%s", session, hint))))
 }
 
 func (m Model) startProject(p Project) tea.Cmd {
-	// Start session using peakypanes start
+	return m.startProjectWith(p, "")
+}
+
+// startArgs returns the peakypanes arguments that start p. run, when set, is
+// typed into the first pane once the layout is applied. detach creates the
+// session in the background without attaching to it.
+func startArgs(p Project, run string, detach bool) []string {
 	args := []string{"start", "--session", p.Session}
 	if p.Path != "" {
 		args = append(args, "--path", p.Path)
@@ -693,32 +1908,31 @@ func (m Model) startProject(p Project) tea.Cmd {
 	if p.Layout != "" {
 		args = append(args, "--layout", p.Layout)
 	}
-
-	return tea.ExecProcess(
-		exec.Command("peakypanes", args...),
-		func(err error) tea.Msg {
-			return nil
-		},
-	)
-}
-
-func (m Model) startSessionAtPath(path string) tea.Cmd {
-	return tea.ExecProcess(
-		exec.Command("peakypanes", "start", "--path", path),
-		func(err error) tea.Msg {
-			return nil
-		},
-	)
+	if run != "" {
+		args = append(args, "--run", run)
+	}
+	if detach {
+		args = append(args, "--detach")
+	}
+	return args
 }
 
-func (m Model) editConfig() tea.Cmd {
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vim"
+func (m Model) startProjectWith(p Project, run string) tea.Cmd {
+	m.recordRecent(p.Session)
+	if m.noTmux {
+		return m.mockStart(p, run)
+	}
+	if m.openCommand != "" {
+		return m.startExternal(p, run)
+	}
+	// Start session using peakypanes start
+	args := startArgs(p, run, false)
+	if m.execMode() {
+		return execCmd(append([]string{"peakypanes"}, args...))
 	}
 
 	return tea.ExecProcess(
-		exec.Command(editor, m.configPath),
+		exec.Command("peakypanes", args...),
 		func(err error) tea.Msg {
 			return nil
 		},
@@ -730,78 +1944,133 @@ func (m Model) View() string {
 	case StateHome:
 		return m.viewHome()
 	case StateProjectPicker:
-		return appStyle.Render(m.projectPicker.View())
-	case StateConfirmKill:
-		return m.viewConfirmKill()
+		return appStyle.Render(m.joinReadme(m.projectPicker.View()))
+	case StateConfirm:
+		return m.viewConfirm()
+	case StateQuickCreate:
+		return m.viewQuickCreate()
+	case StateBroadcastInput:
+		return m.viewBroadcastInput()
+	case StateConfirmBroadcast:
+		return m.viewConfirmBroadcast()
+	case StateLayoutSwitch:
+		return m.viewLayoutSwitch()
+	case StateRunOnceInput:
+		return m.viewRunOnceInput()
+	case StateConfirmCollision:
+		return m.viewConfirmCollision()
+	case StateConfirmSave:
+		return m.viewConfirmSave()
+	case StateConsole:
+		return m.viewConsole()
+	case StateKillChoice:
+		return m.viewKillChoice()
+	case StateQuickAdd:
+		return m.viewQuickAdd()
+	case StateShortcuts:
+		return m.viewShortcuts()
+	case StateBulkRename:
+		return m.viewBulkRename()
+	case StateSwitcher:
+		return m.viewSwitcher()
+	case StateCloneInput:
+		return m.viewCloneInput()
+	case StateWindowList:
+		return m.viewWindowList()
+	case StateCaptureLayout:
+		return m.viewCaptureLayout()
 	default:
 		return m.viewHome()
 	}
 }
 
+// frameHeight is the terminal height available inside the app padding, or
+// zero before the first WindowSizeMsg.
+func (m Model) frameHeight() int {
+	if m.height == 0 {
+		return 0
+	}
+	_, v := appStyle.GetFrameSize()
+	return m.height - v
+}
+
+// resize fits the lists to the terminal size, once it is known.
+func (m *Model) resize() {
+	if m.width == 0 && m.height == 0 {
+		return
+	}
+	h, v := appStyle.GetFrameSize()
+	// Reserve space for the logo at the top unless it is collapsed
+	header := 0
+	if logoVisible(m.showLogo, m.height-v) {
+		header = logoHeight()
+	}
+	width := m.width - h
+	m.footer.apply(&m.list)
+	m.footer.apply(&m.projectPicker)
+	m.list.SetSize(width-m.previewWidth(width), m.height-v-header-m.debugRows())
+	m.refreshVisible() // a taller list shows rows that were not checked yet
+	m.list.SetItems(m.projectsToItems())
+	pickerWidth := width
+	if m.readme {
+		pickerWidth -= m.previewWidth(width)
+	}
+	m.projectPicker.SetSize(pickerWidth, m.height-v)
+}
+
 func (m Model) viewHome() string {
 	var s strings.Builder
 
 	// Logo at the top - using centralized theme
-	for _, line := range Logo {
-		s.WriteString(theme.LogoStyle.Render(line))
+	if logoVisible(m.showLogo, m.frameHeight()) {
+		for _, line := range Logo {
+			s.WriteString(theme.LogoStyle.Render(line))
+			s.WriteString("\n")
+		}
 		s.WriteString("\n")
 	}
-	s.WriteString("\n")
 
-	// List view
-	s.WriteString(m.list.View())
-
-	return appStyle.Render(s.String())
-}
-
-func (m Model) viewConfirmKill() string {
-	// Render list view dimmed in background - using centralized theme
-	listView := theme.ListDimmed.Render(m.list.View())
-
-	// Dialog content
-	var dialogContent strings.Builder
-
-	dialogContent.WriteString(dialogTitleStyle.Render("⚠️  Kill Session?"))
-	dialogContent.WriteString("\n\n")
-
-	if m.confirmProject != nil {
-		dialogContent.WriteString(theme.DialogLabel.Render("Session: "))
-		dialogContent.WriteString(theme.DialogValue.Render(m.confirmProject.Session))
-		dialogContent.WriteString("\n")
-		dialogContent.WriteString(theme.DialogLabel.Render("Project: "))
-		dialogContent.WriteString(theme.DialogValue.Render(m.confirmProject.Name))
-		dialogContent.WriteString("\n\n")
+	// List view, or a hint on how to add projects when there are none
+	if len(m.projects) == 0 {
+		s.WriteString(m.viewEmpty())
+	} else {
+		s.WriteString(m.joinPreview(m.list.View()))
 	}
+	s.WriteString(m.viewDebug())
 
-	dialogContent.WriteString(theme.DialogNote.Render("Kill the session: Notice this won't delete your project"))
-	dialogContent.WriteString("\n\n")
-
-	dialogContent.WriteString(theme.DialogChoiceKey.Render("y"))
-	dialogContent.WriteString(theme.DialogChoiceSep.Render(" confirm • "))
-	dialogContent.WriteString(theme.DialogChoiceKey.Render("n"))
-	dialogContent.WriteString(theme.DialogChoiceSep.Render(" cancel"))
-
-	dialog := dialogStyle.Render(dialogContent.String())
-
-	// Combine list and dialog
-	return appStyle.Render(listView + "\n\n" + dialog)
+	return appStyle.Render(s.String())
 }
 
 // Helper functions
 
-func statusIcon(s Status) string {
+// statusIcon returns the icon for s from set; unknown sets use symbols.
+func statusIcon(s Status, set GlyphSet) string {
+	g, ok := glyphSets[set]
+	if !ok {
+		g = glyphSets[GlyphsSymbol]
+	}
 	switch s {
 	case StatusCurrent:
-		return "◆"
+		return g.current
 	case StatusRunning:
-		return "●"
+		return g.running
 	case StatusStopped:
-		return "○"
+		return g.stopped
+	case StatusMissing:
+		return g.missing
 	default:
-		return "?"
+		return g.unknown
 	}
 }
 
+// pathExists reports whether path exists on disk. Errors other than
+// not-exist (e.g. permissions) count as existing so a project is only
+// flagged when it is really gone.
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil || !os.IsNotExist(err)
+}
+
 func expandPath(p string) string {
 	if p == "" {
 		return p
@@ -831,6 +2100,63 @@ func shortenPath(p string) string {
 	return p
 }
 
+// descSeparator joins the parts of a list item description.
+const descSeparator = " · "
+
+// listItemPadding is the horizontal space the list delegate uses for the
+// selection border and padding in front of each row.
+const listItemPadding = 2
+
+// fitDescription joins path and extras so the result fits within width
+// terminal columns. Only the path is shortened (in the middle); extras such
+// as counts are always kept. A width of zero or less disables truncation.
+func fitDescription(path string, extras []string, width int) string {
+	suffix := ""
+	if len(extras) > 0 {
+		suffix = descSeparator + strings.Join(extras, descSeparator)
+	}
+	if width > 0 {
+		path = truncateMiddle(path, width-runewidth.StringWidth(suffix))
+	}
+	return path + suffix
+}
+
+// truncateMiddle shortens s to at most max terminal columns by replacing its
+// middle with "…", keeping the start and the more informative end of paths.
+// Widths are measured per rune so wide characters (CJK, emoji) are never
+// split and never overflow.
+func truncateMiddle(s string, max int) string {
+	if runewidth.StringWidth(s) <= max {
+		return s
+	}
+	if max <= 0 {
+		return ""
+	}
+	if max == 1 {
+		return "…"
+	}
+	r := []rune(s)
+	keep := max - 1
+	headBudget := keep / 2
+	tailBudget := keep - headBudget
+
+	head, used := 0, 0
+	for head < len(r) && used+runewidth.RuneWidth(r[head]) <= headBudget {
+		used += runewidth.RuneWidth(r[head])
+		head++
+	}
+	// Give columns the head could not use (a wide rune at the boundary)
+	// to the tail.
+	tailBudget += headBudget - used
+
+	tail, used := len(r), 0
+	for tail > head && used+runewidth.RuneWidth(r[tail-1]) <= tailBudget {
+		used += runewidth.RuneWidth(r[tail-1])
+		tail--
+	}
+	return string(r[:head]) + "…" + string(r[tail:])
+}
+
 func sanitizeSessionName(name string) string {
 	name = strings.ToLower(strings.TrimSpace(name))
 	if name == "" {
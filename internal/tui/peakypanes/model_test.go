@@ -1,10 +1,21 @@
 package peakypanes
 
 import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
 )
 
 // TestStatusIcon tests the status icon helper function
@@ -17,12 +28,13 @@ func TestStatusIcon(t *testing.T) {
 		{name: "current", status: StatusCurrent, want: "◆"},
 		{name: "running", status: StatusRunning, want: "●"},
 		{name: "stopped", status: StatusStopped, want: "○"},
+		{name: "missing", status: StatusMissing, want: "⚠"},
 		{name: "unknown", status: Status(99), want: "?"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := statusIcon(tt.status)
+			got := statusIcon(tt.status, GlyphsSymbol)
 			if got != tt.want {
 				t.Errorf("statusIcon(%d) = %q, want %q", tt.status, got, tt.want)
 			}
@@ -151,6 +163,74 @@ func TestProjectListItem(t *testing.T) {
 	}
 }
 
+// TestProjectTagColor tests that the primary tag colors the name, honoring theme overrides
+func TestProjectTagColor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	content := `theme:
+  tag_colors:
+    backend: "#123456"
+projects:
+  - name: api
+    path: /srv/api
+    tags: [backend, go]
+  - name: web
+    path: /srv/web
+    tags: [frontend]
+  - name: docs
+    path: /srv/docs
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := newTestModel(t, nil)
+	m.configPath = path
+	if err := m.loadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	items := m.projectsToItems()
+	want := []lipgloss.Color{"#123456", theme.TagColor("frontend", nil), ""}
+	for i, item := range items {
+		if got := item.(Project).nameColor; got != want[i] {
+			t.Errorf("%s nameColor = %q, want %q", item.(Project).Name, got, want[i])
+		}
+	}
+	if title := items[2].(Project).Title(); title != "○ docs" {
+		t.Errorf("untagged Title() = %q, want plain name", title)
+	}
+}
+
+// TestRefreshStatusesMissingPath tests that stopped projects with a vanished path are flagged
+func TestRefreshStatusesMissingPath(t *testing.T) {
+	dir := t.TempDir()
+	m, _ := newTestModel(t, []Project{
+		{Name: "here", Session: "here", Path: dir},
+		{Name: "gone", Session: "gone", Path: filepath.Join(dir, "gone")},
+	})
+	if err := m.refreshStatuses(); err != nil {
+		t.Fatalf("refreshStatuses() error: %v", err)
+	}
+	if m.projects[0].Status != StatusStopped {
+		t.Errorf("existing path status = %v, want StatusStopped", m.projects[0].Status)
+	}
+	gone := m.projects[1]
+	if gone.Status != StatusMissing {
+		t.Fatalf("missing path status = %v, want StatusMissing", gone.Status)
+	}
+	if !strings.HasPrefix(gone.Title(), "⚠ ") || !strings.HasSuffix(gone.Description(), "missing") {
+		t.Errorf("missing project renders as %q / %q", gone.Title(), gone.Description())
+	}
+
+	// Choosing a missing project must not start a session
+	m.list.SetItems(m.projectsToItems())
+	m.list.Select(1)
+	m.list.SetSize(80, 20)
+	m.delegateUpdate(keyMsg("enter"), &m.list)
+	if view := m.list.View(); !strings.Contains(view, "Path not found") {
+		t.Errorf("choosing a missing project should warn instead of starting it:\n%s", view)
+	}
+}
+
 // TestProjectDescriptionEmpty tests description when path is empty
 func TestProjectDescriptionEmpty(t *testing.T) {
 	p := Project{
@@ -246,7 +326,7 @@ func TestViewStateConstants(t *testing.T) {
 	states := map[ViewState]string{
 		StateHome:          "home",
 		StateProjectPicker: "picker",
-		StateConfirmKill:   "confirm",
+		StateConfirm:       "confirm",
 	}
 
 	seen := make(map[ViewState]bool)
@@ -265,6 +345,7 @@ func TestStatusConstants(t *testing.T) {
 		StatusStopped: "stopped",
 		StatusRunning: "running",
 		StatusCurrent: "current",
+		StatusMissing: "missing",
 	}
 
 	seen := make(map[Status]bool)
@@ -275,3 +356,201 @@ func TestStatusConstants(t *testing.T) {
 		seen[status] = true
 	}
 }
+
+// TestTruncateMiddle tests middle truncation at several widths
+func TestTruncateMiddle(t *testing.T) {
+	path := "/home/user/projects/very/long/project"
+	tests := []struct {
+		max  int
+		want string
+	}{
+		{max: 100, want: path},
+		{max: len(path), want: path},
+		{max: 11, want: "/home…oject"},
+		{max: 10, want: "/hom…oject"},
+		{max: 1, want: "…"},
+		{max: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		got := truncateMiddle(path, tt.max)
+		if got != tt.want {
+			t.Errorf("truncateMiddle(%d) = %q, want %q", tt.max, got, tt.want)
+		}
+		if tt.max > 0 && len([]rune(got)) > tt.max {
+			t.Errorf("truncateMiddle(%d) returned %d runes", tt.max, len([]rune(got)))
+		}
+	}
+}
+
+// TestTruncateMiddleWide tests truncation of wide characters by display width
+func TestTruncateMiddleWide(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{name: "cjk fits", in: "~/項目/api", max: 10, want: "~/項目/api"},
+		{name: "cjk", in: "~/プロジェクト/web", max: 10, want: "~/プ…/web"},
+		{name: "emoji", in: "🚀🚀🚀🚀🚀🚀", max: 7, want: "🚀…🚀🚀"},
+		{name: "wide at boundary", in: "ab漢字cdef", max: 6, want: "ab…def"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateMiddle(tt.in, tt.max)
+			if got != tt.want {
+				t.Errorf("truncateMiddle(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+			if w := runewidth.StringWidth(got); w > tt.max {
+				t.Errorf("truncateMiddle(%q, %d) is %d columns wide", tt.in, tt.max, w)
+			}
+		})
+	}
+
+	desc := fitDescription("~/作品/ゲーム/client", []string{"🧪 tests"}, 20)
+	if w := runewidth.StringWidth(desc); w > 20 {
+		t.Errorf("fitDescription() = %q is %d columns, want <= 20", desc, w)
+	}
+}
+
+// TestProjectDescriptionWidth tests that descriptions fit the width and keep extras
+func TestProjectDescriptionWidth(t *testing.T) {
+	p := Project{Name: "scratch", Path: "/srv/some/deeply/nested/scratch/dir", Scratch: true}
+
+	for _, width := range []int{20, 30, 40} {
+		p.descWidth = width
+		desc := p.Description()
+		if n := len([]rune(desc)); n > width {
+			t.Errorf("width %d: Description() = %q (%d runes)", width, desc, n)
+		}
+		if !strings.HasSuffix(desc, descSeparator+"scratch") {
+			t.Errorf("width %d: Description() = %q lost its extras", width, desc)
+		}
+	}
+
+	p.descWidth = 0
+	if desc := p.Description(); desc != "/srv/some/deeply/nested/scratch/dir · scratch" {
+		t.Errorf("unlimited Description() = %q", desc)
+	}
+}
+
+// tmuxCalls records tmux invocations made through a test client.
+type tmuxCalls struct {
+	args [][]string
+}
+
+// recordingClient returns a tmux client that records its arguments instead
+// of running tmux.
+func recordingClient(t *testing.T) (*tmuxctl.Client, *tmuxCalls) {
+	t.Helper()
+	client, err := tmuxctl.NewClient("tmux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := &tmuxCalls{}
+	client.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls.args = append(calls.args, args)
+		return exec.CommandContext(ctx, "true")
+	})
+	return client, calls
+}
+
+// newTestModel builds a Model around projects without touching tmux or disk.
+func newTestModel(t *testing.T, projects []Project) (*Model, *tmuxCalls) {
+	t.Helper()
+	client, calls := recordingClient(t)
+	m := &Model{
+		tmux:         client,
+		configPath:   filepath.Join(t.TempDir(), "config.yml"),
+		state:        StateHome,
+		keys:         newListKeyMap(),
+		delegateKeys: newDelegateKeyMap(),
+		pickerKeys:   newPickerKeyMap(),
+		projects:     projects,
+	}
+	m.setupList()
+	m.setupProjectPicker()
+	return m, calls
+}
+
+// keyMsg builds a tea.KeyMsg for a key string like "enter" or "y".
+func keyMsg(k string) tea.KeyMsg {
+	switch k {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "ctrl+g":
+		return tea.KeyMsg{Type: tea.KeyCtrlG}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// press sends keys through Update and returns the resulting model.
+func press(t *testing.T, m Model, keys ...string) Model {
+	t.Helper()
+	for _, k := range keys {
+		next, _ := m.Update(keyMsg(k))
+		m = next.(Model)
+	}
+	return m
+}
+
+// TestGlobalPickerKey tests that ctrl+g returns to the picker from any view
+func TestGlobalPickerKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	running := []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}}
+
+	tests := []struct {
+		name  string
+		setup []string
+		want  ViewState
+	}{
+		{name: "home", want: StateHome},
+		{name: "confirm kill", setup: []string{"K"}, want: StateConfirm},
+		{name: "quick create", setup: []string{"c", "x"}, want: StateQuickCreate},
+		{name: "broadcast input", setup: []string{"b", "l", "s"}, want: StateBroadcastInput},
+		{name: "confirm broadcast", setup: []string{"b", "l", "s", "enter"}, want: StateConfirmBroadcast},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, calls := newTestModel(t, running)
+			model := press(t, *m, tt.setup...)
+			if model.state != tt.want {
+				t.Fatalf("setup state = %v, want %v", model.state, tt.want)
+			}
+			calls.args = nil // setup may query tmux, e.g. K asks for the window count
+
+			model = press(t, model, "ctrl+g")
+			if model.state != StateProjectPicker {
+				t.Errorf("state = %v, want StateProjectPicker", model.state)
+			}
+			if model.confirm != nil || model.createFlow != nil || model.broadcastCmd != "" {
+				t.Error("ctrl+g should discard in-progress dialogs")
+			}
+			if len(calls.args) != 0 {
+				t.Errorf("ctrl+g ran tmux commands: %v", calls.args)
+			}
+		})
+	}
+}
+
+// TestGlobalPickerKeyWhileFiltering tests that ctrl+g is left to an active filter
+func TestGlobalPickerKeyWhileFiltering(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api"}})
+	model := press(t, *m, "/")
+	if model.list.FilterState() != list.Filtering {
+		t.Fatalf("filter state = %v, want Filtering", model.list.FilterState())
+	}
+	model = press(t, model, "ctrl+g")
+	if model.state != StateHome {
+		t.Errorf("state = %v, want StateHome while filtering", model.state)
+	}
+}
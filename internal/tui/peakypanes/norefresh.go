@@ -0,0 +1,12 @@
+package peakypanes
+
+// refreshManually is the refresh key's refresh: refreshStatuses, then the
+// path checks and healthchecks of visible projects marked no_auto_refresh,
+// which automatic refreshes skip.
+func (m *Model) refreshManually() error {
+	if err := m.refreshStatuses(); err != nil {
+		return err
+	}
+	m.refreshVisibleWhere(func(p *Project) bool { return p.NoAutoRefresh })
+	return nil
+}
@@ -0,0 +1,67 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestNoAutoRefresh tests flagged projects are skipped by automatic
+// refreshes but checked by the refresh key
+func TestNoAutoRefresh(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Healthcheck: "api"},
+		{Name: "remote", Session: "remote", Path: "/srv/remote", Healthcheck: "remote", NoAutoRefresh: true},
+	})
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		if len(args) == 3 && args[0] == "list-sessions" && args[2] == "#{session_name}" {
+			return exec.CommandContext(ctx, "printf", "api\nremote\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+	var mu sync.Mutex
+	var ran []string
+	m.healthRunner = func(ctx context.Context, command string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		ran = append(ran, command)
+		return nil
+	}
+
+	if err := m.refreshStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	checkQueuedHealth(m)
+	if len(ran) != 1 || ran[0] != "api" {
+		t.Fatalf("automatic refresh ran %v, want only api", ran)
+	}
+	if m.projects[1].Health != HealthUnknown {
+		t.Errorf("remote health = %d, want HealthUnknown before a manual refresh", m.projects[1].Health)
+	}
+
+	ran = nil
+	m.list.StatusMessageLifetime = time.Millisecond
+	next, cmd := m.Update(keyMsg("r"))
+	model := healthResults(t, next.(Model), cmd)
+	if len(ran) != 2 {
+		t.Fatalf("manual refresh ran %v, want api and remote", ran)
+	}
+	if model.projects[1].Health != HealthOK {
+		t.Errorf("remote health = %d, want HealthOK after a manual refresh", model.projects[1].Health)
+	}
+
+	// An automatic refresh keeps the last manual result
+	ran = nil
+	if err := model.refreshStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	checkQueuedHealth(&model)
+	if len(ran) != 1 || ran[0] != "api" {
+		t.Errorf("automatic refresh ran %v, want only api", ran)
+	}
+	if model.projects[1].Health != HealthOK {
+		t.Errorf("remote health = %d, want the kept HealthOK", model.projects[1].Health)
+	}
+}
@@ -0,0 +1,72 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// With Options.NoTmux the model talks to a tmuxctl.Mock instead of a tmux
+// server: starting a project creates a simulated session and attaching only
+// makes it the mock's current client, so nothing is exec'd and the list
+// stays open. Meant for demos and for trying the TUI where tmux is missing.
+
+// mockAttachedMsg reports a simulated attach.
+type mockAttachedMsg struct {
+	Session string
+	Err     error
+}
+
+// mockStartRunner returns a startRunner that creates the session named in
+// peakypanes start arguments on client instead of running peakypanes.
+func mockStartRunner(client *tmuxctl.Client) func(args ...string) ([]byte, error) {
+	return func(args ...string) ([]byte, error) {
+		var session, path string
+		for i := 0; i+1 < len(args); i++ {
+			switch args[i] {
+			case "--session":
+				session = args[i+1]
+			case "--path":
+				path = args[i+1]
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if _, err := client.NewSessionWithCmd(ctx, session, path, "", ""); err != nil {
+			return []byte(err.Error()), err
+		}
+		return nil, nil
+	}
+}
+
+// mockStart starts p as a simulated session and attaches to it.
+func (m Model) mockStart(p Project, run string) tea.Cmd {
+	return m.startDetachedThen(p, run, func(next Model) tea.Cmd {
+		return next.mockAttach(p.Session)
+	})
+}
+
+// mockAttach marks session as the attached one on the mock server.
+func (m Model) mockAttach(session string) tea.Cmd {
+	client := m.tmux
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_, err := client.Exec(ctx, []string{"switch-client", "-t", session})
+		return mockAttachedMsg{Session: session, Err: err}
+	}
+}
+
+// handleMockAttached refreshes the attached counts after a simulated attach.
+func (m Model) handleMockAttached(msg mockAttachedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(NewErrorMsg(msg.Err, "attach "+msg.Session)))
+	}
+	_ = m.refreshStatuses()
+	m.list.SetItems(m.projectsToItems())
+	return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Attached to %s (simulated, no tmux)", msg.Session)))
+}
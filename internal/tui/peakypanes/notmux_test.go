@@ -0,0 +1,99 @@
+package peakypanes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// newMockModel returns a test model in --no-tmux mode backed by mock.
+func newMockModel(t *testing.T, projects []Project) (*Model, *tmuxctl.Mock) {
+	t.Helper()
+	m, _ := newTestModel(t, projects)
+	mock := tmuxctl.NewMock()
+	m.tmux = tmuxctl.NewMockClient(mock)
+	m.noTmux = true
+	m.startRunner = mockStartRunner(m.tmux)
+	m.list.StatusMessageLifetime = time.Millisecond
+	return m, mock
+}
+
+// deliver runs cmd and feeds its messages back through Update until no
+// command is left.
+func deliver(t *testing.T, m Model, cmd tea.Cmd) Model {
+	t.Helper()
+	for _, msg := range runCmd(cmd) {
+		if msg == nil {
+			continue
+		}
+		next, more := m.Update(msg)
+		m = deliver(t, next.(Model), more)
+	}
+	return m
+}
+
+func projectStatus(m Model, session string) Status {
+	for _, p := range m.projects {
+		if p.Session == session {
+			return p.Status
+		}
+	}
+	return StatusMissing
+}
+
+// TestNoTmuxCreateListKill tests starting, listing and killing sessions
+// against the in-memory multiplexer
+func TestNoTmuxCreateListKill(t *testing.T) {
+	t.Setenv("PATH", "") // nothing may be exec'd
+	api := Project{Name: "api", Session: "api", Path: t.TempDir()}
+	web := Project{Name: "web", Session: "web", Path: t.TempDir()}
+	m, mock := newMockModel(t, []Project{api, web})
+	_ = m.refreshStatuses()
+	if got := projectStatus(*m, "api"); got != StatusStopped {
+		t.Fatalf("api status before start = %v, want stopped", got)
+	}
+
+	model := deliver(t, *m, m.startProject(api))
+	model = deliver(t, model, model.startDetachedCmd(web))
+	if want := []string{"api", "web"}; !reflect.DeepEqual(mock.Sessions(), want) {
+		t.Fatalf("mock sessions = %v, want %v", mock.Sessions(), want)
+	}
+	if !projectStatus(model, "api").running() || !projectStatus(model, "web").running() {
+		t.Errorf("statuses after start = %v, %v, want running", projectStatus(model, "api"), projectStatus(model, "web"))
+	}
+	for _, p := range model.projects {
+		if p.Session == "api" && p.Clients != 1 {
+			t.Errorf("api clients = %d, want 1 after the simulated attach", p.Clients)
+		}
+	}
+
+	next, _ := model.killSession(api)
+	model = next.(Model)
+	if want := []string{"web"}; !reflect.DeepEqual(mock.Sessions(), want) {
+		t.Errorf("mock sessions after kill = %v, want %v", mock.Sessions(), want)
+	}
+	if got := projectStatus(model, "api"); got != StatusStopped {
+		t.Errorf("api status after kill = %v, want stopped", got)
+	}
+}
+
+// TestNoTmuxStartTwice tests that a start of a simulated running session
+// reports the mock's duplicate session error
+func TestNoTmuxStartTwice(t *testing.T) {
+	api := Project{Name: "api", Session: "api", Path: t.TempDir()}
+	m, _ := newMockModel(t, []Project{api})
+	msgs := runCmd(m.startDetachedCmd(api))
+	if started, ok := msgs[0].(SessionStartedMsg); !ok || started.Err != nil {
+		t.Fatalf("first start = %#v", msgs)
+	}
+	msgs = runCmd(m.startDetachedCmd(api))
+	started, ok := msgs[0].(SessionStartedMsg)
+	if !ok || started.Err == nil || !strings.Contains(started.Err.Error(), "duplicate session: api") {
+		t.Errorf("second start = %#v, want a duplicate session error", msgs)
+	}
+}
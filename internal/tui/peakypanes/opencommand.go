@@ -0,0 +1,51 @@
+package peakypanes
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openCommandArgv renders the open_command template for p into an argv.
+// The template is split into words on spaces before {session}, {path} and
+// {name} are filled in, so a path with spaces stays one argument. An empty
+// template gives nil.
+func openCommandArgv(template string, p Project) []string {
+	words := strings.Fields(template)
+	if len(words) == 0 {
+		return nil
+	}
+	r := strings.NewReplacer(
+		"{session}", p.Session,
+		"{path}", p.Path,
+		"{name}", p.Name,
+	)
+	argv := make([]string, len(words))
+	for i, w := range words {
+		argv[i] = r.Replace(w)
+	}
+	return argv
+}
+
+// openExternal opens p's running session through open_command, leaving
+// the list open.
+func (m Model) openExternal(p Project) tea.Cmd {
+	argv := openCommandArgv(m.openCommand, p)
+	run := m.openRunner
+	if run == nil {
+		run = startBackground
+	}
+	if err := run(argv); err != nil {
+		return m.list.NewStatusMessage(FormatStatusError(NewErrorMsg(err, "open_command")))
+	}
+	return m.list.NewStatusMessage(FormatStatusSuccess("Opened " + p.Session))
+}
+
+// startExternal starts p in the background, typing run into its first
+// pane, and opens it through open_command once it is up.
+func (m Model) startExternal(p Project, run string) tea.Cmd {
+	return tea.Batch(
+		m.list.NewStatusMessage(FormatStatusInfo("Starting "+p.Session+"…")),
+		m.startDetachedThen(p, run, func(m Model) tea.Cmd { return m.openExternal(p) }),
+	)
+}
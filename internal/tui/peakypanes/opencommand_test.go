@@ -0,0 +1,68 @@
+package peakypanes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestOpenCommandArgv tests rendering the open_command template into an argv
+func TestOpenCommandArgv(t *testing.T) {
+	p := Project{Name: "api", Session: "pp-api", Path: "/srv/my api"}
+	tests := []struct {
+		template string
+		want     []string
+	}{
+		{"ghostty -e tmux attach -t {session}", []string{"ghostty", "-e", "tmux", "attach", "-t", "pp-api"}},
+		{"open-term --cwd={path} --title {name}:{session}", []string{"open-term", "--cwd=/srv/my api", "--title", "api:pp-api"}},
+		{"  wezterm  start  ", []string{"wezterm", "start"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := openCommandArgv(tt.template, p); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("openCommandArgv(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+// TestOpenCommandReplacesAttach tests that enter runs open_command for a
+// running session and starts a stopped one in the background first
+func TestOpenCommandReplacesAttach(t *testing.T) {
+	m, calls := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning},
+		{Name: "web", Session: "web", Path: t.TempDir()},
+	})
+	m.openCommand = "term -e tmux attach -t {session}"
+	m.list.StatusMessageLifetime = time.Millisecond
+	var log []string
+	m.openRunner = func(argv []string) error {
+		log = append(log, "open "+argv[len(argv)-1])
+		return nil
+	}
+	m.startRunner = func(args ...string) ([]byte, error) {
+		log = append(log, "start "+args[2])
+		return nil, nil
+	}
+
+	model := press(t, *m, "enter")
+	if !reflect.DeepEqual(log, []string{"open api"}) {
+		t.Errorf("actions = %q, want only open_command", log)
+	}
+	for _, args := range calls.args {
+		if args[0] == "attach-session" || args[0] == "switch-client" {
+			t.Errorf("attached despite open_command: %q", args)
+		}
+	}
+
+	log = nil
+	model.list.Select(1)
+	next, cmd := model.Update(keyMsg("enter"))
+	for _, msg := range runCmd(cmd) {
+		if started, ok := msg.(startedThenMsg); ok {
+			next.(Model).Update(started)
+		}
+	}
+	if !reflect.DeepEqual(log, []string{"start web", "open web"}) {
+		t.Errorf("actions = %q, want a background start then open_command", log)
+	}
+}
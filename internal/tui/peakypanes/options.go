@@ -0,0 +1,23 @@
+package peakypanes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// optionsWarning names the tmux options of projects that are not known
+// session options. They are skipped when the sessions are created.
+func optionsWarning(projects []Project) error {
+	var parts []string
+	for _, p := range projects {
+		if unknown := tmuxctl.UnknownOptions(p.Options); len(unknown) > 0 {
+			parts = append(parts, fmt.Sprintf("%s (%s)", strings.Join(unknown, ", "), p.Name))
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unknown tmux options %s", strings.Join(parts, ", "))
+}
@@ -0,0 +1,20 @@
+package peakypanes
+
+import "testing"
+
+// TestOptionsWarning tests that unknown tmux option names are reported per project
+func TestOptionsWarning(t *testing.T) {
+	projects := []Project{
+		{Name: "api", Options: map[string]string{"mouse": "off", "status-postion": "top"}},
+		{Name: "web", Options: map[string]string{"history-limit": "50000", "@theme": "dark"}},
+		{Name: "cli", Options: map[string]string{"mouze": "on", "bell": "none"}},
+	}
+	err := optionsWarning(projects)
+	want := "unknown tmux options status-postion (api), bell, mouze (cli)"
+	if err == nil || err.Error() != want {
+		t.Errorf("optionsWarning() = %v, want %q", err, want)
+	}
+	if err := optionsWarning(projects[1:2]); err != nil {
+		t.Errorf("optionsWarning(known) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,217 @@
+package peakypanes
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// SortMode controls the order projects are listed in.
+type SortMode int
+
+const (
+	// SortManual keeps the order from the config file, or follows the
+	// order keys of projects when any project sets one.
+	SortManual SortMode = iota
+	// SortName orders projects alphabetically by name.
+	SortName
+)
+
+func (s SortMode) String() string {
+	switch s {
+	case SortName:
+		return "name"
+	default:
+		return "manual"
+	}
+}
+
+// next cycles to the following sort mode.
+func (s SortMode) next() SortMode {
+	if s == SortName {
+		return SortManual
+	}
+	return SortName
+}
+
+// sortProjects returns the projects ordered according to mode. The input
+// slice is not modified.
+func sortProjects(projects []Project, mode SortMode) []Project {
+	sorted := make([]Project, len(projects))
+	copy(sorted, projects)
+	if mode == SortManual && hasConfigOrder(sorted) {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return configOrderLess(sorted[i], sorted[j])
+		})
+	}
+	if mode == SortName {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if sorted[i].Scratch != sorted[j].Scratch {
+				return sorted[i].Scratch
+			}
+			return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name)
+		})
+	}
+	return sorted
+}
+
+// hasConfigOrder reports whether any project sets an order key.
+func hasConfigOrder(projects []Project) bool {
+	for _, p := range projects {
+		if p.Order != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// configOrderLess orders projects by their order keys: the scratch project
+// first, then projects with an order by it, then configured projects
+// without one by name. Unconfigured sessions stay last in their order.
+func configOrderLess(a, b Project) bool {
+	rank := func(p Project) int {
+		switch {
+		case p.Scratch:
+			return 0
+		case p.Order != nil:
+			return 1
+		case p.Path != "":
+			return 2
+		}
+		return 3
+	}
+	ra, rb := rank(a), rank(b)
+	switch {
+	case ra != rb:
+		return ra < rb
+	case ra == 1:
+		return *a.Order < *b.Order
+	case ra == 2:
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	}
+	return false
+}
+
+// moveProject moves the project at index by delta within the first limit
+// entries (the configured projects). Only entries for which visible reports
+// true count as steps, so the project swaps places with its nearest visible
+// neighbor and hidden ones, e.g. archived projects, stay where they are; a
+// nil visible counts every entry. It returns the new slice, the new index
+// and whether anything moved; moving past either boundary or moving the
+// pinned scratch project is a no-op.
+func moveProject(projects []Project, limit, index, delta int, visible func(Project) bool) ([]Project, int, bool) {
+	if index < 0 || index >= limit || limit > len(projects) || delta == 0 {
+		return projects, index, false
+	}
+	step, steps := 1, delta
+	if delta < 0 {
+		step, steps = -1, -delta
+	}
+	target := index
+	for steps > 0 {
+		target += step
+		if target < 0 || target >= limit {
+			return projects, index, false
+		}
+		if visible == nil || visible(projects[target]) {
+			steps--
+		}
+	}
+	if projects[index].Scratch || projects[target].Scratch {
+		return projects, index, false
+	}
+	moved := make([]Project, len(projects))
+	copy(moved, projects)
+	moved[index], moved[target] = moved[target], moved[index]
+	return moved, target, true
+}
+
+// configuredCount returns how many leading projects come from the config file.
+// refreshStatuses keeps configured projects first and appends transient
+// projects and unconfigured running sessions after them.
+func configuredCount(projects []Project) int {
+	n := 0
+	for _, p := range projects {
+		if p.Path == "" || p.transient() {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// reorderProjectNodes reorders the project sequence to follow sessions,
+// resolving entries with session prefix prefix. Entries not listed keep
+// their relative order at the end.
+func reorderProjectNodes(seq *yaml.Node, sessions []string, prefix string) {
+	rank := make(map[string]int, len(sessions))
+	for i, s := range sessions {
+		rank[s] = i
+	}
+	pos := func(n *yaml.Node) int {
+		if r, ok := rank[projectNodeSession(n, prefix)]; ok {
+			return r
+		}
+		return len(sessions)
+	}
+	sort.SliceStable(seq.Content, func(i, j int) bool {
+		return pos(seq.Content[i]) < pos(seq.Content[j])
+	})
+}
+
+// saveProjectOrder persists the current order of configured projects.
+func (m *Model) saveProjectOrder() error {
+	var sessions []string
+	for _, p := range m.projects[:configuredCount(m.projects)] {
+		sessions = append(sessions, p.savedSession())
+	}
+	return m.updateConfigFile(func(doc *yaml.Node) error {
+		if seq := projectsNode(doc, false); seq != nil {
+			reorderProjectNodes(seq, sessions, m.sessionPrefix)
+		}
+		return nil
+	})
+}
+
+// moveSelected moves the selected project by delta and saves the new order.
+func (m *Model) moveSelected(delta int) tea.Cmd {
+	if m.sortMode != SortManual {
+		return m.list.NewStatusMessage(FormatStatusWarning("Reordering only works in manual sort mode"))
+	}
+	if hasConfigOrder(m.projects) {
+		return m.list.NewStatusMessage(FormatStatusWarning("The order keys in the config file set the order"))
+	}
+	if m.list.FilterState() != list.Unfiltered {
+		return m.list.NewStatusMessage(FormatStatusWarning("Clear the filter to reorder projects"))
+	}
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return nil
+	}
+	index := -1
+	for i, p := range m.projects {
+		if p.Session == item.Session {
+			index = i
+			break
+		}
+	}
+
+	listed := make(map[string]bool)
+	for _, it := range m.list.Items() {
+		if p, ok := it.(Project); ok {
+			listed[p.Session] = true
+		}
+	}
+	visible := func(p Project) bool { return listed[p.Session] }
+	projects, _, moved := moveProject(m.projects, configuredCount(m.projects), index, delta, visible)
+	if !moved {
+		return nil
+	}
+	m.projects = projects
+	m.list.SetItems(m.projectsToItems())
+	m.selectSession(item.Session)
+	return m.saveConfig((*Model).saveProjectOrder)
+}
@@ -0,0 +1,191 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func projectNames(projects []Project) string {
+	names := make([]string, len(projects))
+	for i, p := range projects {
+		names[i] = p.Name
+	}
+	return strings.Join(names, ",")
+}
+
+// TestMoveProject tests reordering including top/bottom boundaries
+func TestMoveProject(t *testing.T) {
+	projects := []Project{
+		{Name: "a", Path: "/a"},
+		{Name: "b", Path: "/b"},
+		{Name: "c", Path: "/c"},
+		{Name: "live"}, // unconfigured running session
+	}
+	limit := configuredCount(projects)
+	if limit != 3 {
+		t.Fatalf("configuredCount() = %d, want 3", limit)
+	}
+
+	tests := []struct {
+		name      string
+		index     int
+		delta     int
+		want      string
+		wantIndex int
+		wantMoved bool
+	}{
+		{name: "down", index: 0, delta: 1, want: "b,a,c,live", wantIndex: 1, wantMoved: true},
+		{name: "up", index: 2, delta: -1, want: "a,c,b,live", wantIndex: 1, wantMoved: true},
+		{name: "top no-op", index: 0, delta: -1, want: "a,b,c,live", wantIndex: 0},
+		{name: "bottom no-op", index: 2, delta: 1, want: "a,b,c,live", wantIndex: 2},
+		{name: "unconfigured no-op", index: 3, delta: -1, want: "a,b,c,live", wantIndex: 3},
+		{name: "not found", index: -1, delta: 1, want: "a,b,c,live", wantIndex: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, index, moved := moveProject(projects, limit, tt.index, tt.delta, nil)
+			if projectNames(got) != tt.want || index != tt.wantIndex || moved != tt.wantMoved {
+				t.Errorf("moveProject(%d, %d) = %s, %d, %v; want %s, %d, %v",
+					tt.index, tt.delta, projectNames(got), index, moved, tt.want, tt.wantIndex, tt.wantMoved)
+			}
+		})
+	}
+
+	if projectNames(projects) != "a,b,c,live" {
+		t.Error("moveProject must not modify its input")
+	}
+}
+
+// TestMoveProjectSkipsHidden tests that a move swaps with the nearest
+// visible neighbor and leaves hidden entries in place
+func TestMoveProjectSkipsHidden(t *testing.T) {
+	projects := []Project{
+		{Name: "a", Path: "/a"},
+		{Name: "old", Path: "/old", Archived: true},
+		{Name: "b", Path: "/b"},
+		{Name: "hidden", Path: "/h", Archived: true},
+	}
+	visible := func(p Project) bool { return !p.Archived }
+
+	got, index, moved := moveProject(projects, 4, 2, -1, visible)
+	if projectNames(got) != "b,old,a,hidden" || index != 0 || !moved {
+		t.Errorf("up past hidden = %s, %d, %v; want b,old,a,hidden, 0, true", projectNames(got), index, moved)
+	}
+	// Only hidden entries below: nothing to swap with
+	if got, _, moved := moveProject(projects, 4, 2, 1, visible); moved || projectNames(got) != "a,old,b,hidden" {
+		t.Errorf("down onto hidden = %s, %v; want no move", projectNames(got), moved)
+	}
+}
+
+// TestMoveSelectedSkipsArchived tests reordering in the TUI past an
+// archived project that is not listed
+func TestMoveSelectedSkipsArchived(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/a"},
+		{Name: "old", Session: "old", Path: "/o", Archived: true},
+		{Name: "web", Session: "web", Path: "/w"},
+	})
+	m.configPath = filepath.Join(t.TempDir(), "config.yml")
+	m.selectSession("web")
+	m.moveSelected(-1)
+	if got := projectNames(m.projects); got != "web,old,api" {
+		t.Errorf("projects = %s, want web,old,api", got)
+	}
+}
+
+// TestSortProjects tests manual and name sort modes
+func TestSortProjects(t *testing.T) {
+	projects := []Project{{Name: "beta"}, {Name: "Alpha"}, {Name: "gamma"}}
+
+	if got := projectNames(sortProjects(projects, SortManual)); got != "beta,Alpha,gamma" {
+		t.Errorf("manual sort = %s", got)
+	}
+	if got := projectNames(sortProjects(projects, SortName)); got != "Alpha,beta,gamma" {
+		t.Errorf("name sort = %s", got)
+	}
+	if SortManual.next() != SortName || SortName.next() != SortManual {
+		t.Error("SortMode.next() should cycle manual <-> name")
+	}
+}
+
+// TestSortProjectsConfigOrder tests that order keys drive the manual sort,
+// with unordered projects after them by name
+func TestSortProjectsConfigOrder(t *testing.T) {
+	order := func(n int) *int { return &n }
+	projects := []Project{
+		{Name: "zeta", Path: "/z"},
+		{Name: "web", Path: "/w", Order: order(2)},
+		{Name: "Beta", Path: "/b"},
+		{Name: "live"}, // unconfigured session
+		{Name: "api", Path: "/a", Order: order(1)},
+		{Name: "scratch", Path: "~", Scratch: true},
+		{Name: "cli", Path: "/c", Order: order(2)},
+	}
+	if got := projectNames(sortProjects(projects, SortManual)); got != "scratch,api,web,cli,Beta,zeta,live" {
+		t.Errorf("manual sort = %s", got)
+	}
+	if got := projectNames(sortProjects(projects, SortName)); got != "scratch,api,Beta,cli,live,web,zeta" {
+		t.Errorf("name sort = %s, want order keys ignored", got)
+	}
+}
+
+// TestConfigOrderBlocksMove tests that in-TUI reordering is refused while
+// order keys set the order
+func TestConfigOrderBlocksMove(t *testing.T) {
+	one := 1
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/a", Order: &one},
+		{Name: "web", Session: "web", Path: "/w"},
+	})
+	if cmd := m.moveSelected(1); cmd == nil || projectNames(m.projects) != "api,web" {
+		t.Errorf("projects = %s, want a warning and no move", projectNames(m.projects))
+	}
+}
+
+// TestSaveProjectOrder tests that reordering rewrites the config file order
+func TestSaveProjectOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	content := `# my config
+tmux:
+  config: ~/.tmux.conf
+projects:
+  - name: Alpha
+    path: /a
+    vars:
+      KEEP: me
+  - name: beta
+    session: b
+    path: /b
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Model{configPath: path, projects: []Project{
+		{Name: "beta", Session: "b", Path: "/b"},
+		{Name: "Alpha", Session: "alpha", Path: "/a"},
+	}}
+	if err := m.saveProjectOrder(); err != nil {
+		t.Fatalf("saveProjectOrder() error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Projects) != 2 || cfg.Projects[0].Session != "b" || cfg.Projects[1].Name != "Alpha" {
+		t.Errorf("saved projects = %+v", cfg.Projects)
+	}
+	if !strings.Contains(string(data), "KEEP: me") || !strings.Contains(string(data), "~/.tmux.conf") {
+		t.Errorf("saved config lost unrelated keys:\n%s", data)
+	}
+}
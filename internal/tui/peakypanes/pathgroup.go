@@ -0,0 +1,195 @@
+package peakypanes
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kregenrek/tmuxman/internal/state"
+)
+
+// minPathGroup is how many projects must share a parent directory before
+// they are put under a header.
+const minPathGroup = 2
+
+// pathHeader is the list row shown above the projects living in the same
+// directory when group_by_path is on. Folding it hides those projects.
+// In the tree view it is a directory node, shown as Label and indented by
+// Depth.
+type pathHeader struct {
+	Prefix    string
+	Projects  int
+	Collapsed bool
+	Label     string
+	Depth     int
+}
+
+func (h pathHeader) Title() string {
+	arrow := "▾"
+	if h.Collapsed {
+		arrow = "▸"
+	}
+	if h.Label != "" {
+		return indent(h.Depth) + arrow + " " + h.Label + "/"
+	}
+	return arrow + " " + shortenPath(h.Prefix) + "/*"
+}
+
+func (h pathHeader) Description() string {
+	if h.Projects == 1 {
+		return indent(h.Depth) + "1 project"
+	}
+	return indent(h.Depth) + fmt.Sprintf("%d projects", h.Projects)
+}
+
+// FilterValue is empty so headers drop out while filtering.
+func (h pathHeader) FilterValue() string { return "" }
+
+// isPathHeader reports whether item is a path group header.
+func isPathHeader(item list.Item) bool {
+	_, ok := item.(pathHeader)
+	return ok
+}
+
+// pathGroups maps each project path to the directory it is grouped under:
+// its parent, when at least minPathGroup paths share that parent. Paths
+// without a group are left out.
+func pathGroups(paths []string) map[string]string {
+	count := make(map[string]int)
+	for _, p := range paths {
+		if p != "" {
+			count[filepath.Dir(filepath.Clean(p))]++
+		}
+	}
+	groups := make(map[string]string)
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		if dir := filepath.Dir(filepath.Clean(p)); count[dir] >= minPathGroup {
+			groups[p] = dir
+		}
+	}
+	return groups
+}
+
+// groupByPath records each project's path group and moves the members of
+// a group next to the first one. The input slice is not modified.
+func groupByPath(projects []Project) []Project {
+	paths := make([]string, len(projects))
+	for i, p := range projects {
+		paths[i] = p.Path
+	}
+	groups := pathGroups(paths)
+	projects = append([]Project(nil), projects...)
+	for i := range projects {
+		projects[i].pathGroup = groups[projects[i].Path]
+	}
+	return clusterBy(projects, func(p Project) string { return p.pathGroup })
+}
+
+// pathGroupSize counts the leading projects of path group g.
+func pathGroupSize(projects []Project, g string) int {
+	n := 0
+	for n < len(projects) && projects[n].pathGroup == g {
+		n++
+	}
+	return n
+}
+
+// selectedPathGroup returns the path group of the selected header or
+// project.
+func (m Model) selectedPathGroup() string {
+	switch item := m.list.SelectedItem().(type) {
+	case pathHeader:
+		return item.Prefix
+	case Project:
+		return item.pathGroup
+	}
+	return ""
+}
+
+// selectPathHeader selects the header of path group g, if it is shown.
+func (m *Model) selectPathHeader(g string) {
+	for i, item := range m.list.Items() {
+		if h, ok := item.(pathHeader); ok && h.Prefix == g {
+			m.list.Select(i)
+			return
+		}
+	}
+}
+
+// toggleFold folds or unfolds the path group of the selected row and
+// remembers it in the state file.
+func (m Model) toggleFold() (tea.Model, tea.Cmd) {
+	g := m.selectedPathGroup()
+	if g == "" {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Not in a path group"))
+	}
+	m.setFolded([]string{g}, !m.collapsed[g])
+	m.list.SetItems(m.projectsToItems())
+	m.selectPathHeader(g)
+	return m, nil
+}
+
+// toggleFoldAll folds every path group, or unfolds them all when they are
+// already folded.
+func (m Model) toggleFoldAll() (tea.Model, tea.Cmd) {
+	var groups []string
+	fold := false
+	for _, item := range m.list.Items() {
+		if h, ok := item.(pathHeader); ok {
+			groups = append(groups, h.Prefix)
+			fold = fold || !h.Collapsed
+		}
+	}
+	if len(groups) == 0 {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("No path groups"))
+	}
+	g := m.selectedPathGroup()
+	m.setFolded(groups, fold)
+	m.list.SetItems(m.projectsToItems())
+	if fold {
+		m.selectPathHeader(g)
+	}
+	return m, nil
+}
+
+// setFolded marks groups folded or unfolded. The state file is updated on
+// a best-effort basis: folding still works for this run if it fails.
+func (m *Model) setFolded(groups []string, folded bool) {
+	if m.collapsed == nil {
+		m.collapsed = make(map[string]bool)
+	}
+	for _, g := range groups {
+		if folded {
+			m.collapsed[g] = true
+		} else {
+			delete(m.collapsed, g)
+		}
+	}
+	if m.statePath == "" {
+		return
+	}
+	_ = state.Update(m.statePath, func(s *state.State) {
+		for _, g := range groups {
+			s.SetCollapsed(g, folded)
+		}
+	})
+}
+
+// loadCollapsed reads the folded path groups from the state file.
+func (m *Model) loadCollapsed() {
+	m.collapsed = make(map[string]bool)
+	if m.statePath == "" {
+		return
+	}
+	st, err := state.Load(m.statePath)
+	if err != nil {
+		return
+	}
+	for _, g := range st.CollapsedGroups {
+		m.collapsed[g] = true
+	}
+}
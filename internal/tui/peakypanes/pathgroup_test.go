@@ -0,0 +1,127 @@
+package peakypanes
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+)
+
+// TestPathGroups tests grouping project paths by their parent directory
+func TestPathGroups(t *testing.T) {
+	got := pathGroups([]string{
+		"/home/me/work/api",
+		"/home/me/work/web/",
+		"/home/me/oss/peakypanes",
+		"/home/me/work/docs",
+		"/srv/app",
+		"",
+	})
+	want := map[string]string{
+		"/home/me/work/api":  "/home/me/work",
+		"/home/me/work/web/": "/home/me/work",
+		"/home/me/work/docs": "/home/me/work",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pathGroups() = %v, want %v", got, want)
+	}
+
+	if got := pathGroups([]string{"/a/x", "/b/y"}); len(got) != 0 {
+		t.Errorf("pathGroups() without shared parents = %v, want none", got)
+	}
+}
+
+// pathGroupRows renders the list rows of m as names and [prefix] headers.
+func pathGroupRows(m Model) string {
+	var rows []string
+	for _, item := range m.list.Items() {
+		switch it := item.(type) {
+		case pathHeader:
+			if it.Collapsed {
+				rows = append(rows, "+"+it.Prefix)
+			} else {
+				rows = append(rows, "-"+it.Prefix)
+			}
+		case Project:
+			rows = append(rows, it.Name)
+		}
+	}
+	return strings.Join(rows, ",")
+}
+
+func pathGroupModel(t *testing.T) Model {
+	t.Helper()
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/work/api"},
+		{Name: "dotfiles", Session: "dotfiles", Path: "/home/dotfiles"},
+		{Name: "web", Session: "web", Path: "/work/web"},
+	})
+	m.statePath = filepath.Join(t.TempDir(), "state.yml")
+	m.groupByPath = true
+	m.list.SetItems(m.projectsToItems())
+	return *m
+}
+
+// TestGroupByPathDisplay tests that grouped projects are listed together
+// under a header
+func TestGroupByPathDisplay(t *testing.T) {
+	m := pathGroupModel(t)
+	if got := pathGroupRows(m); got != "-/work,api,web,dotfiles" {
+		t.Errorf("rows = %s, want -/work,api,web,dotfiles", got)
+	}
+	header := m.list.Items()[0].(pathHeader)
+	if header.Title() != "▾ /work/*" || header.Description() != "2 projects" {
+		t.Errorf("header = %q / %q", header.Title(), header.Description())
+	}
+}
+
+// TestFoldPathGroup tests folding from a member row, persisting the fold
+// and unfolding from the header
+func TestFoldPathGroup(t *testing.T) {
+	m := pathGroupModel(t)
+	m.list.Select(2) // web
+
+	m = press(t, m, "z")
+	if got := pathGroupRows(m); got != "+/work,dotfiles" {
+		t.Errorf("rows after folding = %s, want +/work,dotfiles", got)
+	}
+	if !isPathHeader(m.list.SelectedItem()) {
+		t.Errorf("selected %#v, want the folded header", m.list.SelectedItem())
+	}
+	st, err := state.Load(m.statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(st.CollapsedGroups, []string{"/work"}) {
+		t.Errorf("saved CollapsedGroups = %v, want [/work]", st.CollapsedGroups)
+	}
+
+	reopened, _ := newTestModel(t, m.projects)
+	reopened.statePath = m.statePath
+	reopened.groupByPath = true
+	reopened.loadCollapsed()
+	reopened.list.SetItems(reopened.projectsToItems())
+	if got := pathGroupRows(*reopened); got != "+/work,dotfiles" {
+		t.Errorf("rows after reopening = %s, want the group still folded", got)
+	}
+
+	m = press(t, m, "enter")
+	if got := pathGroupRows(m); got != "-/work,api,web,dotfiles" {
+		t.Errorf("rows after unfolding = %s", got)
+	}
+}
+
+// TestFoldAllPathGroups tests folding and unfolding every group at once
+func TestFoldAllPathGroups(t *testing.T) {
+	m := pathGroupModel(t)
+	m = press(t, m, "Z")
+	if got := pathGroupRows(m); got != "+/work,dotfiles" {
+		t.Errorf("rows after folding all = %s", got)
+	}
+	m = press(t, m, "Z")
+	if got := pathGroupRows(m); got != "-/work,api,web,dotfiles" {
+		t.Errorf("rows after unfolding all = %s", got)
+	}
+}
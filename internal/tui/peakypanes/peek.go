@@ -0,0 +1,86 @@
+package peakypanes
+
+import (
+	"os/exec"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultPeekDuration is how long a peek lasts unless peek_seconds is set.
+const defaultPeekDuration = 10 * time.Second
+
+// peekScript waits for the client the peek attaches to session $1, then
+// detaches that client after $2 seconds, unless the session saw input in
+// that time: the user took over, so the attach is kept. Only the new client
+// is detached, so other terminals on the session, and the outer client
+// when peeking from a popup, stay attached.
+const peekScript = `session=$1
+clients() { tmux list-clients -t "$session" -F '#{client_name}'; }
+before=$(clients) || exit 0
+client= tries=0
+while [ -z "$client" ] && [ $tries -lt 25 ]; do
+  sleep 0.2
+  client=$(clients | grep -vxF "$before" | head -n 1)
+  tries=$((tries + 1))
+done
+[ -n "$client" ] || exit 0
+sleep "$2"
+last=$(tmux display-message -p -t "$session" '#{session_activity}') || exit 0
+[ $(( $(date +%s) - last )) -ge "$2" ] && tmux detach-client -t "$client"`
+
+// peekArgs returns the command that ends a peek at session after d.
+func peekArgs(session string, d time.Duration) []string {
+	secs := int(d / time.Second)
+	if secs < 1 {
+		secs = 1
+	}
+	return []string{"sh", "-c", peekScript, "peek", session, strconv.Itoa(secs)}
+}
+
+// startBackground runs argv without waiting for it.
+func startBackground(argv []string) error {
+	cmd := exec.Command(argv[0], argv[1:]...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// peekProject attaches to the selected running session and schedules a
+// detach after the peek duration, handing control back to the list unless
+// the user starts typing. Switching clients inside tmux cannot be undone
+// by a detach, so peeking needs to attach from outside tmux or in a popup.
+func (m Model) peekProject() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	if !item.Status.running() {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Only running sessions can be peeked at"))
+	}
+	if m.noTmux {
+		return m, m.mockAttach(item.Session)
+	}
+	args, _ := m.attachArgs(item.Session)
+	if args[0] == "switch-client" {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Peeking inside tmux needs open_mode: popup"))
+	}
+
+	d := m.peekDuration
+	if d <= 0 {
+		d = defaultPeekDuration
+	}
+	run := m.peekRunner
+	if run == nil {
+		run = startBackground
+	}
+	if err := run(peekArgs(item.Session, d)); err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(NewErrorMsg(err, "schedule peek")))
+	}
+	return m, tea.ExecProcess(exec.Command("tmux", args...), func(error) tea.Msg {
+		return attachDoneMsg{}
+	})
+}
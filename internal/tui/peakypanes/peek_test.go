@@ -0,0 +1,112 @@
+package peakypanes
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestPeekArgs tests the background detach command of a peek
+func TestPeekArgs(t *testing.T) {
+	got := peekArgs("api", 15*time.Second)
+	want := []string{"sh", "-c", peekScript, "peek", "api", "15"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("peekArgs() = %q, want %q", got, want)
+	}
+	if got := peekArgs("api", 200*time.Millisecond); got[len(got)-1] != "1" {
+		t.Errorf("peekArgs() waits %s seconds, want at least 1", got[len(got)-1])
+	}
+}
+
+// TestPeekSchedulesDetach tests that v schedules the timed detach before
+// attaching
+func TestPeekSchedulesDetach(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	m.peekDuration = 30 * time.Second
+	var scheduled [][]string
+	m.peekRunner = func(argv []string) error {
+		scheduled = append(scheduled, argv)
+		return nil
+	}
+
+	_, cmd := m.Update(keyMsg("v"))
+	if cmd == nil {
+		t.Fatal("v should return the attach command")
+	}
+	want := [][]string{peekArgs("api", 30*time.Second)}
+	if !reflect.DeepEqual(scheduled, want) {
+		t.Errorf("scheduled = %q, want %q", scheduled, want)
+	}
+}
+
+// TestPeekDefaultsAndRefusals tests the default duration and the cases
+// that do not peek
+func TestPeekDefaultsAndRefusals(t *testing.T) {
+	running := Project{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}
+	m, _ := newTestModel(t, []Project{running})
+	var scheduled [][]string
+	m.peekRunner = func(argv []string) error {
+		scheduled = append(scheduled, argv)
+		return nil
+	}
+	press(t, *m, "v")
+	if len(scheduled) != 1 || !reflect.DeepEqual(scheduled[0], peekArgs("api", defaultPeekDuration)) {
+		t.Errorf("scheduled = %q, want the default duration", scheduled)
+	}
+
+	scheduled = nil
+	m.insideTmux = true
+	press(t, *m, "v")
+	stopped, _ := newTestModel(t, []Project{{Name: "web", Session: "web", Path: "/srv/web"}})
+	stopped.peekRunner = m.peekRunner
+	press(t, *stopped, "v")
+	if len(scheduled) != 0 {
+		t.Errorf("scheduled = %q, want nothing inside tmux or for a stopped session", scheduled)
+	}
+
+	m.insideTmux = false
+	m.peekRunner = func([]string) error { return errors.New("no sh") }
+	if _, cmd := m.Update(keyMsg("v")); cmd == nil {
+		t.Error("a failed schedule should report an error")
+	}
+}
+
+// TestPeekScriptDetachesPeekClient tests that the scheduled command detaches
+// only the client the peek attached, with a fake tmux on PATH
+func TestPeekScriptDetachesPeekClient(t *testing.T) {
+	dir := t.TempDir()
+	fake := `#!/bin/sh
+case "$1" in
+list-clients)
+  echo /dev/pts/1
+  [ -f "$PEEK_DIR/attached" ] && echo /dev/pts/7
+  touch "$PEEK_DIR/attached" ;;
+display-message) echo 0 ;;
+detach-client) echo "$@" >> "$PEEK_DIR/log" ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "tmux"), []byte(fake), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	argv := peekArgs("api", time.Second)
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Env = append(os.Environ(), "PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"), "PEEK_DIR="+dir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("peek script: %v: %s", err, out)
+	}
+	log, err := os.ReadFile(filepath.Join(dir, "log"))
+	if err != nil {
+		t.Fatalf("no client was detached: %v", err)
+	}
+	if got, want := strings.TrimSpace(string(log)), "detach-client -t /dev/pts/7"; got != want {
+		t.Errorf("detached with %q, want %q", got, want)
+	}
+	if strings.Contains(peekScript, "detach-client -s") {
+		t.Error("peekScript detaches every client of the session")
+	}
+}
@@ -0,0 +1,70 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// Open modes for attaching to a running session from inside tmux.
+const (
+	// openModeSwitch moves the current client to the session.
+	openModeSwitch = "switch"
+	// openModePopup shows the session in a display-popup overlay.
+	openModePopup = "popup"
+)
+
+// attachArgs returns the tmux arguments used to open session, and a warning
+// when the configured popup mode had to fall back to switching clients.
+func (m Model) attachArgs(session string) ([]string, string) {
+	if !m.insideTmux {
+		return []string{"attach-session", "-t", session}, ""
+	}
+	if m.openMode == openModePopup {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		ok, err := m.tmux.SupportsPopup(ctx)
+		if ok {
+			size, _ := m.tmux.ClientSize(ctx) // unknown size falls back to percentages
+			return m.tmux.PopupArgs(session, size), ""
+		}
+		warning := fmt.Sprintf("Popup mode needs tmux %s or newer; switching instead", tmuxctl.PopupMinVersion)
+		if err != nil {
+			warning = fmt.Sprintf("Popup mode unavailable (%v); switching instead", err)
+		}
+		return []string{"switch-client", "-t", session}, warning
+	}
+	return []string{"switch-client", "-t", session}, ""
+}
+
+// attachProject opens a running project's session.
+func (m Model) attachProject(p Project) tea.Cmd {
+	session := p.Session
+	m.recordRecent(session)
+	if m.noTmux {
+		return m.mockAttach(session)
+	}
+	if m.openCommand != "" {
+		return m.openExternal(p)
+	}
+
+	args, warning := m.attachArgs(session)
+	if m.execMode() {
+		return execCmd(append([]string{"tmux"}, args...))
+	}
+	attach := tea.ExecProcess(
+		exec.Command("tmux", args...),
+		func(err error) tea.Msg {
+			return attachDoneMsg{}
+		},
+	)
+	if warning != "" {
+		return tea.Batch(NewWarningCmd(warning), attach)
+	}
+	return attach
+}
@@ -0,0 +1,59 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// versionClient returns a tmux client whose `tmux -V` reports version.
+func versionClient(t *testing.T, version string) *tmuxctl.Client {
+	t.Helper()
+	client, err := tmuxctl.NewClient("tmux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "printf", "%s", version)
+	})
+	return client
+}
+
+// TestAttachArgs tests open modes and the popup version gate
+func TestAttachArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		insideTmux  bool
+		mode        string
+		version     string
+		wantCmd     string
+		wantWarning bool
+	}{
+		{name: "outside tmux", mode: openModePopup, version: "tmux 3.4", wantCmd: "attach-session"},
+		{name: "switch", insideTmux: true, version: "tmux 3.4", wantCmd: "switch-client"},
+		{name: "popup", insideTmux: true, mode: openModePopup, version: "tmux 3.4", wantCmd: "display-popup"},
+		{name: "popup on old tmux", insideTmux: true, mode: openModePopup, version: "tmux 3.1c", wantCmd: "switch-client", wantWarning: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{tmux: versionClient(t, tt.version), insideTmux: tt.insideTmux, openMode: tt.mode}
+			args, warning := m.attachArgs("proj")
+			if args[0] != tt.wantCmd {
+				t.Errorf("attachArgs() = %v, want %s", args, tt.wantCmd)
+			}
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("attachArgs() warning = %q, want warning: %v", warning, tt.wantWarning)
+			}
+		})
+	}
+
+	m := Model{tmux: versionClient(t, "tmux 3.2"), insideTmux: true, openMode: openModePopup}
+	args, _ := m.attachArgs("proj")
+	if !reflect.DeepEqual(args, m.tmux.PopupArgs("proj", tmuxctl.Size{})) || !strings.Contains(args[len(args)-1], "attach-session -t '=proj'") {
+		t.Errorf("popup args = %q", args)
+	}
+}
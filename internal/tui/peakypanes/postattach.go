@@ -0,0 +1,36 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// postAttachTimeout bounds how long post_attach_local may run.
+const postAttachTimeout = 10 * time.Second
+
+// attachDoneMsg is sent when an attach started from the TUI returns.
+type attachDoneMsg struct{}
+
+// postAttachCmd runs the configured post_attach_local command. A failure is
+// reported as a warning; the attach itself already succeeded.
+func (m Model) postAttachCmd() tea.Cmd {
+	command := m.postAttachLocal
+	if command == "" {
+		return nil
+	}
+	run := m.localRunner
+	if run == nil {
+		run = shellRunner
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), postAttachTimeout)
+		defer cancel()
+		if err := run(ctx, command); err != nil {
+			return WarningMsg{Message: fmt.Sprintf("post_attach_local failed: %v", err)}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,68 @@
+package peakypanes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestPostAttachCmd tests that post_attach_local runs through the runner
+// and that failures only warn
+func TestPostAttachCmd(t *testing.T) {
+	var ran []string
+	ok := func(ctx context.Context, command string) error {
+		ran = append(ran, command)
+		return nil
+	}
+	failing := func(ctx context.Context, command string) error {
+		ran = append(ran, command)
+		return errors.New("exit status 1")
+	}
+
+	m := Model{}
+	if cmd := m.postAttachCmd(); cmd != nil {
+		t.Fatal("postAttachCmd() without a command should be nil")
+	}
+
+	m.postAttachLocal = "afplay done.aiff"
+	m.localRunner = ok
+	if msg := m.postAttachCmd()(); msg != nil {
+		t.Errorf("successful run returned %#v, want nil", msg)
+	}
+
+	m.localRunner = failing
+	msg := m.postAttachCmd()()
+	warning, isWarning := msg.(WarningMsg)
+	if !isWarning {
+		t.Fatalf("failed run returned %#v, want WarningMsg", msg)
+	}
+	if !strings.Contains(warning.Message, "exit status 1") {
+		t.Errorf("warning = %q, want the exit error", warning.Message)
+	}
+
+	if len(ran) != 2 || ran[0] != "afplay done.aiff" || ran[1] != "afplay done.aiff" {
+		t.Errorf("runner calls = %q", ran)
+	}
+}
+
+// TestAttachDoneRunsPostAttach tests that returning from an attach runs the
+// local command
+func TestAttachDoneRunsPostAttach(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	called := false
+	m.postAttachLocal = "echo back"
+	m.localRunner = func(ctx context.Context, command string) error {
+		called = true
+		return nil
+	}
+
+	_, cmd := m.Update(attachDoneMsg{})
+	if cmd == nil {
+		t.Fatal("attachDoneMsg should return the post-attach command")
+	}
+	cmd()
+	if !called {
+		t.Error("post_attach_local was not run")
+	}
+}
@@ -0,0 +1,54 @@
+package peakypanes
+
+import "github.com/kregenrek/tmuxman/internal/state"
+
+// parseSortMode returns the sort mode named name, as printed by
+// SortMode.String. Unknown names give the default.
+func parseSortMode(name string) SortMode {
+	if name == SortName.String() {
+		return SortName
+	}
+	return SortManual
+}
+
+// preferences returns the list view settings to remember.
+func (m Model) preferences() state.Preferences {
+	var prefs state.Preferences
+	if m.sortMode != SortManual {
+		prefs.Sort = m.sortMode.String()
+	}
+	prefs.TreeView = m.treeView
+	prefs.ShowArchived = m.showArchived
+	prefs.HideStopped = m.hideStopped
+	return prefs
+}
+
+// applyPreferences restores remembered list view settings.
+func (m *Model) applyPreferences(prefs state.Preferences) {
+	m.sortMode = parseSortMode(prefs.Sort)
+	m.treeView = prefs.TreeView
+	m.showArchived = prefs.ShowArchived
+	m.hideStopped = prefs.HideStopped
+}
+
+// loadPreferences restores the list view settings from the state file.
+func (m *Model) loadPreferences() {
+	if m.statePath == "" {
+		return
+	}
+	st, err := state.Load(m.statePath)
+	if err != nil {
+		return
+	}
+	m.applyPreferences(st.Preferences)
+}
+
+// savePreferences remembers the list view settings in the state file. It
+// is best effort: the settings still apply for this run if it fails.
+func (m *Model) savePreferences() {
+	if m.statePath == "" {
+		return
+	}
+	prefs := m.preferences()
+	_ = state.Update(m.statePath, func(s *state.State) { s.Preferences = prefs })
+}
@@ -0,0 +1,58 @@
+package peakypanes
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+)
+
+// TestParseSortMode tests that sort mode names round-trip
+func TestParseSortMode(t *testing.T) {
+	for _, mode := range []SortMode{SortManual, SortName} {
+		if got := parseSortMode(mode.String()); got != mode {
+			t.Errorf("parseSortMode(%q) = %v, want %v", mode.String(), got, mode)
+		}
+	}
+	if got := parseSortMode("bogus"); got != SortManual {
+		t.Errorf("parseSortMode(bogus) = %v, want manual", got)
+	}
+}
+
+// TestPreferencesRestored tests that the sort, tree, archived and
+// hide-stopped toggles
+// are saved and applied to a fresh model
+func TestPreferencesRestored(t *testing.T) {
+	projects := []Project{
+		{Name: "web", Session: "web", Path: "/srv/web"},
+		{Name: "api", Session: "api", Path: "/srv/api"},
+	}
+	m, _ := newTestModel(t, projects)
+	m.statePath = filepath.Join(t.TempDir(), "state.yml")
+	model := press(t, *m, "s", "T", "H", "S")
+
+	st, err := state.Load(m.statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := state.Preferences{Sort: "name", TreeView: true, ShowArchived: true, HideStopped: true}
+	if st.Preferences != want {
+		t.Errorf("saved preferences = %+v, want %+v", st.Preferences, want)
+	}
+	if model.preferences() != want {
+		t.Errorf("preferences() = %+v, want %+v", model.preferences(), want)
+	}
+
+	fresh, _ := newTestModel(t, projects)
+	fresh.statePath = m.statePath
+	fresh.loadPreferences()
+	if fresh.sortMode != SortName || !fresh.treeView || !fresh.showArchived || !fresh.hideStopped {
+		t.Errorf("restored sort %v, tree %v, archived %v, hide stopped %v", fresh.sortMode, fresh.treeView, fresh.showArchived, fresh.hideStopped)
+	}
+
+	model = press(t, model, "s", "T", "H", "S")
+	st, _ = state.Load(m.statePath)
+	if st.Preferences != (state.Preferences{}) {
+		t.Errorf("preferences after resetting = %+v, want defaults", st.Preferences)
+	}
+}
@@ -0,0 +1,162 @@
+package peakypanes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// previewDelay is how long the selection has to rest on a project before its
+// pane is captured, so scrolling through the list does not hammer tmux.
+const previewDelay = 150 * time.Millisecond
+
+// previewMinWidth is the narrowest terminal that still gets a side panel.
+const previewMinWidth = 80
+
+// previewTickMsg fires previewDelay after the selection moved. seq identifies
+// the selection change it was scheduled for; older ticks are dropped.
+type previewTickMsg struct {
+	seq     int
+	session string
+}
+
+// previewMsg carries the captured pane content for session.
+type previewMsg struct {
+	session string
+	content string
+	err     error
+}
+
+// previewWidth returns the columns given to the preview or README panel
+// out of total, or zero when both are off or the terminal is too narrow.
+func (m Model) previewWidth(total int) int {
+	if (!m.preview && !m.readme) || total < previewMinWidth {
+		return 0
+	}
+	return total * 2 / 5
+}
+
+// selectedSession returns the session of the highlighted project if it is
+// running.
+func (m Model) selectedSession() string {
+	if p, ok := m.list.SelectedItem().(Project); ok && p.Status.running() {
+		return p.Session
+	}
+	return ""
+}
+
+// schedulePreview starts the debounce timer for the current selection.
+func (m *Model) schedulePreview() tea.Cmd {
+	m.previewSeq++
+	m.previewSession, m.previewContent = "", ""
+	session := m.selectedSession()
+	if !m.preview || session == "" {
+		return nil
+	}
+	seq := m.previewSeq
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg {
+		return previewTickMsg{seq: seq, session: session}
+	})
+}
+
+// previewSelectionChanged schedules a preview when the selection moved away
+// from before.
+func (m *Model) previewSelectionChanged(before string) tea.Cmd {
+	if !m.preview || m.selectedSession() == before {
+		return nil
+	}
+	return m.schedulePreview()
+}
+
+// handlePreviewTick captures the selected pane once the selection has
+// settled. Ticks superseded by a later selection change are ignored.
+func (m Model) handlePreviewTick(msg previewTickMsg) (tea.Model, tea.Cmd) {
+	if !m.preview || msg.seq != m.previewSeq || msg.session != m.selectedSession() {
+		return m, nil
+	}
+	client := m.tmux
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		content, err := client.CapturePane(ctx, tmuxctl.ActivePaneTarget(msg.session))
+		return previewMsg{session: msg.session, content: content, err: err}
+	}
+}
+
+// handlePreview stores captured content if it is still for the selection.
+func (m Model) handlePreview(msg previewMsg) (tea.Model, tea.Cmd) {
+	if !m.preview || msg.session != m.selectedSession() {
+		return m, nil
+	}
+	m.previewSession = msg.session
+	m.previewContent = msg.content
+	if msg.err != nil {
+		m.previewContent = msg.err.Error()
+	}
+	return m, nil
+}
+
+// togglePreview turns the preview panel on or off.
+func (m Model) togglePreview() (tea.Model, tea.Cmd) {
+	m.preview = !m.preview
+	if m.preview {
+		m.readme = false // the panels share the space
+	}
+	m.resize()
+	if !m.preview {
+		m.previewSeq++ // drop pending ticks
+		m.previewSession, m.previewContent = "", ""
+		return m, m.list.NewStatusMessage(FormatStatusInfo("Preview off"))
+	}
+	return m, tea.Batch(m.schedulePreview(), m.list.NewStatusMessage(FormatStatusInfo("Preview on")))
+}
+
+// viewPreview renders the captured pane in a panel of the given size,
+// keeping the bottom of the output where the prompt usually is.
+func (m Model) viewPreview(width, height int) string {
+	style := theme.PreviewPanel
+	innerW := width - style.GetHorizontalFrameSize()
+	innerH := height - style.GetVerticalFrameSize()
+	if innerW <= 0 || innerH <= 0 {
+		return ""
+	}
+
+	var body string
+	switch {
+	case m.selectedSession() == "":
+		body = theme.DialogNote.Render("Select a running session to preview it")
+	case m.previewSession == "":
+		body = theme.DialogNote.Render("Loading preview…")
+	default:
+		lines := strings.Split(m.previewContent, "\n")
+		if len(lines) > innerH {
+			lines = lines[len(lines)-innerH:]
+		}
+		for i, line := range lines {
+			lines[i] = runewidth.Truncate(line, innerW, "")
+		}
+		body = strings.Join(lines, "\n")
+	}
+	return style.Width(innerW).Height(innerH).Render(body)
+}
+
+// joinPreview places the preview or README panel to the right of the list
+// view.
+func (m Model) joinPreview(listView string) string {
+	width := m.previewWidth(m.width - appStyle.GetHorizontalFrameSize())
+	if width == 0 {
+		return listView
+	}
+	panel := m.viewPreview(width, lipgloss.Height(listView))
+	if m.readme {
+		panel = m.viewReadme(width, lipgloss.Height(listView))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, listView, panel)
+}
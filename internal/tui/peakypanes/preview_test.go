@@ -0,0 +1,111 @@
+package peakypanes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func previewProjects() []Project {
+	return []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning},
+		{Name: "web", Session: "web", Path: "/srv/web", Status: StatusRunning},
+		{Name: "docs", Session: "docs", Path: "/srv/docs", Status: StatusStopped},
+	}
+}
+
+// TestPreviewSelectionSchedulesCapture tests that moving the selection schedules a capture
+func TestPreviewSelectionSchedulesCapture(t *testing.T) {
+	m, calls := newTestModel(t, previewProjects())
+
+	next, cmd := m.Update(keyMsg("down"))
+	if cmd != nil {
+		t.Error("selection changes must not schedule a preview while it is off")
+	}
+
+	model := next.(Model)
+	model.list.Select(0)
+	model = press(t, model, "p")
+	if !model.preview {
+		t.Fatal("p should turn the preview on")
+	}
+	seq := model.previewSeq
+
+	next, cmd = model.Update(keyMsg("down"))
+	model = next.(Model)
+	if cmd == nil || model.previewSeq != seq+1 {
+		t.Fatalf("moving the selection should schedule a preview (seq %d → %d)", seq, model.previewSeq)
+	}
+
+	// Once the debounce tick for the current selection fires, the pane is captured
+	next, cmd = model.Update(previewTickMsg{seq: model.previewSeq, session: "web"})
+	if cmd == nil {
+		t.Fatal("a current tick should return a capture command")
+	}
+	next, _ = next.(Model).Update(cmd())
+	want := [][]string{{"capture-pane", "-p", "-t", "web:"}}
+	if !reflect.DeepEqual(calls.args, want) {
+		t.Errorf("tmux calls = %q, want %q", calls.args, want)
+	}
+	if next.(Model).previewSession != "web" {
+		t.Errorf("previewSession = %q, want web", next.(Model).previewSession)
+	}
+}
+
+// TestPreviewDebounce tests that only the tick for the latest selection captures
+func TestPreviewDebounce(t *testing.T) {
+	m, calls := newTestModel(t, previewProjects())
+	m.preview = true
+
+	m.schedulePreview()
+	stale := previewTickMsg{seq: m.previewSeq, session: "api"}
+	m.list.Select(1)
+	m.schedulePreview()
+	current := previewTickMsg{seq: m.previewSeq, session: "web"}
+
+	if _, cmd := m.Update(stale); cmd != nil {
+		t.Error("a superseded tick must not capture")
+	}
+	if _, cmd := m.Update(current); cmd == nil {
+		t.Error("the latest tick should capture")
+	}
+	if len(calls.args) != 0 {
+		t.Errorf("ticks should only return commands, got tmux calls %q", calls.args)
+	}
+
+	m.list.Select(2) // stopped project
+	if cmd := m.schedulePreview(); cmd != nil {
+		t.Error("stopped projects should not be previewed")
+	}
+
+	m.list.Select(1)
+	m.preview = false
+	if _, cmd := m.Update(previewTickMsg{seq: m.previewSeq, session: "web"}); cmd != nil {
+		t.Error("ticks must be ignored once the preview is off")
+	}
+}
+
+// TestPreviewStaleCapture tests that output for a previous selection is dropped
+func TestPreviewStaleCapture(t *testing.T) {
+	m, _ := newTestModel(t, previewProjects())
+	m.preview = true
+	m.list.Select(1)
+
+	next, _ := m.Update(previewMsg{session: "api", content: "old"})
+	if next.(Model).previewContent != "" {
+		t.Error("capture for another session should be dropped")
+	}
+	next, _ = m.Update(previewMsg{session: "web", content: "line 1\nready"})
+	model := next.(Model)
+	model, _ = updateSize(model, 120, 30)
+	if view := model.View(); !strings.Contains(view, "ready") {
+		t.Errorf("view should show the preview:\n%s", view)
+	}
+}
+
+func updateSize(m Model, width, height int) (Model, tea.Cmd) {
+	next, cmd := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return next.(Model), cmd
+}
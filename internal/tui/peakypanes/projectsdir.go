@@ -0,0 +1,88 @@
+package peakypanes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadProjectDir reads every *.yaml / *.yml file in dir as a single project
+// definition, in file name order. A file that cannot be read or parsed is
+// reported in the returned errors and skipped; the others still load. A
+// missing directory is not an error.
+func loadProjectDir(dir string) ([]projectConfig, []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []error{fmt.Errorf("read projects dir %q: %w", dir, err)}
+	}
+
+	var names []string
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	var projects []projectConfig
+	var errs []error
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		var pc projectConfig
+		if err := yaml.Unmarshal(data, &pc); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		if pc.Name == "" && pc.Session == "" {
+			errs = append(errs, fmt.Errorf("%s: project needs a name or session", name))
+			continue
+		}
+		projects = append(projects, pc)
+	}
+	return projects, errs
+}
+
+// projectKey is the name a project is matched by when merging.
+func projectKey(pc projectConfig) string {
+	if pc.Name != "" {
+		return pc.Name
+	}
+	return pc.Session
+}
+
+// mergeProjectConfigs appends extra to base. A project whose name is already
+// present is skipped, or replaces the earlier entry in place when override
+// is set.
+func mergeProjectConfigs(base, extra []projectConfig, override bool) []projectConfig {
+	merged := append([]projectConfig(nil), base...)
+	index := make(map[string]int, len(merged))
+	for i, pc := range merged {
+		index[projectKey(pc)] = i
+	}
+	for _, pc := range extra {
+		key := projectKey(pc)
+		if i, ok := index[key]; ok {
+			if override {
+				merged[i] = pc
+			}
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, pc)
+	}
+	return merged
+}
@@ -0,0 +1,90 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeProjectFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// TestLoadProjectDir tests merging multiple files and per-file error reporting
+func TestLoadProjectDir(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"10-api.yaml":  "name: api\npath: /srv/api\n",
+		"20-web.yml":   "name: web\npath: /srv/web\nlayout: dev-3\n",
+		"30-bad.yaml":  "name: [unterminated\n",
+		"40-anon.yaml": "path: /srv/anon\n",
+		"notes.txt":    "name: ignored\n",
+	})
+
+	projects, errs := loadProjectDir(dir)
+	var names []string
+	for _, pc := range projects {
+		names = append(names, pc.Name)
+	}
+	if strings.Join(names, ",") != "api,web" {
+		t.Errorf("loaded projects = %v, want api,web", names)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("errors = %v, want 2", errs)
+	}
+	if !strings.HasPrefix(errs[0].Error(), "30-bad.yaml:") || !strings.HasPrefix(errs[1].Error(), "40-anon.yaml:") {
+		t.Errorf("errors should name their file: %v", errs)
+	}
+
+	if projects, errs := loadProjectDir(filepath.Join(dir, "missing")); projects != nil || errs != nil {
+		t.Errorf("missing dir = %v, %v; want nil, nil", projects, errs)
+	}
+}
+
+// TestMergeProjectConfigs tests name collisions with and without override
+func TestMergeProjectConfigs(t *testing.T) {
+	base := []projectConfig{{Name: "api", Path: "/base/api"}, {Name: "cli", Path: "/base/cli"}}
+	extra := []projectConfig{{Name: "api", Path: "/dir/api"}, {Name: "web", Path: "/dir/web"}, {Name: "web", Path: "/dir/web2"}}
+
+	merged := mergeProjectConfigs(base, extra, false)
+	if len(merged) != 3 || merged[0].Path != "/base/api" || merged[2].Path != "/dir/web" {
+		t.Errorf("merge without override = %+v", merged)
+	}
+
+	merged = mergeProjectConfigs(base, extra, true)
+	if len(merged) != 3 || merged[0].Path != "/dir/api" || merged[2].Path != "/dir/web2" {
+		t.Errorf("merge with override = %+v", merged)
+	}
+	if base[0].Path != "/base/api" {
+		t.Error("mergeProjectConfigs must not modify base")
+	}
+}
+
+// TestLoadConfigProjectsDir tests that loadConfig merges projects_dir and keeps warnings
+func TestLoadConfigProjectsDir(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"api.yaml": "name: api\npath: /srv/api\n",
+		"bad.yaml": ": :\n\t",
+	})
+	m, _ := newTestModel(t, nil)
+	cfg := "projects:\n  - name: cli\n    path: /srv/cli\nprojects_dir: " + dir + "\n"
+	if err := os.WriteFile(m.configPath, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.loadConfig(); err != nil {
+		t.Fatalf("loadConfig() error: %v", err)
+	}
+	if projectNames(m.projects) != "cli,api" {
+		t.Errorf("projects = %s, want cli,api", projectNames(m.projects))
+	}
+	if w := m.configWarning(); !strings.Contains(w, "bad.yaml") {
+		t.Errorf("configWarning() = %q, want it to mention bad.yaml", w)
+	}
+}
@@ -0,0 +1,41 @@
+package peakypanes
+
+import (
+	"fmt"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+)
+
+// knownSessions returns the sessions of all listed projects, configured or
+// running.
+func knownSessions(projects []Project) map[string]bool {
+	sessions := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		if p.Session != "" {
+			sessions[p.Session] = true
+		}
+	}
+	return sessions
+}
+
+// PruneState removes state file entries for sessions that are neither
+// configured nor running, returning how many were removed.
+func (m *Model) PruneState() (int, error) {
+	if m.statePath == "" {
+		return 0, nil
+	}
+	live := knownSessions(m.projects)
+	removed := 0
+	err := state.Update(m.statePath, func(s *state.State) {
+		removed = s.Prune(func(session string) bool { return live[session] })
+	})
+	return removed, err
+}
+
+// pruneMessage formats the result of PruneState for display.
+func pruneMessage(removed int) string {
+	if removed == 1 {
+		return "Removed 1 stale state entry"
+	}
+	return fmt.Sprintf("Removed %d stale state entries", removed)
+}
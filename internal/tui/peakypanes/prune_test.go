@@ -0,0 +1,50 @@
+package peakypanes
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+)
+
+// TestPruneState tests that entries for unknown sessions are removed from the state file
+func TestPruneState(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api"},
+		{Name: "live", Session: "live", Status: StatusRunning},
+	})
+	m.statePath = filepath.Join(t.TempDir(), "state.yml")
+	st := &state.State{
+		Layouts: map[string][]state.WindowLayout{
+			"api":     {{Name: "main", Layout: "abcd"}},
+			"deleted": {{Name: "main", Layout: "ef01"}},
+		},
+		Recent: []string{"live", "deleted", "api", "old"},
+	}
+	if err := st.Save(m.statePath); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := m.PruneState()
+	if err != nil {
+		t.Fatalf("PruneState() error: %v", err)
+	}
+	if removed != 3 {
+		t.Errorf("PruneState() removed %d, want 3", removed)
+	}
+
+	got, err := state.Load(m.statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Layouts) != 1 || got.Layouts["api"] == nil {
+		t.Errorf("layouts after prune = %v", got.Layouts)
+	}
+	if !reflect.DeepEqual(got.Recent, []string{"live", "api"}) {
+		t.Errorf("recent after prune = %v", got.Recent)
+	}
+	if msg := pruneMessage(removed); msg != "Removed 3 stale state entries" {
+		t.Errorf("pruneMessage() = %q", msg)
+	}
+}
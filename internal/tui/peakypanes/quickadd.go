@@ -0,0 +1,207 @@
+package peakypanes
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// quickAdd is the multi-select of unregistered repos found under the
+// projects root.
+type quickAdd struct {
+	root     string
+	repos    []GitProject
+	selected []bool
+	cursor   int
+}
+
+// toggle flips the selection of the repo under the cursor.
+func (q *quickAdd) toggle() {
+	if len(q.repos) > 0 {
+		q.selected[q.cursor] = !q.selected[q.cursor]
+	}
+}
+
+// toggleAll selects every repo, or clears the selection when all are
+// already selected.
+func (q *quickAdd) toggleAll() {
+	all := true
+	for _, s := range q.selected {
+		all = all && s
+	}
+	for i := range q.selected {
+		q.selected[i] = !all
+	}
+}
+
+// move moves the cursor by delta, clamped to the list.
+func (q *quickAdd) move(delta int) {
+	q.cursor += delta
+	if q.cursor >= len(q.repos) {
+		q.cursor = len(q.repos) - 1
+	}
+	if q.cursor < 0 {
+		q.cursor = 0
+	}
+}
+
+// quickAddProjects converts the selected repos into projects named after
+// their directory. Session names get prefix and are made unique against
+// existing projects and each other.
+func quickAddProjects(repos []GitProject, selected []bool, existing []Project, prefix string) []Project {
+	var taken []string
+	for _, p := range existing {
+		taken = append(taken, p.Session)
+	}
+	var added []Project
+	for i, repo := range repos {
+		if i >= len(selected) || !selected[i] {
+			continue
+		}
+		name := filepath.Base(repo.Path)
+		session := uniqueSessionName(prefixSession(prefix, sanitizeSessionName(name)), taken)
+		taken = append(taken, session)
+		added = append(added, Project{
+			Name:    name,
+			Session: session,
+			Path:    repo.Path,
+			Status:  StatusStopped,
+		})
+	}
+	return added
+}
+
+// startQuickAdd scans the projects root and offers the repos that are not
+// projects yet.
+func (m Model) startQuickAdd() (tea.Model, tea.Cmd) {
+	root := m.projectsRootDir()
+	repos := visibleGitProjects(ScanGitProjects(root), m.projects, false)
+	if len(repos) == 0 {
+		return m, m.list.NewStatusMessage(FormatStatusInfo("No new git repos in " + shortenPath(root)))
+	}
+	m.quickAdd = &quickAdd{root: root, repos: repos, selected: make([]bool, len(repos))}
+	m.state = StateQuickAdd
+	return m, nil
+}
+
+func (m Model) updateQuickAdd(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	q := m.quickAdd
+	if q == nil {
+		m.state = StateHome
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc", "q", "ctrl+c":
+		m.quickAdd = nil
+		m.state = StateHome
+		return m, nil
+	case "up", "k":
+		q.move(-1)
+	case "down", "j":
+		q.move(1)
+	case " ", "x":
+		q.toggle()
+	case "a":
+		q.toggleAll()
+	case "enter":
+		added := quickAddProjects(q.repos, q.selected, m.projects, m.sessionPrefix)
+		if len(added) == 0 {
+			return m, nil
+		}
+		m.quickAdd = nil
+		m.state = StateHome
+		return m.addProjects(added)
+	}
+	return m, nil
+}
+
+// addProjects appends projects to the configured ones and persists them.
+func (m Model) addProjects(added []Project) (tea.Model, tea.Cmd) {
+	n := configuredCount(m.projects)
+	projects := append([]Project{}, m.projects[:n]...)
+	projects = append(projects, added...)
+	m.projects = append(projects, m.projects[n:]...)
+	_ = m.refreshStatuses()
+	m.list.SetItems(m.projectsToItems())
+
+	write := func(m *Model) error { return m.saveNewProjects(added) }
+	if cmd := m.saveConfig(write); cmd != nil || m.confirmSave {
+		return m, cmd
+	}
+	return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Added %d project(s)", len(added))))
+}
+
+// saveNewProjects appends entries for projects to the config file.
+func (m *Model) saveNewProjects(projects []Project) error {
+	return m.updateConfigFile(func(doc *yaml.Node) error {
+		seq := projectsNode(doc, true)
+		for _, p := range projects {
+			var n yaml.Node
+			if err := n.Encode(projectEntry(p, m.sessionPrefix)); err != nil {
+				return fmt.Errorf("encode project %q: %w", p.Name, err)
+			}
+			seq.Content = append(seq.Content, &n)
+		}
+		return nil
+	})
+}
+
+// projectEntry is the config entry for a newly added project. The session
+// is only written when it differs from the one derived from the name.
+func projectEntry(p Project, prefix string) projectEntryConfig {
+	e := projectEntryConfig{Name: p.Name, Path: shortenPath(p.Path), Layout: p.Layout}
+	if p.Session != prefixSession(prefix, sanitizeSessionName(p.Name)) {
+		e.Session = p.Session
+	}
+	return e
+}
+
+// projectEntryConfig mirrors projectConfig but omits empty keys.
+type projectEntryConfig struct {
+	Name    string `yaml:"name"`
+	Session string `yaml:"session,omitempty"`
+	Path    string `yaml:"path"`
+	Layout  string `yaml:"layout,omitempty"`
+}
+
+func (m Model) viewQuickAdd() string {
+	q := m.quickAdd
+	if q == nil {
+		return m.viewHome()
+	}
+
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("➕ Add Projects"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogLabel.Render("Repos in " + shortenPath(q.root)))
+	b.WriteString("\n")
+	for i, repo := range q.repos {
+		mark := "[ ]"
+		if q.selected[i] {
+			mark = "[x]"
+		}
+		line := mark + " " + repo.Name
+		if i == q.cursor {
+			b.WriteString(theme.DialogChoiceKey.Render("› " + line))
+		} else {
+			b.WriteString(theme.DialogValue.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(theme.DialogChoiceKey.Render("space"))
+	b.WriteString(theme.DialogChoiceSep.Render(" toggle • "))
+	b.WriteString(theme.DialogChoiceKey.Render("a"))
+	b.WriteString(theme.DialogChoiceSep.Render(" all • "))
+	b.WriteString(theme.DialogChoiceKey.Render("enter"))
+	b.WriteString(theme.DialogChoiceSep.Render(" add • "))
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" cancel"))
+
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
@@ -0,0 +1,91 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestQuickAddProjects tests converting selected repos into projects
+func TestQuickAddProjects(t *testing.T) {
+	repos := []GitProject{
+		{Name: "work/api", Path: "/code/work/api"},
+		{Name: "oss/api", Path: "/code/oss/api"},
+		{Name: "My Site", Path: "/code/My Site"},
+		{Name: "skip", Path: "/code/skip"},
+	}
+	selected := []bool{true, true, true, false}
+	existing := []Project{{Name: "API", Session: "api", Path: "/srv/api"}}
+
+	got := quickAddProjects(repos, selected, existing, "")
+	want := []Project{
+		{Name: "api", Session: "api-2", Path: "/code/work/api"},
+		{Name: "api", Session: "api-3", Path: "/code/oss/api"},
+		{Name: "My Site", Session: "my-site", Path: "/code/My Site"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("quickAddProjects() = %+v, want %d projects", got, len(want))
+	}
+	for i, p := range got {
+		if p.Name != want[i].Name || p.Session != want[i].Session || p.Path != want[i].Path || p.Status != StatusStopped {
+			t.Errorf("project %d = %+v, want %+v", i, p, want[i])
+		}
+	}
+
+	if got := quickAddProjects(repos, []bool{false}, existing, ""); len(got) != 0 {
+		t.Errorf("nothing selected: got %+v", got)
+	}
+}
+
+// TestProjectEntry tests that derivable sessions are not written to the config
+func TestProjectEntry(t *testing.T) {
+	if e := projectEntry(Project{Name: "My Site", Session: "my-site", Path: "/code/site"}, ""); e.Session != "" {
+		t.Errorf("derived session written: %+v", e)
+	}
+	if e := projectEntry(Project{Name: "api", Session: "api-2", Path: "/code/api"}, ""); e.Session != "api-2" {
+		t.Errorf("unique session dropped: %+v", e)
+	}
+}
+
+// TestQuickAddFlow tests scanning the root, selecting repos and saving them
+func TestQuickAddFlow(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"api", "cli", "web"} {
+		if err := os.MkdirAll(filepath.Join(root, name, ".git"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: filepath.Join(root, "api")}})
+	m.projectsRoot = root
+
+	model := press(t, *m, "a")
+	if model.state != StateQuickAdd || model.quickAdd == nil {
+		t.Fatalf("state = %v, want StateQuickAdd", model.state)
+	}
+	if n := len(model.quickAdd.repos); n != 2 {
+		t.Fatalf("offered %d repos, want the 2 unregistered ones", n)
+	}
+
+	// select web (second entry) only
+	model = press(t, model, "j", " ", "enter")
+	if model.state != StateHome {
+		t.Errorf("state = %v, want StateHome", model.state)
+	}
+	if len(model.projects) != 2 || model.projects[1].Name != "web" {
+		t.Fatalf("projects = %+v", model.projects)
+	}
+
+	data, err := os.ReadFile(model.configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Projects) != 1 || cfg.Projects[0].Name != "web" || cfg.Projects[0].Path != filepath.Join(root, "web") {
+		t.Errorf("saved projects = %+v", cfg.Projects)
+	}
+}
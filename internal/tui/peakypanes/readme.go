@@ -0,0 +1,223 @@
+package peakypanes
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// defaultReadmeLines is how much of a README the panel reads unless
+// readme_lines says otherwise.
+const defaultReadmeLines = 20
+
+// readmeNames are the file names taken for a README, matched without
+// regard to case, most preferred first.
+var readmeNames = []string{"readme.md", "readme.markdown", "readme.txt", "readme"}
+
+// errNoReadme is reported for directories without a README.
+var errNoReadme = errors.New("no README")
+
+// readmeTickMsg fires previewDelay after the selected path changed. seq
+// identifies the change it was scheduled for; older ticks are dropped.
+type readmeTickMsg struct {
+	seq int
+	dir string
+}
+
+// readmeMsg carries the first lines of the README in dir.
+type readmeMsg struct {
+	dir     string
+	content string
+	err     error
+}
+
+// findReadme returns the README file in dir. File names are compared
+// without regard to case, so README.md, readme.md and Readme.MD all match.
+func findReadme(dir string) (string, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", false
+	}
+	for _, want := range readmeNames {
+		for _, e := range entries {
+			if !e.IsDir() && strings.EqualFold(e.Name(), want) {
+				return filepath.Join(dir, e.Name()), true
+			}
+		}
+	}
+	return "", false
+}
+
+// headLines returns up to n lines from r, without line endings. Only as
+// much of r is read as those lines need.
+func headLines(r io.Reader, n int) ([]string, error) {
+	br := bufio.NewReader(r)
+	var lines []string
+	for len(lines) < n {
+		line, err := br.ReadString('\n')
+		if line != "" || err == nil {
+			lines = append(lines, strings.TrimRight(line, "\r\n"))
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return lines, err
+		}
+	}
+	return lines, nil
+}
+
+// readReadme reads the first n lines of the README in dir.
+func readReadme(dir string, n int) (string, error) {
+	path, ok := findReadme(dir)
+	if !ok {
+		return "", errNoReadme
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	lines, err := headLines(f, n)
+	return strings.Join(lines, "\n"), err
+}
+
+// readmeDir returns the directory of the highlighted project or repo.
+func (m Model) readmeDir() string {
+	switch m.state {
+	case StateProjectPicker:
+		if g, ok := m.projectPicker.SelectedItem().(GitProject); ok {
+			return g.Path
+		}
+	case StateHome:
+		if p, ok := m.list.SelectedItem().(Project); ok {
+			return p.Path
+		}
+	}
+	return ""
+}
+
+// followReadme schedules a README read when the panel is on and the
+// highlighted directory changed since the last one.
+func (m *Model) followReadme() tea.Cmd {
+	if !m.readme {
+		return nil
+	}
+	dir := m.readmeDir()
+	if dir == m.readmeWant {
+		return nil
+	}
+	m.readmeSeq++
+	m.readmeWant, m.readmeLoaded, m.readmeContent = dir, "", ""
+	if dir == "" {
+		return nil
+	}
+	seq := m.readmeSeq
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg {
+		return readmeTickMsg{seq: seq, dir: dir}
+	})
+}
+
+// handleReadmeTick reads the README once the selection has settled.
+func (m Model) handleReadmeTick(msg readmeTickMsg) (tea.Model, tea.Cmd) {
+	if !m.readme || msg.seq != m.readmeSeq {
+		return m, nil
+	}
+	n := m.readmeLines
+	if n <= 0 {
+		n = defaultReadmeLines
+	}
+	return m, func() tea.Msg {
+		content, err := readReadme(msg.dir, n)
+		return readmeMsg{dir: msg.dir, content: content, err: err}
+	}
+}
+
+// handleReadme stores README content if it is still for the selection.
+func (m Model) handleReadme(msg readmeMsg) (tea.Model, tea.Cmd) {
+	if !m.readme || msg.dir != m.readmeWant {
+		return m, nil
+	}
+	m.readmeLoaded = msg.dir
+	switch {
+	case errors.Is(msg.err, errNoReadme):
+		m.readmeContent = theme.DialogNote.Render("No README in " + shortenPath(msg.dir))
+	case msg.err != nil:
+		m.readmeContent = theme.DialogNote.Render(msg.err.Error())
+	default:
+		m.readmeContent = msg.content
+	}
+	return m, nil
+}
+
+// toggleReadme turns the README panel on or off. It shares the side panel
+// with the pane preview, which it turns off.
+func (m Model) toggleReadme() (tea.Model, tea.Cmd) {
+	m.readme = !m.readme
+	m.readmeSeq++ // drop pending ticks
+	m.readmeWant, m.readmeLoaded, m.readmeContent = "", "", ""
+	status := "README off"
+	if m.readme {
+		status = "README on"
+		m.preview = false
+		m.previewSeq++
+		m.previewSession, m.previewContent = "", ""
+	}
+	m.resize()
+	cmd := m.followReadme()
+	if m.state == StateProjectPicker {
+		return m, tea.Batch(cmd, m.projectPicker.NewStatusMessage(FormatStatusInfo(status)))
+	}
+	return m, tea.Batch(cmd, m.list.NewStatusMessage(FormatStatusInfo(status)))
+}
+
+// viewReadme renders the README lines in a panel of the given size.
+func (m Model) viewReadme(width, height int) string {
+	style := theme.PreviewPanel
+	innerW := width - style.GetHorizontalFrameSize()
+	innerH := height - style.GetVerticalFrameSize()
+	if innerW <= 0 || innerH <= 0 {
+		return ""
+	}
+
+	var body string
+	switch {
+	case m.readmeWant == "":
+		body = theme.DialogNote.Render("Select a project to see its README")
+	case m.readmeLoaded == "":
+		body = theme.DialogNote.Render("Loading README…")
+	default:
+		lines := strings.Split(m.readmeContent, "\n")
+		if len(lines) > innerH {
+			lines = lines[:innerH]
+		}
+		for i, line := range lines {
+			lines[i] = runewidth.Truncate(strings.ReplaceAll(line, "\t", "    "), innerW, "")
+		}
+		body = strings.Join(lines, "\n")
+	}
+	return style.Width(innerW).Height(innerH).Render(body)
+}
+
+// joinReadme places the README panel to the right of the project picker.
+func (m Model) joinReadme(pickerView string) string {
+	if !m.readme {
+		return pickerView
+	}
+	width := m.previewWidth(m.width - appStyle.GetHorizontalFrameSize())
+	if width == 0 {
+		return pickerView
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, pickerView, m.viewReadme(width, lipgloss.Height(pickerView)))
+}
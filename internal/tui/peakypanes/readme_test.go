@@ -0,0 +1,153 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestFindReadme tests that README names match without regard to case and
+// that markdown wins over other READMEs
+func TestFindReadme(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{name: "upper case", files: []string{"README.md", "main.go"}, want: "README.md"},
+		{name: "mixed case", files: []string{"Readme.MD"}, want: "Readme.MD"},
+		{name: "markdown first", files: []string{"README", "readme.txt", "README.markdown"}, want: "README.markdown"},
+		{name: "plain readme", files: []string{"README"}, want: "README"},
+		{name: "none", files: []string{"main.go", "README.md.bak"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files...)
+			path, ok := findReadme(dir)
+			if tt.want == "" {
+				if ok {
+					t.Errorf("findReadme() = %q, want none", path)
+				}
+				return
+			}
+			if !ok || filepath.Base(path) != tt.want {
+				t.Errorf("findReadme() = %q, %v, want %s", path, ok, tt.want)
+			}
+		})
+	}
+}
+
+// TestFindReadmeSkipsDirectories tests that a readme directory is not taken
+func TestFindReadmeSkipsDirectories(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "readme"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if path, ok := findReadme(dir); ok {
+		t.Errorf("findReadme() = %q, want none", path)
+	}
+	if _, ok := findReadme(filepath.Join(dir, "missing")); ok {
+		t.Error("a missing directory has no README")
+	}
+}
+
+// TestHeadLines tests that only the first n lines are returned
+func TestHeadLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		n     int
+		want  []string
+	}{
+		{name: "limited", input: "a\nb\nc\nd\n", n: 2, want: []string{"a", "b"}},
+		{name: "shorter", input: "a\nb\n", n: 5, want: []string{"a", "b"}},
+		{name: "no final newline", input: "a\nb", n: 5, want: []string{"a", "b"}},
+		{name: "crlf", input: "a\r\nb\r\n", n: 5, want: []string{"a", "b"}},
+		{name: "blank lines kept", input: "# Title\n\ntext\n", n: 3, want: []string{"# Title", "", "text"}},
+		{name: "empty", input: "", n: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := headLines(strings.NewReader(tt.input), tt.n)
+			if err != nil {
+				t.Fatalf("headLines() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("headLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestReadmePanelFollowsSelection tests that the README of the highlighted
+// project is read after the debounce and a missing one is reported
+func TestReadmePanelFollowsSelection(t *testing.T) {
+	withReadme, without := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(withReadme, "readme.md"), []byte("# API\nserves things\nmore\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: withReadme},
+		{Name: "web", Session: "web", Path: without},
+	})
+	m.readmeLines = 2
+
+	model := press(t, *m, "m")
+	if !model.readme || model.readmeWant != withReadme {
+		t.Fatalf("m should show the README of the selection (want %q)", model.readmeWant)
+	}
+	seq := model.readmeSeq
+
+	// A stale tick is dropped
+	next, cmd := model.Update(readmeTickMsg{seq: seq - 1, dir: withReadme})
+	if cmd != nil {
+		t.Error("a stale tick must not read the README")
+	}
+	next, cmd = next.(Model).Update(readmeTickMsg{seq: seq, dir: withReadme})
+	if cmd == nil {
+		t.Fatal("a current tick should read the README")
+	}
+	next, _ = next.(Model).Update(cmd())
+	model = next.(Model)
+	if model.readmeContent != "# API\nserves things" {
+		t.Errorf("readmeContent = %q, want the first two lines", model.readmeContent)
+	}
+
+	next, _ = model.Update(keyMsg("down"))
+	model = next.(Model)
+	if model.readmeWant != without || model.readmeContent != "" {
+		t.Fatalf("moving the selection should clear the panel (want %q)", model.readmeWant)
+	}
+	next, cmd = model.Update(readmeTickMsg{seq: model.readmeSeq, dir: without})
+	next, _ = next.(Model).Update(cmd())
+	if got := next.(Model).readmeContent; !strings.Contains(got, "No README") {
+		t.Errorf("readmeContent = %q, want a note about the missing README", got)
+	}
+}
+
+// TestReadmeReplacesPreview tests that the README panel and the pane
+// preview are never on together
+func TestReadmeReplacesPreview(t *testing.T) {
+	m, _ := newTestModel(t, previewProjects())
+	model := press(t, *m, "p")
+	model = press(t, model, "m")
+	if model.preview || !model.readme {
+		t.Errorf("preview = %v, readme = %v, want only the README", model.preview, model.readme)
+	}
+	model = press(t, model, "p")
+	if !model.preview || model.readme {
+		t.Errorf("preview = %v, readme = %v, want only the preview", model.preview, model.readme)
+	}
+}
@@ -0,0 +1,41 @@
+package peakypanes
+
+import "github.com/kregenrek/tmuxman/internal/state"
+
+// recordRecent marks session as most recently opened in the state file.
+// Failures are ignored: the MRU list is a convenience, not critical state.
+func (m Model) recordRecent(session string) {
+	if m.statePath == "" || session == "" {
+		return
+	}
+	_ = state.Update(m.statePath, func(s *state.State) { s.Touch(session) })
+}
+
+// lastRunningSession returns the most recently opened session that is still
+// running, according to the MRU list and the projects' statuses.
+func lastRunningSession(recent []string, projects []Project) (string, bool) {
+	running := make(map[string]bool)
+	for _, session := range runningSessions(projects) {
+		running[session] = true
+	}
+	for _, session := range recent {
+		if running[session] {
+			return session, true
+		}
+	}
+	return "", false
+}
+
+// ReopenSession reports the session to attach to on startup when the
+// reopen-last option is enabled. When it returns false the list should be
+// shown.
+func (m *Model) ReopenSession() (string, bool) {
+	if !m.reopenLast || m.statePath == "" {
+		return "", false
+	}
+	st, err := state.Load(m.statePath)
+	if err != nil {
+		return "", false
+	}
+	return lastRunningSession(st.Recent, m.projects)
+}
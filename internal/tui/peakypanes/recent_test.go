@@ -0,0 +1,60 @@
+package peakypanes
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+)
+
+// TestLastRunningSession tests the reopen decision against the MRU list
+func TestLastRunningSession(t *testing.T) {
+	projects := []Project{
+		{Session: "api", Status: StatusRunning},
+		{Session: "web", Status: StatusStopped},
+	}
+
+	tests := []struct {
+		name   string
+		recent []string
+		want   string
+		wantOK bool
+	}{
+		{name: "last alive attaches", recent: []string{"api", "web"}, want: "api", wantOK: true},
+		{name: "skips gone sessions", recent: []string{"web", "api"}, want: "api", wantOK: true},
+		{name: "all gone shows list", recent: []string{"web", "old"}},
+		{name: "empty mru shows list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := lastRunningSession(tt.recent, projects)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("lastRunningSession(%v) = %q, %v; want %q, %v", tt.recent, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestReopenSession tests the option gate and state file lookup
+func TestReopenSession(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	m.statePath = filepath.Join(t.TempDir(), "state.yml")
+	if err := state.Update(m.statePath, func(s *state.State) { s.Touch("api") }); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := m.ReopenSession(); ok {
+		t.Error("ReopenSession() without the option should show the list")
+	}
+
+	m.reopenLast = true
+	if session, ok := m.ReopenSession(); !ok || session != "api" {
+		t.Errorf("ReopenSession() = %q, %v; want api, true", session, ok)
+	}
+
+	m.projects[0].Status = StatusStopped
+	if _, ok := m.ReopenSession(); ok {
+		t.Error("ReopenSession() with the last session gone should show the list")
+	}
+}
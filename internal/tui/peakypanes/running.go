@@ -0,0 +1,39 @@
+package peakypanes
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Hiding stopped projects turns the list into a switcher between the live
+// sessions. The toggle is remembered; peakypanes --running starts with it
+// on either way.
+
+// onlyRunning returns the projects whose session is running.
+func onlyRunning(projects []Project) []Project {
+	var running []Project
+	for _, p := range projects {
+		if p.Status.running() {
+			running = append(running, p)
+		}
+	}
+	return running
+}
+
+// listed returns the projects the list shows under the archived and
+// hide-stopped filters.
+func (m *Model) listed() []Project {
+	projects := listedProjects(m.projects, m.showArchived)
+	if m.hideStopped {
+		projects = onlyRunning(projects)
+	}
+	return projects
+}
+
+// toggleHideStopped hides or shows projects without a running session.
+func (m Model) toggleHideStopped() (tea.Model, tea.Cmd) {
+	m.hideStopped = !m.hideStopped
+	m.savePreferences()
+	m.list.SetItems(m.projectsToItems())
+	if m.hideStopped {
+		return m, m.list.NewStatusMessage(FormatStatusInfo("Showing running sessions only"))
+	}
+	return m, m.list.NewStatusMessage(FormatStatusInfo("Showing all projects"))
+}
@@ -0,0 +1,88 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// listedSessions returns the sessions of the projects in the list, in order.
+func listedSessions(m Model) []string {
+	var sessions []string
+	for _, item := range m.list.Items() {
+		if p, ok := item.(Project); ok {
+			sessions = append(sessions, p.Session)
+		}
+	}
+	return sessions
+}
+
+// TestRunningOption tests --running starts with stopped projects hidden
+func TestRunningOption(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	client, err := tmuxctl.NewClient("tmux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		if len(args) == 3 && args[0] == "list-sessions" && args[2] == "#{session_name}" {
+			return exec.CommandContext(ctx, "printf", "api\nlogs\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+
+	tests := []struct {
+		name string
+		opts Options
+		want bool
+	}{
+		{name: "default", opts: Options{}, want: false},
+		{name: "running", opts: Options{Running: true}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewModel(client, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.hideStopped != tt.want {
+				t.Errorf("hideStopped = %v, want %v", m.hideStopped, tt.want)
+			}
+		})
+	}
+}
+
+// TestHideStopped tests the filter lists only running projects and toggles
+// with S
+func TestHideStopped(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning},
+		{Name: "web", Session: "web", Path: "/srv/web", Status: StatusStopped},
+		{Name: "cli", Session: "cli", Path: "/srv/cli", Status: StatusCurrent},
+		{Name: "logs", Session: "logs", Status: StatusRunning},
+	})
+	m.hideStopped = true
+	m.list.SetItems(m.projectsToItems())
+
+	got := listedSessions(*m)
+	want := []string{"api", "cli", "logs"}
+	if len(got) != len(want) {
+		t.Fatalf("listed %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("listed %v, want %v", got, want)
+		}
+	}
+
+	model := press(t, *m, "S")
+	if model.hideStopped {
+		t.Fatal("S did not turn the filter off")
+	}
+	if got := listedSessions(model); len(got) != 4 {
+		t.Errorf("listed %v after S, want all projects", got)
+	}
+}
@@ -0,0 +1,93 @@
+package peakypanes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// startRunOnce prompts for a command to run once in the selected project.
+func (m Model) startRunOnce() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	if item.Status == StatusMissing {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Path not found: " + shortenPath(item.Path)))
+	}
+	m.runOnceProject = &item
+	m.cmdInput = newCommandInput("npm test")
+	m.cmdHistory = m.loadHistory(func(s *state.State) []string { return s.RunHistory[item.Session] })
+	m.state = StateRunOnceInput
+	return m, textinput.Blink
+}
+
+// runOnce opens p and runs command in it. A running session gets the
+// command in its active pane before attaching; a stopped one is started
+// with the command sent to its first pane after the layout is applied.
+func (m Model) runOnce(p Project, command string) tea.Cmd {
+	if !p.Status.running() {
+		return m.startProjectWith(p, command)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := m.tmux.RunOnce(ctx, tmuxctl.ActivePaneTarget(p.Session), command); err != nil {
+		return NewErrorCmd(err, "run command")
+	}
+	return m.attachProject(p)
+}
+
+func (m Model) updateRunOnceInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.runOnceProject = nil
+		m.state = StateHome
+		return m, nil
+	case "enter":
+		command := strings.TrimSpace(m.cmdInput.Value())
+		if command == "" || m.runOnceProject == nil {
+			return m, nil
+		}
+		p := *m.runOnceProject
+		m.runOnceProject = nil
+		m.state = StateHome
+		m.saveHistory(func(s *state.State) { s.AddRunHistory(p.Session, command) })
+		return m, m.runOnce(p, command)
+	}
+	if recall(m.cmdHistory, &m.cmdInput, msg.String()) {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.cmdInput, cmd = m.cmdInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) viewRunOnceInput() string {
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("▶ Open & run"))
+	b.WriteString("\n\n")
+	if m.runOnceProject != nil {
+		b.WriteString(theme.DialogLabel.Render("Session: "))
+		b.WriteString(theme.DialogValue.Render(m.runOnceProject.Session))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(theme.DialogLabel.Render("Command"))
+	b.WriteString("\n")
+	b.WriteString(m.cmdInput.View())
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogNote.Render("Runs once; not saved to the project • ↑/↓ history"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogChoiceKey.Render("enter"))
+	b.WriteString(theme.DialogChoiceSep.Render(" open & run • "))
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" cancel"))
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
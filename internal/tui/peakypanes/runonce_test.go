@@ -0,0 +1,45 @@
+package peakypanes
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestRunOnceRunningSession tests that the command is sent to the active pane before attaching
+func TestRunOnceRunningSession(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+
+	model := press(t, *m, "x")
+	if model.state != StateRunOnceInput {
+		t.Fatalf("state = %v, want StateRunOnceInput", model.state)
+	}
+	model = press(t, model, "npm test", "enter")
+	if model.state != StateHome {
+		t.Errorf("state = %v, want StateHome", model.state)
+	}
+	want := [][]string{{"send-keys", "-t", "api:", "npm test", "Enter"}}
+	if !reflect.DeepEqual(calls.args, want) {
+		t.Errorf("tmux calls = %q, want %q", calls.args, want)
+	}
+}
+
+// TestRunOnceStoppedSession tests that a stopped project is started with the command
+func TestRunOnceStoppedSession(t *testing.T) {
+	p := Project{Name: "api", Session: "api", Path: "/srv/api", Layout: "dev-3"}
+	want := []string{"start", "--session", "api", "--path", "/srv/api", "--layout", "dev-3", "--run", "npm test"}
+	if got := startArgs(p, "npm test", false); !reflect.DeepEqual(got, want) {
+		t.Errorf("startArgs() = %q, want %q", got, want)
+	}
+	if got := startArgs(p, "", false); len(got) != 7 {
+		t.Errorf("startArgs() without command = %q", got)
+	}
+
+	m, calls := newTestModel(t, []Project{p})
+	model := press(t, *m, "x", "npm test", "enter")
+	if model.state != StateHome {
+		t.Errorf("state = %v, want StateHome", model.state)
+	}
+	if len(calls.args) != 0 {
+		t.Errorf("stopped project should not send keys from the TUI: %q", calls.args)
+	}
+}
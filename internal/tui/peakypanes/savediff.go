@@ -0,0 +1,188 @@
+package peakypanes
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// pendingSave is a config change held back until the user confirms it.
+type pendingSave struct {
+	diff  []string
+	write func(*Model) error
+}
+
+// diffProjects describes how after differs from before, one line per
+// change. Projects are matched by session name: "-" marks removed
+// projects, "+" added ones, "~" changed fields and "↕" a new position.
+func diffProjects(before, after []Project) []string {
+	old := make(map[string]Project, len(before))
+	for _, p := range before {
+		old[p.Session] = p
+	}
+	current := make(map[string]bool, len(after))
+	for _, p := range after {
+		current[p.Session] = true
+	}
+
+	var lines []string
+	var keptBefore, keptAfter []string
+	for _, p := range before {
+		if !current[p.Session] {
+			lines = append(lines, fmt.Sprintf("- %s (%s)", p.Name, p.Path))
+			continue
+		}
+		keptBefore = append(keptBefore, p.Session)
+	}
+	for _, p := range after {
+		prev, ok := old[p.Session]
+		if !ok {
+			lines = append(lines, fmt.Sprintf("+ %s (%s)", p.Name, p.Path))
+			continue
+		}
+		keptAfter = append(keptAfter, p.Session)
+		lines = append(lines, fieldChanges(prev, p)...)
+	}
+
+	position := make(map[string]int, len(keptBefore))
+	for i, s := range keptBefore {
+		position[s] = i
+	}
+	for i, s := range keptAfter {
+		if from := position[s]; from != i {
+			lines = append(lines, fmt.Sprintf("↕ %s: %d → %d", old[s].Name, from+1, i+1))
+		}
+	}
+	return lines
+}
+
+// fieldChanges lists the config fields that differ between two versions of
+// the same project.
+func fieldChanges(prev, next Project) []string {
+	var lines []string
+	change := func(field, a, b string) {
+		if a != b {
+			lines = append(lines, fmt.Sprintf("~ %s: %s %q → %q", prev.Session, field, a, b))
+		}
+	}
+	change("name", prev.Name, next.Name)
+	change("path", prev.Path, next.Path)
+	change("layout", prev.Layout, next.Layout)
+	change("archived", strconv.FormatBool(prev.Archived), strconv.FormatBool(next.Archived))
+	change("order", formatOrder(prev.Order), formatOrder(next.Order))
+	return lines
+}
+
+// formatOrder formats an order key, empty when unset.
+func formatOrder(order *int) string {
+	if order == nil {
+		return ""
+	}
+	return strconv.Itoa(*order)
+}
+
+// diskProjects returns the configured projects as currently written in the
+// config file.
+func (m *Model) diskProjects() ([]Project, error) {
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	projects, _ := configProjects(cfg)
+	return projects, nil
+}
+
+// saveConfig writes a config change, or holds it for review on the confirm
+// save screen when confirm_save is enabled.
+func (m *Model) saveConfig(write func(*Model) error) tea.Cmd {
+	if !m.confirmSave {
+		if err := write(m); err != nil {
+			return m.list.NewStatusMessage(FormatStatusError(err))
+		}
+		return nil
+	}
+	before, err := m.diskProjects()
+	if err != nil {
+		return m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	m.pendingSave = &pendingSave{
+		diff:  diffProjects(before, m.projects[:configuredCount(m.projects)]),
+		write: write,
+	}
+	m.state = StateConfirmSave
+	return nil
+}
+
+// discardChanges reloads projects from disk, dropping unsaved edits.
+func (m *Model) discardChanges() error {
+	if err := m.loadConfig(); err != nil {
+		return err
+	}
+	if err := m.refreshStatuses(); err != nil {
+		return err
+	}
+	m.list.SetItems(m.projectsToItems())
+	return nil
+}
+
+func (m Model) updateConfirmSave(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	pending := m.pendingSave
+	if pending == nil {
+		m.state = StateHome
+		return m, nil
+	}
+	switch msg.String() {
+	case "y", "enter":
+		m.pendingSave = nil
+		m.state = StateHome
+		if err := pending.write(&m); err != nil {
+			return m, m.list.NewStatusMessage(FormatStatusError(err))
+		}
+		return m, m.list.NewStatusMessage(FormatStatusSuccess("Config saved"))
+	case "n", "esc":
+		m.pendingSave = nil
+		m.state = StateHome
+		if err := m.discardChanges(); err != nil {
+			return m, m.list.NewStatusMessage(FormatStatusError(err))
+		}
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Changes discarded"))
+	}
+	return m, nil
+}
+
+func (m Model) viewConfirmSave() string {
+	listView := theme.ListDimmed.Render(m.list.View())
+
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("💾 Save config changes?"))
+	b.WriteString("\n\n")
+	if m.pendingSave != nil && len(m.pendingSave.diff) > 0 {
+		for _, line := range m.pendingSave.diff {
+			b.WriteString(theme.DialogValue.Render(line))
+			b.WriteString("\n")
+		}
+	} else {
+		b.WriteString(theme.DialogNote.Render("No differences from the config on disk"))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(theme.DialogChoiceKey.Render("y"))
+	b.WriteString(theme.DialogChoiceSep.Render(" save • "))
+	b.WriteString(theme.DialogChoiceKey.Render("n"))
+	b.WriteString(theme.DialogChoiceSep.Render(" discard"))
+
+	return appStyle.Render(listView + "\n\n" + dialogStyle.Render(b.String()))
+}
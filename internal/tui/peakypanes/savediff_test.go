@@ -0,0 +1,112 @@
+package peakypanes
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+// TestDiffProjects tests the textual diff between two project lists
+func TestDiffProjects(t *testing.T) {
+	a := Project{Name: "alpha", Session: "alpha", Path: "/a"}
+	b := Project{Name: "beta", Session: "beta", Path: "/b"}
+	c := Project{Name: "gamma", Session: "gamma", Path: "/c"}
+	renamed := b
+	renamed.Name = "Beta"
+	moved := a
+	moved.Path = "/srv/a"
+
+	tests := []struct {
+		name   string
+		before []Project
+		after  []Project
+		want   []string
+	}{
+		{name: "unchanged", before: []Project{a, b}, after: []Project{a, b}},
+		{name: "added", before: []Project{a}, after: []Project{a, c}, want: []string{"+ gamma (/c)"}},
+		{name: "removed", before: []Project{a, b}, after: []Project{a}, want: []string{"- beta (/b)"}},
+		{
+			name:   "renamed",
+			before: []Project{a, b},
+			after:  []Project{a, renamed},
+			want:   []string{`~ beta: name "beta" → "Beta"`},
+		},
+		{
+			name:   "path changed",
+			before: []Project{a},
+			after:  []Project{moved},
+			want:   []string{`~ alpha: path "/a" → "/srv/a"`},
+		},
+		{
+			name:   "reordered",
+			before: []Project{a, b, c},
+			after:  []Project{b, a, c},
+			want:   []string{"↕ beta: 2 → 1", "↕ alpha: 1 → 2"},
+		},
+		{
+			name:   "removal does not count as a move",
+			before: []Project{a, b, c},
+			after:  []Project{a, c},
+			want:   []string{"- beta (/b)"},
+		},
+		{name: "empty disk config", after: []Project{a}, want: []string{"+ alpha (/a)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffProjects(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffProjects() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestConfirmSave tests that reordering waits for confirmation and that
+// cancelling restores the order from disk
+func TestConfirmSave(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "alpha", Session: "alpha", Path: "/a", Status: StatusStopped},
+		{Name: "beta", Session: "beta", Path: "/b", Status: StatusStopped},
+	})
+	content := "confirm_save: true\nprojects:\n  - name: alpha\n    path: /a\n  - name: beta\n    path: /b\n"
+	if err := os.WriteFile(m.configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m.confirmSave = true
+
+	m.moveSelected(1)
+	if m.state != StateConfirmSave {
+		t.Fatalf("state = %v, want StateConfirmSave", m.state)
+	}
+	if want := []string{"↕ beta: 2 → 1", "↕ alpha: 1 → 2"}; !reflect.DeepEqual(m.pendingSave.diff, want) {
+		t.Errorf("pending diff = %q, want %q", m.pendingSave.diff, want)
+	}
+	if data, _ := os.ReadFile(m.configPath); string(data) != content {
+		t.Errorf("config written before confirmation:\n%s", data)
+	}
+
+	cancelled := press(t, *m, "n")
+	if cancelled.state != StateHome || cancelled.pendingSave != nil {
+		t.Errorf("after cancel: state = %v, pending = %v", cancelled.state, cancelled.pendingSave)
+	}
+	if got := projectNames(cancelled.projects[:configuredCount(cancelled.projects)]); got != "alpha,beta" {
+		t.Errorf("after cancel projects = %s, want alpha,beta", got)
+	}
+
+	cancelled.list.Select(0)
+	cancelled.moveSelected(1)
+	if cancelled.state != StateConfirmSave {
+		t.Fatalf("state = %v, want StateConfirmSave", cancelled.state)
+	}
+	saved := press(t, cancelled, "y")
+	if saved.state != StateHome {
+		t.Errorf("after confirm: state = %v", saved.state)
+	}
+	disk, err := saved.diskProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := projectNames(disk); got != "beta,alpha" {
+		t.Errorf("saved projects = %s, want beta,alpha", got)
+	}
+}
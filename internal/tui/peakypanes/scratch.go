@@ -0,0 +1,58 @@
+package peakypanes
+
+import "strings"
+
+const (
+	defaultScratchSession = "scratch"
+	defaultScratchLayout  = "simple"
+)
+
+// scratchConfig configures the always-present scratch project.
+type scratchConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Session string `yaml:"session"`
+	Path    string `yaml:"path"`
+	Layout  string `yaml:"layout"`
+}
+
+// project builds the scratch Project from its config.
+func (c scratchConfig) project() Project {
+	session := strings.TrimSpace(c.Session)
+	if session == "" {
+		session = defaultScratchSession
+	}
+	path := c.Path
+	if path == "" {
+		path = "~"
+	}
+	layoutName := c.Layout
+	if layoutName == "" {
+		layoutName = defaultScratchLayout
+	}
+	return Project{
+		Name:    session,
+		Session: session,
+		Path:    expandPath(path),
+		Layout:  layoutName,
+		Status:  StatusStopped,
+		Scratch: true,
+	}
+}
+
+// withScratch pins the scratch project to the top of projects when enabled.
+// A configured project using the scratch session name is replaced so the
+// session is listed once.
+func withScratch(projects []Project, c scratchConfig) []Project {
+	if !c.Enabled {
+		return projects
+	}
+	scratch := c.project()
+	result := []Project{scratch}
+	for _, p := range projects {
+		if p.Scratch || p.Session == scratch.Session {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
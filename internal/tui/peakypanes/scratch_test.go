@@ -0,0 +1,87 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestScratchAlwaysPresent tests the scratch project is pinned first for any config contents
+func TestScratchAlwaysPresent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int // expected number of projects including scratch
+	}{
+		{name: "no projects", content: "scratch:\n  enabled: true\n", want: 1},
+		{name: "empty projects", content: "scratch:\n  enabled: true\nprojects: []\n", want: 1},
+		{
+			name:    "with projects",
+			content: "scratch:\n  enabled: true\nprojects:\n  - name: api\n    path: /srv/api\n  - name: web\n    path: /srv/web\n",
+			want:    3,
+		},
+		{
+			name:    "project shadows scratch session",
+			content: "scratch:\n  enabled: true\nprojects:\n  - name: scratch\n    path: /tmp\n  - name: api\n    path: /srv/api\n",
+			want:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			m := &Model{configPath: path}
+			if err := m.loadConfig(); err != nil {
+				t.Fatalf("loadConfig() error: %v", err)
+			}
+			if len(m.projects) != tt.want {
+				t.Fatalf("got %d projects, want %d: %+v", len(m.projects), tt.want, m.projects)
+			}
+			first := m.projects[0]
+			if !first.Scratch || first.Session != defaultScratchSession {
+				t.Errorf("first project = %+v, want scratch", first)
+			}
+			for _, p := range m.projects[1:] {
+				if p.Session == defaultScratchSession {
+					t.Errorf("scratch session listed twice")
+				}
+			}
+		})
+	}
+}
+
+// TestScratchConfig tests custom scratch session settings and the disabled default
+func TestScratchConfig(t *testing.T) {
+	p := scratchConfig{Enabled: true, Session: "notes", Path: "/tmp/notes", Layout: "dev-2"}.project()
+	if p.Session != "notes" || p.Path != "/tmp/notes" || p.Layout != "dev-2" || !p.Scratch {
+		t.Errorf("project() = %+v", p)
+	}
+
+	def := scratchConfig{Enabled: true}.project()
+	if def.Layout != defaultScratchLayout || def.Path == "" {
+		t.Errorf("default project() = %+v", def)
+	}
+
+	projects := []Project{{Name: "api", Path: "/srv/api"}}
+	if got := withScratch(projects, scratchConfig{}); len(got) != 1 {
+		t.Errorf("withScratch() disabled should not add scratch, got %+v", got)
+	}
+}
+
+// TestScratchPinnedOnSortAndMove tests scratch stays first when sorting and reordering
+func TestScratchPinnedOnSortAndMove(t *testing.T) {
+	projects := withScratch([]Project{{Name: "alpha", Session: "alpha", Path: "/a"}}, scratchConfig{Enabled: true})
+
+	if sorted := sortProjects(projects, SortName); !sorted[0].Scratch {
+		t.Errorf("name sort moved scratch: %s", projectNames(sorted))
+	}
+	if _, _, moved := moveProject(projects, 2, 1, -1, nil); moved {
+		t.Error("moving a project above scratch should be a no-op")
+	}
+	if _, _, moved := moveProject(projects, 2, 0, 1, nil); moved {
+		t.Error("moving scratch should be a no-op")
+	}
+}
@@ -0,0 +1,55 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// clearDialog asks before clearing the scrollback of p's session.
+func clearDialog(p Project, screen bool) *confirmDialog {
+	note := "The scrollback of every pane is lost"
+	if screen {
+		note = "The scrollback of every pane is lost and clear is typed into each shell"
+	}
+	return &confirmDialog{
+		title:  "🧹 Clear Scrollback?",
+		action: "Clear",
+		fields: []confirmField{{"Project", p.Name}, {"Session", p.Session}},
+		note:   note,
+		prompt: "clear_scrollback",
+		data:   promptDataFor(p),
+		result: func(m Model, confirmed bool) (tea.Model, tea.Cmd) {
+			if !confirmed {
+				return m, nil
+			}
+			return m.clearHistory(p)
+		},
+	}
+}
+
+// startClearHistory opens the clear dialog for the selected running
+// session.
+func (m Model) startClearHistory() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	if !item.Status.running() {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Session not running"))
+	}
+	return m, m.openConfirm(clearDialog(item, m.clearScreen))
+}
+
+// clearHistory clears the scrollback of every pane of p's session.
+func (m Model) clearHistory(p Project) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	n, err := m.tmux.ClearHistory(ctx, p.Session, m.clearScreen)
+	if err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(NewErrorMsg(err, "clear "+p.Session)))
+	}
+	return m, m.list.NewStatusMessage(FormatStatusSuccess(fmt.Sprintf("Cleared scrollback of %d panes in %s", n, p.Session)))
+}
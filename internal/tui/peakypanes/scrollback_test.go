@@ -0,0 +1,52 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+// TestClearHistory tests that confirming clears every pane of the selected session
+func TestClearHistory(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls.args = append(calls.args, args)
+		if args[0] == "list-panes" {
+			return exec.CommandContext(ctx, "printf", "%%1\n%%2\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+	m.clearScreen = true
+
+	model := press(t, *m, "C")
+	if model.state != StateConfirm || model.confirm == nil {
+		t.Fatalf("state = %v, want StateConfirm", model.state)
+	}
+	if len(calls.args) != 0 {
+		t.Fatalf("calls before confirming = %q", calls.args)
+	}
+	press(t, model, "y")
+	want := [][]string{
+		{"list-panes", "-s", "-t", "api", "-F", "#{pane_id}"},
+		{"send-keys", "-t", "%1", "clear", "Enter"},
+		{"clear-history", "-t", "%1"},
+		{"send-keys", "-t", "%2", "clear", "Enter"},
+		{"clear-history", "-t", "%2"},
+	}
+	if !reflect.DeepEqual(calls.args, want) {
+		t.Errorf("calls = %q, want %q", calls.args, want)
+	}
+}
+
+// TestClearHistoryNotRunning tests that stopped sessions are not offered for clearing
+func TestClearHistoryNotRunning(t *testing.T) {
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusStopped}})
+	model := press(t, *m, "C")
+	if model.state != StateHome {
+		t.Errorf("state = %v, want StateHome", model.state)
+	}
+	if len(calls.args) != 0 {
+		t.Errorf("calls = %q, want none", calls.args)
+	}
+}
@@ -0,0 +1,84 @@
+package peakypanes
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// resolvePath returns p with ~ expanded, cleaned and, when it exists,
+// with symlinks resolved, so differently spelled paths to one directory
+// compare equal.
+func resolvePath(p string) string {
+	if p == "" {
+		return ""
+	}
+	p = filepath.Clean(expandPath(p))
+	if resolved, err := filepath.EvalSymlinks(p); err == nil {
+		return resolved
+	}
+	return p
+}
+
+// matchSessionsByPath points configured projects whose session is not
+// running at a live session started in the project's path, for sessions
+// created by other tools under another name. Only sessions no configured
+// project owns are used, each for one project. A matched project keeps its
+// configured name in configSession while Session holds the live one.
+func matchSessionsByPath(projects []Project, paths map[string]tmuxctl.SessionPaths) {
+	owned := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		if p.Path != "" {
+			owned[p.Session] = true
+		}
+	}
+	var live []string
+	starts := make(map[string]string)
+	for s, sp := range paths {
+		if !owned[s] && sp.Start != "" {
+			live = append(live, s)
+			starts[s] = resolvePath(sp.Start)
+		}
+	}
+	if len(live) == 0 {
+		return
+	}
+	sort.Strings(live)
+
+	taken := make(map[string]bool)
+	for i := range projects {
+		p := &projects[i]
+		if p.Path == "" || p.Archived {
+			continue
+		}
+		if _, running := paths[p.Session]; running {
+			continue
+		}
+		dir := resolvePath(p.Path)
+		for _, s := range live {
+			if taken[s] || starts[s] != dir {
+				continue
+			}
+			p.configSession, p.Session = p.Session, s
+			taken[s] = true
+			break
+		}
+	}
+}
+
+// unmatchSession restores the configured session name of a project that
+// was matched to a live session by path.
+func (p *Project) unmatchSession() {
+	if p.configSession != "" {
+		p.Session, p.configSession = p.configSession, ""
+	}
+}
+
+// savedSession is the session name the config file gives p.
+func (p Project) savedSession() string {
+	if p.configSession != "" {
+		return p.configSession
+	}
+	return p.Session
+}
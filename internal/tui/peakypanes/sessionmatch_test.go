@@ -0,0 +1,115 @@
+package peakypanes
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// TestMatchSessionsByPath tests matching with trailing-slash and symlinked
+// path variants
+func TestMatchSessionsByPath(t *testing.T) {
+	root := t.TempDir()
+	api := filepath.Join(root, "api")
+	web := filepath.Join(root, "web")
+	for _, dir := range []string{api, web} {
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	link := filepath.Join(root, "web-link")
+	if err := os.Symlink(web, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	projects := []Project{
+		{Name: "api", Session: "api", Path: api + "/"},
+		{Name: "web", Session: "web", Path: link},
+		{Name: "cli", Session: "cli", Path: api},
+		{Name: "docs", Session: "docs", Path: filepath.Join(root, "docs"), Archived: true},
+	}
+	paths := map[string]tmuxctl.SessionPaths{
+		"my-api":  {Start: api},                 // trailing slash on the project path
+		"web-dev": {Start: web + "/"},           // project path is a symlink
+		"cli":     {Start: "/elsewhere"},        // running under its own name
+		"other":   {Start: filepath.Join(root)}, // no project works here
+	}
+	matchSessionsByPath(projects, paths)
+
+	want := []struct{ session, saved string }{
+		{"my-api", "api"},
+		{"web-dev", "web"},
+		{"cli", "cli"},
+		{"docs", "docs"},
+	}
+	for i, w := range want {
+		if projects[i].Session != w.session || projects[i].savedSession() != w.saved {
+			t.Errorf("%s: session = %q (saved %q), want %q (saved %q)",
+				projects[i].Name, projects[i].Session, projects[i].savedSession(), w.session, w.saved)
+		}
+	}
+
+	projects[0].unmatchSession()
+	if projects[0].Session != "api" || projects[0].configSession != "" {
+		t.Errorf("unmatchSession() left %+v", projects[0])
+	}
+}
+
+// TestMatchSessionsByPathOnce tests that a live session matches one project
+// and never one a configured project owns
+func TestMatchSessionsByPathOnce(t *testing.T) {
+	projects := []Project{
+		{Name: "a", Session: "a", Path: "/srv/x"},
+		{Name: "b", Session: "b", Path: "/srv/x"},
+		{Name: "c", Session: "c", Path: "/srv/y"},
+	}
+	paths := map[string]tmuxctl.SessionPaths{"x": {Start: "/srv/x"}, "a-old": {Start: "/srv/x"}, "b": {Start: "/srv/y"}}
+	matchSessionsByPath(projects, paths)
+	if projects[0].Session != "a-old" || projects[1].Session != "b" || projects[2].Session != "c" {
+		t.Errorf("sessions = %q, %q, %q; want a-old, b, c", projects[0].Session, projects[1].Session, projects[2].Session)
+	}
+}
+
+// TestRefreshMatchByPath tests that refresh marks a project running through
+// a session of another name and restores the name once it is gone
+func TestRefreshMatchByPath(t *testing.T) {
+	dir := t.TempDir()
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: dir + "/"}})
+	m.matchByPath = true
+	sessions := "tool-api\t" + dir + "\t" + dir + "\n"
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		if len(args) == 3 && args[0] == "list-sessions" {
+			out := sessions
+			if args[2] == "#{session_name}" {
+				out = ""
+				if sessions != "" {
+					out = "tool-api\n"
+				}
+			}
+			return exec.CommandContext(ctx, "printf", "%s", out)
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+
+	if err := m.refreshStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.projects) != 1 {
+		t.Fatalf("projects = %+v, want the matched session folded into api", m.projects)
+	}
+	if p := m.projects[0]; p.Session != "tool-api" || !p.Status.running() {
+		t.Errorf("project = %q %v, want running as tool-api", p.Session, p.Status)
+	}
+
+	sessions = ""
+	if err := m.refreshStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	if p := m.projects[0]; p.Session != "api" || p.Status.running() {
+		t.Errorf("project = %q %v, want stopped as api", p.Session, p.Status)
+	}
+}
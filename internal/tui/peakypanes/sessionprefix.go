@@ -0,0 +1,59 @@
+package peakypanes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseSessionPrefix checks a session_prefix. tmux does not allow ':' or
+// '.' in session names, so such a prefix is rejected and none is used.
+func parseSessionPrefix(s string) (string, error) {
+	if strings.ContainsAny(s, ":. \t") {
+		return "", fmt.Errorf("session_prefix %q must not contain ':', '.' or spaces", s)
+	}
+	return s, nil
+}
+
+// prefixSession puts prefix in front of a session name derived by
+// peakypanes. A name already carrying the prefix is returned as is.
+func prefixSession(prefix, session string) string {
+	if prefix == "" || session == "" || strings.HasPrefix(session, prefix) {
+		return session
+	}
+	return prefix + session
+}
+
+// stripSessionPrefix returns session as it is shown, without prefix.
+func stripSessionPrefix(prefix, session string) string {
+	if s := strings.TrimPrefix(session, prefix); s != "" {
+		return s
+	}
+	return session
+}
+
+// displaySession is p's session name without the session prefix.
+func (p Project) displaySession() string {
+	return stripSessionPrefix(p.sessionPrefix, p.Session)
+}
+
+// DefaultSessionName returns the session name peakypanes derives for an
+// unconfigured project in dir: the directory name behind the session_prefix
+// of the config at configPath. Without a readable config or with an invalid
+// prefix the name is left unprefixed.
+func DefaultSessionName(configPath, dir string) string {
+	session := sanitizeSessionName(filepath.Base(dir))
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return session
+	}
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return session
+	}
+	prefix, _ := parseSessionPrefix(cfg.SessionPrefix)
+	return prefixSession(prefix, session)
+}
@@ -0,0 +1,129 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestPrefixSession tests applying and stripping the session prefix
+func TestPrefixSession(t *testing.T) {
+	tests := []struct {
+		prefix, session, prefixed string
+	}{
+		{"pp-", "api", "pp-api"},
+		{"pp-", "pp-api", "pp-api"},
+		{"", "api", "api"},
+		{"pp-", "", ""},
+	}
+	for _, tt := range tests {
+		if got := prefixSession(tt.prefix, tt.session); got != tt.prefixed {
+			t.Errorf("prefixSession(%q, %q) = %q, want %q", tt.prefix, tt.session, got, tt.prefixed)
+		}
+		if tt.session == "" {
+			continue
+		}
+		if got := stripSessionPrefix(tt.prefix, tt.prefixed); got != strings.TrimPrefix(tt.session, tt.prefix) {
+			t.Errorf("stripSessionPrefix(%q, %q) = %q", tt.prefix, tt.prefixed, got)
+		}
+	}
+	if got := stripSessionPrefix("pp-", "pp-"); got != "pp-" {
+		t.Errorf("stripSessionPrefix() = %q, want a session named like the prefix kept", got)
+	}
+	if _, err := parseSessionPrefix("pp:"); err == nil {
+		t.Error("a prefix with ':' should be rejected")
+	}
+}
+
+// TestConfigProjectsSessionPrefix tests that derived session names get the
+// prefix while explicit ones are used as written
+func TestConfigProjectsSessionPrefix(t *testing.T) {
+	var cfg config
+	err := yaml.Unmarshal([]byte(`
+session_prefix: pp-
+projects:
+  - name: My API
+    path: /srv/api
+  - name: web
+    session: web-main
+    path: /srv/web
+`), &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	projects, _ := configProjects(cfg)
+	if projects[0].Session != "pp-my-api" || projects[1].Session != "web-main" {
+		t.Errorf("sessions = %q, %q; want pp-my-api, web-main", projects[0].Session, projects[1].Session)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte("name: My API\npath: /srv/api\n"), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if got := projectNodeSession(doc.Content[0], "pp-"); got != "pp-my-api" {
+		t.Errorf("projectNodeSession() = %q, want pp-my-api", got)
+	}
+	if e := projectEntry(Project{Name: "api", Session: "pp-api", Path: "/srv/api"}, "pp-"); e.Session != "" {
+		t.Errorf("entry session = %q, want the derived prefixed session left out", e.Session)
+	}
+}
+
+// TestSessionPrefixDisplay tests that rows show sessions without the prefix
+func TestSessionPrefixDisplay(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "pp-api", Path: "/srv/api"}})
+	m.sessionPrefix = "pp-"
+	tmpl, err := parseTitleFormat("{{.Name}} [{{.Session}}]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.titleTmpl = tmpl
+	p := m.projectsToItems()[0].(Project)
+	if got := p.Title(); got != "api [api]" {
+		t.Errorf("Title() = %q, want the session without prefix", got)
+	}
+}
+
+// TestRefreshSessionPrefix tests that refresh matches prefixed sessions to
+// their projects and names other prefixed sessions without the prefix
+func TestRefreshSessionPrefix(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "pp-api", Path: "/srv/api"},
+		{Name: "web", Session: "pp-web", Path: "/srv/web"},
+	})
+	m.sessionPrefix = "pp-"
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		if args[0] == "list-sessions" {
+			return exec.CommandContext(ctx, "printf", "pp-api\nweb\npp-tool\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+	if err := m.refreshStatuses(); err != nil {
+		t.Fatal(err)
+	}
+	if m.projects[0].Status != StatusRunning || m.projects[1].Status.running() {
+		t.Errorf("statuses = %v, %v; want only the prefixed api session running", m.projects[0].Status, m.projects[1].Status)
+	}
+	var names []string
+	for _, p := range m.projects[2:] {
+		names = append(names, p.Name+"="+p.Session)
+	}
+	if got := strings.Join(names, " "); got != "web=web tool=pp-tool" {
+		t.Errorf("unconfigured sessions = %q, want web=web tool=pp-tool", got)
+	}
+}
+
+// TestCreateSessionPrefix tests that sessions created from the picker get
+// the prefix
+func TestCreateSessionPrefix(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	m.sessionPrefix = "pp-"
+	m.execFlag = true // the start command is returned instead of run
+	_, cmd := m.createSession(Project{Name: "site", Session: "site", Path: "/srv/site"})
+	argv := execArgvOf(t, cmd)
+	if !strings.Contains(strings.Join(argv, " "), "--session pp-site") {
+		t.Errorf("start argv = %q, want session pp-site", argv)
+	}
+}
@@ -0,0 +1,72 @@
+package peakypanes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// startScript returns the shell commands that create p's session by hand,
+// the same ones peakypanes start --dry-run prints for it.
+func startScript(p Project) (string, error) {
+	loader, err := layout.NewLoader()
+	if err != nil {
+		return "", err
+	}
+	loader.SetProjectDir(p.Path)
+	if err := loader.LoadAll(); err != nil {
+		return "", err
+	}
+	l, _, err := loader.ResolveLayout(p.Layout)
+	if err != nil {
+		return "", err
+	}
+	planned := layout.SessionLayout(l, p.Path, p.LogFile)
+	return tmuxctl.Script(tmuxctl.CreatePlan(p.Session, p.Path, planned, loader.DefaultPaneCommand(), p.Options)), nil
+}
+
+// osc52 returns the escape sequence that puts text on the terminal's
+// clipboard. Inside tmux it is wrapped so tmux passes it to the outer
+// terminal, which needs allow-passthrough.
+func osc52(text string, insideTmux bool) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	if insideTmux {
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return seq
+}
+
+// copyStartScript copies the commands that recreate the selected project's
+// session to the clipboard, to share with someone without peakypanes.
+func (m Model) copyStartScript() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	if item.Path == "" {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("No path configured"))
+	}
+	script, err := startScript(item)
+	if err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(NewErrorMsg(err, "copy "+item.Session)))
+	}
+	out := m.clipboard
+	if out == nil {
+		out = os.Stdout
+	}
+	seq := osc52(script, m.insideTmux)
+	return m, func() tea.Msg {
+		if _, err := io.WriteString(out, seq); err != nil {
+			return NewErrorMsg(err, "copy "+item.Session)
+		}
+		lines := strings.Count(script, "\n")
+		return SuccessMsg{Message: fmt.Sprintf("Copied %d tmux commands for %s", lines, item.Session)}
+	}
+}
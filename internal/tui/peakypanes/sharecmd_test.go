@@ -0,0 +1,70 @@
+package peakypanes
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kregenrek/tmuxman/internal/layout"
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+)
+
+// TestCopyStartScript tests that the copied commands are the dry-run plan of the project
+func TestCopyStartScript(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	p := Project{Name: "api", Session: "api", Path: dir, Layout: "split-v", Options: map[string]string{"mouse": "off"}}
+	m, _ := newTestModel(t, []Project{p})
+	var clip bytes.Buffer
+	m.clipboard = &clip
+	m.list.StatusMessageLifetime = time.Millisecond
+
+	_, cmd := m.Update(keyMsg("y"))
+	if cmd == nil {
+		t.Fatal("no copy command")
+	}
+	msgs := runCmd(cmd)
+	if len(msgs) == 0 {
+		t.Fatal("copy reported nothing")
+	}
+	if _, ok := msgs[0].(SuccessMsg); !ok {
+		t.Fatalf("msg = %#v, want SuccessMsg", msgs[0])
+	}
+
+	seq := clip.String()
+	if !strings.HasPrefix(seq, "\x1b]52;c;") || !strings.HasSuffix(seq, "\x07") {
+		t.Fatalf("clipboard = %q, want an OSC 52 sequence", seq)
+	}
+	copied, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(seq, "\x1b]52;c;"), "\x07"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loader, err := layout.NewLoader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loader.LoadAll(); err != nil {
+		t.Fatal(err)
+	}
+	l, _, err := loader.GetLayout("split-v")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := tmuxctl.Script(tmuxctl.CreatePlan("api", dir, layout.SessionLayout(l, dir, ""), "", p.Options))
+	if string(copied) != want {
+		t.Errorf("copied =\n%s\nwant the dry-run plan\n%s", copied, want)
+	}
+}
+
+// TestOSC52Tmux tests that the clipboard sequence is wrapped for tmux passthrough
+func TestOSC52Tmux(t *testing.T) {
+	got := osc52("hi", true)
+	want := "\x1bPtmux;\x1b\x1b]52;c;aGk=\x07\x1b\\"
+	if got != want {
+		t.Errorf("osc52() = %q, want %q", got, want)
+	}
+}
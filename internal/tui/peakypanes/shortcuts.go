@@ -0,0 +1,46 @@
+package peakypanes
+
+import (
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tui/ghosttyhelp"
+)
+
+// openShortcuts shows the terminal shortcut help over the list. The
+// terminal is picked like tmuxhelp does, from PEAKYPANES_TERM.
+func (m Model) openShortcuts() (tea.Model, tea.Cmd) {
+	t, _ := ghosttyhelp.ResolveTerminal("", os.Getenv("PEAKYPANES_TERM")) // unknown names fall back to the default
+	help := ghosttyhelp.NewModelFor(t)
+	m.shortcuts = &help
+	m.state = StateShortcuts
+	return m, nil
+}
+
+// updateShortcuts closes the overlay on esc, q or the toggle key and passes
+// everything else to the help model. The help model quits on its own close
+// keys when run standalone, so those never reach it here.
+func (m Model) updateShortcuts(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "ctrl+c", "i":
+		m.shortcuts = nil
+		m.state = StateHome
+		return m, nil
+	}
+	if m.shortcuts == nil {
+		m.state = StateHome
+		return m, nil
+	}
+	next, cmd := m.shortcuts.Update(msg)
+	help := next.(ghosttyhelp.Model)
+	m.shortcuts = &help
+	return m, cmd
+}
+
+func (m Model) viewShortcuts() string {
+	if m.shortcuts == nil {
+		return m.viewHome()
+	}
+	return appStyle.Render(m.shortcuts.View())
+}
@@ -0,0 +1,51 @@
+package peakypanes
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestShortcutsOverlay tests that the shortcut help opens over the list and
+// esc returns to it with the list untouched
+func TestShortcutsOverlay(t *testing.T) {
+	t.Setenv("PEAKYPANES_TERM", "")
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api"},
+		{Name: "web", Session: "web", Path: "/srv/web"},
+	})
+	m.list.Select(1)
+
+	model := press(t, *m, "i")
+	if model.state != StateShortcuts || model.shortcuts == nil {
+		t.Fatalf("state = %v, want the shortcut overlay", model.state)
+	}
+	if view := model.View(); !strings.Contains(view, "Cmd+I") {
+		t.Errorf("overlay view does not list shortcuts:\n%s", view)
+	}
+
+	next, cmd := model.Update(keyMsg("esc"))
+	model = next.(Model)
+	if cmd != nil {
+		t.Error("closing the overlay should not quit")
+	}
+	if model.state != StateHome || model.shortcuts != nil {
+		t.Errorf("state = %v after esc, want StateHome", model.state)
+	}
+	if model.list.Index() != 1 || len(model.list.Items()) != 2 {
+		t.Errorf("list changed underneath: index %d, %d items", model.list.Index(), len(model.list.Items()))
+	}
+}
+
+// TestShortcutsOverlayToggle tests that the open key also closes the overlay
+// and other keys leave it open
+func TestShortcutsOverlayToggle(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api"}})
+
+	model := press(t, *m, "i", "j")
+	if model.state != StateShortcuts {
+		t.Errorf("state = %v, want the overlay to stay open", model.state)
+	}
+	if model = press(t, model, "i"); model.state != StateHome {
+		t.Errorf("state = %v, want StateHome after toggling", model.state)
+	}
+}
@@ -0,0 +1,21 @@
+package peakypanes
+
+import (
+	"context"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+)
+
+// snapshotLayouts captures the window layouts of a session into the state
+// file so the next start of the project restores them.
+func (m *Model) snapshotLayouts(ctx context.Context, session string) error {
+	windows, err := m.tmux.WindowLayouts(ctx, session)
+	if err != nil {
+		return err
+	}
+	saved := make([]state.WindowLayout, 0, len(windows))
+	for _, w := range windows {
+		saved = append(saved, state.WindowLayout{Name: w.Name, Layout: w.Layout})
+	}
+	return state.Update(m.statePath, func(s *state.State) { s.SetLayouts(session, saved) })
+}
@@ -0,0 +1,45 @@
+package peakypanes
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+)
+
+// TestKillSnapshotsLayout tests that opted-in projects save their layout on kill
+func TestKillSnapshotsLayout(t *testing.T) {
+	for _, optIn := range []bool{true, false} {
+		tm, calls := newTestModel(t, []Project{
+			{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning, SnapshotLayout: optIn},
+		})
+		tm.statePath = filepath.Join(t.TempDir(), "state.yml")
+
+		press(t, *tm, "K", "y")
+
+		st, err := state.Load(tm.statePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, saved := st.Layouts["api"]
+		if saved != optIn {
+			t.Errorf("optIn=%v: layout saved = %v", optIn, saved)
+		}
+		// K first inspects the session's panes and windows; the snapshot
+		// must come before the kill
+		if optIn && calledBefore(calls.args, "kill-session") != "list-windows" {
+			t.Errorf("optIn: tmux calls = %q, want list-windows right before kill-session", calls.args)
+		}
+	}
+}
+
+// calledBefore returns the tmux subcommand issued right before the first
+// call to subcmd, or "" when there is none.
+func calledBefore(calls [][]string, subcmd string) string {
+	for i, args := range calls {
+		if args[0] == subcmd && i > 0 {
+			return calls[i-1][0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package peakypanes
+
+import (
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editorOpenedMsg is sent when the editor opened on a project exits.
+type editorOpenedMsg struct {
+	dir string
+	err error
+}
+
+// startAndEdit starts the selected project's session in the background and
+// then opens $EDITOR in the project directory, without attaching to tmux.
+// A running session is left alone and only the editor is opened.
+func (m Model) startAndEdit() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	switch {
+	case item.Status == StatusMissing:
+		return m, m.list.NewStatusMessage(FormatStatusWarning("Path not found: " + shortenPath(item.Path)))
+	case item.Status.running():
+		return m, m.openEditorCmd(item.Path)
+	}
+	dir := item.Path
+	return m, tea.Batch(
+		m.list.NewStatusMessage(FormatStatusInfo("Starting "+item.Session+" in background…")),
+		m.startDetachedThen(item, "", func(m Model) tea.Cmd { return m.openEditorCmd(dir) }),
+	)
+}
+
+// openEditorCmd opens $EDITOR on dir, with dir as its working directory.
+func (m Model) openEditorCmd(dir string) tea.Cmd {
+	if m.editorRunner != nil {
+		return m.editorRunner(dir)
+	}
+	args := editorArgs(os.Getenv("EDITOR"), dir)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorOpenedMsg{dir: dir, err: err}
+	})
+}
+
+// handleEditorOpened reports an editor that failed to run.
+func (m Model) handleEditorOpened(msg editorOpenedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(NewErrorMsg(msg.err, "open editor in "+shortenPath(msg.dir))))
+	}
+	return m, nil
+}
@@ -0,0 +1,82 @@
+package peakypanes
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recordStartEdit wires m to log background starts and editor launches
+// into the returned slice, in the order they happen.
+func recordStartEdit(m *Model, startErr error) *[]string {
+	var log []string
+	m.startRunner = func(args ...string) ([]byte, error) {
+		log = append(log, "start "+args[2])
+		return nil, startErr
+	}
+	m.editorRunner = func(dir string) tea.Cmd {
+		log = append(log, "editor "+dir)
+		return nil
+	}
+	m.list.StatusMessageLifetime = time.Millisecond
+	return &log
+}
+
+// TestStartAndEdit tests that E starts the session detached and then
+// opens the editor in the project dir
+func TestStartAndEdit(t *testing.T) {
+	dir := t.TempDir()
+	m, calls := newTestModel(t, []Project{{Name: "api", Session: "api", Path: dir}})
+	log := recordStartEdit(m, nil)
+
+	next, cmd := m.Update(keyMsg("E"))
+	if len(*log) != 0 {
+		t.Fatalf("E ran %q before its command was executed", *log)
+	}
+	for _, msg := range runCmd(cmd) {
+		if started, ok := msg.(startedThenMsg); ok {
+			next, _ = next.(Model).Update(started)
+		}
+	}
+	want := []string{"start api", "editor " + dir}
+	if len(*log) != 2 || (*log)[0] != want[0] || (*log)[1] != want[1] {
+		t.Errorf("actions = %q, want %q", *log, want)
+	}
+	if next.(Model).state != StateHome {
+		t.Errorf("state = %v, want StateHome", next.(Model).state)
+	}
+	for _, args := range calls.args {
+		if args[0] == "attach-session" || args[0] == "switch-client" {
+			t.Errorf("start & edit attached: %q", args)
+		}
+	}
+}
+
+// TestStartAndEditFailedStart tests that no editor opens when the start fails
+func TestStartAndEditFailedStart(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: t.TempDir()}})
+	log := recordStartEdit(m, errors.New("boom"))
+
+	next, cmd := m.Update(keyMsg("E"))
+	for _, msg := range runCmd(cmd) {
+		if started, ok := msg.(startedThenMsg); ok {
+			next.(Model).Update(started)
+		}
+	}
+	if len(*log) != 1 || (*log)[0] != "start api" {
+		t.Errorf("actions = %q, want only the start", *log)
+	}
+}
+
+// TestStartAndEditRunning tests that a running session only opens the editor
+func TestStartAndEditRunning(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	log := recordStartEdit(m, nil)
+
+	press(t, *m, "E")
+	if len(*log) != 1 || (*log)[0] != "editor /srv/api" {
+		t.Errorf("actions = %q, want only the editor", *log)
+	}
+}
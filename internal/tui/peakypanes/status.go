@@ -0,0 +1,62 @@
+package peakypanes
+
+// Exit codes of `peakypanes status`, so scripts can branch on a project's
+// state, e.g. `peakypanes status api || peakypanes open api`.
+const (
+	// ExitRunning: the session is live (running or current).
+	ExitRunning = 0
+	// ExitStopped: the project has no session.
+	ExitStopped = 1
+	// ExitMissing: the project has no session and its path is gone.
+	ExitMissing = 2
+	// ExitUnknownProject: no single configured project matches the query.
+	ExitUnknownProject = 3
+	// ExitStatusError: the status could not be determined, e.g. tmux failed.
+	ExitStatusError = 4
+)
+
+// String returns the lowercase name printed by `peakypanes status`.
+func (s Status) String() string {
+	switch s {
+	case StatusCurrent:
+		return "current"
+	case StatusRunning:
+		return "running"
+	case StatusStopped:
+		return "stopped"
+	case StatusMissing:
+		return "missing"
+	default:
+		return "unknown"
+	}
+}
+
+// ExitCode maps a status to the exit code of `peakypanes status`.
+func (s Status) ExitCode() int {
+	switch s {
+	case StatusCurrent, StatusRunning:
+		return ExitRunning
+	case StatusStopped:
+		return ExitStopped
+	case StatusMissing:
+		return ExitMissing
+	default:
+		return ExitStatusError
+	}
+}
+
+// ProjectStatus resolves the status of the configured project named by
+// query, as of the model's last refresh. Errors are those of FindProject.
+func (m *Model) ProjectStatus(query string) (Status, error) {
+	p, err := m.FindProject(query)
+	if err != nil {
+		return StatusStopped, err
+	}
+	return p.Status, nil
+}
+
+// Projects returns the configured projects in config order, with their
+// status as of the model's last refresh.
+func (m *Model) Projects() []Project {
+	return append([]Project(nil), m.projects...)
+}
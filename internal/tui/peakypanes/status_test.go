@@ -0,0 +1,50 @@
+package peakypanes
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestStatusExitCode tests the status-to-exit-code mapping
+func TestStatusExitCode(t *testing.T) {
+	tests := []struct {
+		status Status
+		name   string
+		code   int
+	}{
+		{StatusCurrent, "current", 0},
+		{StatusRunning, "running", 0},
+		{StatusStopped, "stopped", 1},
+		{StatusMissing, "missing", 2},
+		{Status(99), "unknown", ExitStatusError},
+	}
+	for _, tt := range tests {
+		if got := tt.status.String(); got != tt.name {
+			t.Errorf("Status(%d).String() = %q, want %q", tt.status, got, tt.name)
+		}
+		if got := tt.status.ExitCode(); got != tt.code {
+			t.Errorf("%s.ExitCode() = %d, want %d", tt.name, got, tt.code)
+		}
+	}
+}
+
+// TestProjectStatus tests resolving a project's status by name, session or alias
+func TestProjectStatus(t *testing.T) {
+	m := &Model{projects: []Project{
+		{Name: "API Gateway", Session: "api-gateway", Path: "/srv/api", Aliases: []string{"api"}, Status: StatusRunning},
+		{Name: "Web", Session: "web", Path: "/srv/web", Status: StatusMissing},
+		{Name: "stray", Session: "stray", Status: StatusRunning}, // unconfigured
+	}}
+
+	for query, want := range map[string]Status{"api": StatusRunning, "API-GATEWAY": StatusRunning, "web": StatusMissing} {
+		got, err := m.ProjectStatus(query)
+		if err != nil || got != want {
+			t.Errorf("ProjectStatus(%q) = %v, %v; want %v", query, got, err, want)
+		}
+	}
+	for _, query := range []string{"nope", "stray"} {
+		if _, err := m.ProjectStatus(query); !errors.Is(err, ErrNoProjectMatch) {
+			t.Errorf("ProjectStatus(%q) error = %v, want ErrNoProjectMatch", query, err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package peakypanes
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// switcherHotkeys are the keys handed out to running sessions in the
+// switcher, in order. Sessions beyond them are left out.
+const switcherHotkeys = "123456789abcdefghijklmnopqrstuvwxyz"
+
+// switchEntry is a running session reachable from the switcher.
+type switchEntry struct {
+	Key     string
+	Project Project
+}
+
+// switcherEntries assigns a hotkey to each running session of projects, in
+// list order. A session shared by several projects is listed once.
+func switcherEntries(projects []Project) []switchEntry {
+	var entries []switchEntry
+	seen := make(map[string]bool)
+	for _, p := range projects {
+		if len(entries) == len(switcherHotkeys) {
+			break
+		}
+		if !p.Status.running() || p.Session == "" || seen[p.Session] {
+			continue
+		}
+		seen[p.Session] = true
+		entries = append(entries, switchEntry{Key: switcherHotkeys[len(entries) : len(entries)+1], Project: p})
+	}
+	return entries
+}
+
+// switcherTarget returns the project whose hotkey is k.
+func switcherTarget(entries []switchEntry, k string) (Project, bool) {
+	for _, e := range entries {
+		if e.Key == k {
+			return e.Project, true
+		}
+	}
+	return Project{}, false
+}
+
+// openSwitcher shows the running sessions with their hotkeys.
+func (m Model) openSwitcher() (tea.Model, tea.Cmd) {
+	entries := switcherEntries(sortProjects(listedProjects(m.projects, m.showArchived), m.sortMode))
+	if len(entries) == 0 {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("No running sessions"))
+	}
+	m.switcher = entries
+	m.state = StateSwitcher
+	return m, nil
+}
+
+// updateSwitcher opens the session of a pressed hotkey right away. Every
+// letter may be a hotkey, so only esc closes the switcher.
+func (m Model) updateSwitcher(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.switcher = nil
+		m.state = StateHome
+		return m, nil
+	}
+	p, ok := switcherTarget(m.switcher, msg.String())
+	if !ok {
+		return m, nil
+	}
+	m.switcher = nil
+	m.state = StateHome
+	return m, m.attachProject(p)
+}
+
+func (m Model) viewSwitcher() string {
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("⇄ Switch Session"))
+	b.WriteString("\n\n")
+	for _, e := range m.switcher {
+		b.WriteString(theme.DialogChoiceKey.Render(e.Key))
+		b.WriteString("  ")
+		b.WriteString(theme.DialogValue.Render(e.Project.Name))
+		var extras []string
+		if e.Project.Session != e.Project.Name {
+			extras = append(extras, e.Project.Session)
+		}
+		if label := commandLabel(e.Project.Command); label != "" {
+			extras = append(extras, label)
+		}
+		if len(extras) > 0 {
+			b.WriteString(theme.DialogNote.Render("  " + strings.Join(extras, " • ")))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" close"))
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
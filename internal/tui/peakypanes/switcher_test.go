@@ -0,0 +1,89 @@
+package peakypanes
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/state"
+)
+
+// TestSwitcherEntries tests hotkey assignment to running sessions in list order
+func TestSwitcherEntries(t *testing.T) {
+	projects := []Project{
+		{Name: "api", Session: "api", Status: StatusRunning},
+		{Name: "web", Session: "web", Status: StatusStopped},
+		{Name: "cli", Session: "cli", Status: StatusCurrent},
+		{Name: "cli-alt", Session: "cli", Status: StatusCurrent},
+		{Name: "docs", Session: "docs", Status: StatusRunning},
+	}
+	var got []string
+	for _, e := range switcherEntries(projects) {
+		got = append(got, e.Key+"="+e.Project.Session)
+	}
+	want := []string{"1=api", "2=cli", "3=docs"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("switcherEntries() = %q, want %q", got, want)
+	}
+}
+
+// TestSwitcherEntriesLetters tests that hotkeys continue with letters after 9 and run out after z
+func TestSwitcherEntriesLetters(t *testing.T) {
+	var projects []Project
+	for i := 0; i < 40; i++ {
+		s := "s" + strconv.Itoa(i)
+		projects = append(projects, Project{Name: s, Session: s, Status: StatusRunning})
+	}
+	entries := switcherEntries(projects)
+	if len(entries) != len(switcherHotkeys) {
+		t.Fatalf("len = %d, want %d", len(entries), len(switcherHotkeys))
+	}
+	if e := entries[9]; e.Key != "a" || e.Project.Session != "s9" {
+		t.Errorf("entries[9] = %s=%s, want a=s9", e.Key, e.Project.Session)
+	}
+	if e := entries[len(entries)-1]; e.Key != "z" || e.Project.Session != "s34" {
+		t.Errorf("last entry = %s=%s, want z=s34", e.Key, e.Project.Session)
+	}
+}
+
+// TestSwitcherDispatch tests that a hotkey opens its session at once
+func TestSwitcherDispatch(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning},
+		{Name: "web", Session: "web", Path: "/srv/web", Status: StatusRunning},
+	})
+	m.statePath = filepath.Join(t.TempDir(), "state.yml")
+
+	model := press(t, *m, "w")
+	if model.state != StateSwitcher || len(model.switcher) != 2 {
+		t.Fatalf("state = %v, entries = %d, want the switcher with 2", model.state, len(model.switcher))
+	}
+
+	// Keys without a session are ignored
+	model = press(t, model, "5")
+	if model.state != StateSwitcher {
+		t.Fatalf("state = %v after an unassigned key, want StateSwitcher", model.state)
+	}
+
+	next, cmd := model.Update(keyMsg("2"))
+	model = next.(Model)
+	if model.state != StateHome || cmd == nil {
+		t.Fatalf("state = %v, cmd = %v; want StateHome and an attach", model.state, cmd)
+	}
+	st, err := state.Load(m.statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(st.Recent) == 0 || st.Recent[0] != "web" {
+		t.Errorf("recent = %q, want web opened", st.Recent)
+	}
+}
+
+// TestSwitcherNoRunning tests that the switcher stays closed without running sessions
+func TestSwitcherNoRunning(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusStopped}})
+	if model := press(t, *m, "w"); model.state != StateHome {
+		t.Errorf("state = %v, want StateHome", model.state)
+	}
+}
@@ -0,0 +1,29 @@
+package peakypanes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// themePreset resolves a theme preset name. An unknown name yields the
+// default palette and an error naming the presets there are.
+func themePreset(name string) (theme.Theme, error) {
+	if t, ok := theme.Preset(name); ok {
+		return t, nil
+	}
+	t, _ := theme.Preset(theme.DefaultPreset)
+	return t, fmt.Errorf("unknown theme %q (have %s)", name, strings.Join(theme.PresetNames(), ", "))
+}
+
+// applyTheme switches the styles to t, including the copies this package
+// keeps. It must run before the lists are set up, which copy styles too.
+func applyTheme(t theme.Theme) {
+	theme.Apply(t)
+	appStyle = theme.App
+	titleStyle = theme.Title
+	statusMessageStyle = theme.StatusMessage
+	dialogStyle = theme.Dialog
+	dialogTitleStyle = theme.DialogTitle
+}
@@ -0,0 +1,23 @@
+package peakypanes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// TestThemePresetFallback tests that an unknown theme falls back to the
+// default palette with a warning
+func TestThemePresetFallback(t *testing.T) {
+	if p, err := themePreset("gruvbox"); err != nil || p.Name != "gruvbox" {
+		t.Errorf("themePreset(gruvbox) = %q, %v", p.Name, err)
+	}
+	p, err := themePreset("neon")
+	if p.Name != theme.DefaultPreset {
+		t.Errorf("themePreset(neon) = %q, want the default palette", p.Name)
+	}
+	if err == nil || !strings.Contains(err.Error(), "dracula") {
+		t.Errorf("error = %v, want one listing the presets", err)
+	}
+}
@@ -0,0 +1,57 @@
+package peakypanes
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// titleData holds the placeholders available to title_format. The default
+// format is equivalent to "{{.Icon}} {{.Name}}".
+type titleData struct {
+	Icon    string // status glyph, e.g. ●, tinted by the project color
+	Name    string // project name, colored by its primary tag
+	Session string // tmux session name, without the session prefix
+	Status  string // current, running, stopped or missing
+}
+
+// parseTitleFormat compiles a title_format template. An empty format yields
+// nil, meaning the default. The template is test-rendered so that unknown
+// placeholders are reported at load time rather than on every row.
+func parseTitleFormat(format string) (*template.Template, error) {
+	if strings.TrimSpace(format) == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("title").Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("title_format: %w", err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, titleData{}); err != nil {
+		return nil, fmt.Errorf("title_format: %w", err)
+	}
+	return tmpl, nil
+}
+
+// renderTitle renders the row title of p with tmpl, falling back to the
+// default format when tmpl is nil or fails.
+func renderTitle(p Project, tmpl *template.Template) string {
+	data := titleData{
+		Icon:    iconStyle(p).Render(statusIcon(p.Status, p.glyphs)),
+		Name:    p.Name,
+		Session: p.displaySession(),
+		Status:  p.Status.String(),
+	}
+	if p.nameColor != "" {
+		data.Name = lipgloss.NewStyle().Foreground(p.nameColor).Render(p.Name)
+	}
+	if tmpl != nil {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err == nil {
+			return b.String()
+		}
+	}
+	return data.Icon + " " + data.Name
+}
@@ -0,0 +1,61 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRenderTitleCustom tests a couple of custom title templates
+func TestRenderTitleCustom(t *testing.T) {
+	p := Project{Name: "api", Session: "api-gw", Status: StatusRunning}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", "● api"},
+		{"{{.Name}} {{.Icon}}", "api ●"},
+		{"{{.Icon}} {{.Name}} ({{.Session}})", "● api (api-gw)"},
+		{"[{{.Status}}] {{.Name}}", "[running] api"},
+	}
+	for _, tt := range tests {
+		tmpl, err := parseTitleFormat(tt.format)
+		if err != nil {
+			t.Fatalf("parseTitleFormat(%q) error: %v", tt.format, err)
+		}
+		p.titleTmpl = tmpl
+		if got := p.Title(); got != tt.want {
+			t.Errorf("Title() with %q = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+// TestParseTitleFormatInvalid tests that broken templates are rejected
+func TestParseTitleFormatInvalid(t *testing.T) {
+	for _, format := range []string{"{{.Name", "{{.Owner}}", "{{template \"x\"}}"} {
+		if tmpl, err := parseTitleFormat(format); err == nil || tmpl != nil {
+			t.Errorf("parseTitleFormat(%q) = %v, %v; want error", format, tmpl, err)
+		}
+	}
+}
+
+// TestTitleFormatFallback tests that an invalid config template warns and keeps the default
+func TestTitleFormatFallback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	content := "title_format: \"{{.Owner}} {{.Name}}\"\nprojects:\n  - name: api\n    path: /srv/api\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := newTestModel(t, nil)
+	m.configPath = path
+	if err := m.loadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if w := m.configWarning(); !strings.Contains(w, "title_format") {
+		t.Errorf("configWarning() = %q, want a title_format warning", w)
+	}
+	if title := m.projectsToItems()[0].(Project).Title(); title != "○ api" {
+		t.Errorf("Title() = %q, want default format", title)
+	}
+}
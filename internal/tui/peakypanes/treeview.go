@@ -0,0 +1,175 @@
+package peakypanes
+
+import (
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// treeIndent is the indentation of one tree level.
+const treeIndent = "  "
+
+// dirNode is a directory of the tree view. entries hold its
+// subdirectories and projects in the order they first appear.
+type dirNode struct {
+	dir     string
+	label   string
+	entries []treeEntry
+	// projects counts the projects in the whole subtree.
+	projects int
+}
+
+// treeEntry is a subdirectory, or the index of a project when node is nil.
+type treeEntry struct {
+	node    *dirNode
+	project int
+}
+
+// treeRow is one row of the flattened tree: a directory header or the
+// index of a project, depth levels deep. dir is the directory a project
+// row is listed under.
+type treeRow struct {
+	header  *pathHeader
+	project int
+	depth   int
+	dir     string
+}
+
+// buildDirTree arranges paths under the directories holding them, rooted
+// at the deepest directory containing all of them. Chains of directories
+// with a single subdirectory and no projects are merged into one node
+// labelled "a/b". Indexes of empty paths are left out; nil is returned
+// when no path is set.
+func buildDirTree(paths []string) *dirNode {
+	var root string
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		dir := filepath.Dir(filepath.Clean(p))
+		if root == "" {
+			root = dir
+		} else {
+			root = commonDir(root, dir)
+		}
+	}
+	if root == "" {
+		return nil
+	}
+
+	tree := &dirNode{dir: root, label: shortenPath(root)}
+	nodes := map[string]*dirNode{root: tree}
+	var nodeFor func(dir string) *dirNode
+	nodeFor = func(dir string) *dirNode {
+		if n, ok := nodes[dir]; ok {
+			return n
+		}
+		parent := nodeFor(filepath.Dir(dir))
+		n := &dirNode{dir: dir, label: filepath.Base(dir)}
+		parent.entries = append(parent.entries, treeEntry{node: n})
+		nodes[dir] = n
+		return n
+	}
+	for i, p := range paths {
+		if p == "" {
+			continue
+		}
+		n := nodeFor(filepath.Dir(filepath.Clean(p)))
+		n.entries = append(n.entries, treeEntry{project: i})
+	}
+	tree.compact()
+	return tree
+}
+
+// commonDir returns the deepest directory containing both a and b.
+func commonDir(a, b string) string {
+	for a != b {
+		if len(a) > len(b) {
+			a = filepath.Dir(a)
+		} else {
+			b = filepath.Dir(b)
+		}
+	}
+	return a
+}
+
+// compact merges single-child directory chains below n and counts the
+// projects of every subtree.
+func (n *dirNode) compact() {
+	n.projects = 0
+	for i, e := range n.entries {
+		if e.node == nil {
+			n.projects++
+			continue
+		}
+		child := e.node
+		for len(child.entries) == 1 && child.entries[0].node != nil {
+			next := child.entries[0].node
+			next.label = child.label + "/" + next.label
+			child = next
+		}
+		child.compact()
+		n.entries[i].node = child
+		n.projects += child.projects
+	}
+}
+
+// rows flattens the tree below n, starting at depth. The subtrees of
+// directories in collapsed are left out, only their header is shown.
+func (n *dirNode) rows(depth int, collapsed map[string]bool) []treeRow {
+	folded := collapsed[n.dir]
+	rows := []treeRow{{header: &pathHeader{
+		Prefix:    n.dir,
+		Label:     n.label,
+		Depth:     depth,
+		Projects:  n.projects,
+		Collapsed: folded,
+	}, depth: depth}}
+	if folded {
+		return rows
+	}
+	for _, e := range n.entries {
+		if e.node != nil {
+			rows = append(rows, e.node.rows(depth+1, collapsed)...)
+			continue
+		}
+		rows = append(rows, treeRow{project: e.project, depth: depth + 1, dir: n.dir})
+	}
+	return rows
+}
+
+// treeRows returns the tree view of projects: the directory tree of the
+// projects with a path, then those without one at the top level.
+func treeRows(projects []Project, collapsed map[string]bool) []treeRow {
+	paths := make([]string, len(projects))
+	for i, p := range projects {
+		paths[i] = p.Path
+	}
+	var rows []treeRow
+	if tree := buildDirTree(paths); tree != nil {
+		rows = tree.rows(0, collapsed)
+	}
+	for i, p := range projects {
+		if p.Path == "" {
+			rows = append(rows, treeRow{project: i})
+		}
+	}
+	return rows
+}
+
+// indent returns the indentation of a row depth levels deep.
+func indent(depth int) string {
+	return strings.Repeat(treeIndent, depth)
+}
+
+// toggleTreeView switches the list between the flat and the tree view.
+func (m Model) toggleTreeView() (tea.Model, tea.Cmd) {
+	m.treeView = !m.treeView
+	m.savePreferences()
+	m.list.SetItems(m.projectsToItems())
+	if m.treeView {
+		return m, m.list.NewStatusMessage(FormatStatusInfo("Tree view"))
+	}
+	return m, m.list.NewStatusMessage(FormatStatusInfo("Flat list"))
+}
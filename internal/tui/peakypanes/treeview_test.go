@@ -0,0 +1,138 @@
+package peakypanes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// describeTree renders n as "label[projects](entries...)" with project
+// entries as their index, for compact comparisons.
+func describeTree(n *dirNode) string {
+	var parts []string
+	for _, e := range n.entries {
+		if e.node != nil {
+			parts = append(parts, describeTree(e.node))
+		} else {
+			parts = append(parts, string(rune('0'+e.project)))
+		}
+	}
+	return n.dir + "[" + string(rune('0'+n.projects)) + "](" + strings.Join(parts, " ") + ")"
+}
+
+// TestBuildDirTree tests building the directory tree from project paths
+func TestBuildDirTree(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  string
+	}{
+		{
+			name:  "siblings",
+			paths: []string{"/code/api", "/code/web/"},
+			want:  "/code[2](0 1)",
+		},
+		{
+			name:  "nested directories",
+			paths: []string{"/code/go/api", "/code/js/web", "/code/go/cli", "/code/notes"},
+			want:  "/code[4](/code/go[2](0 2) /code/js[1](1) 3)",
+		},
+		{
+			name:  "single child chains are merged",
+			paths: []string{"/code/a/b/c/api", "/code/x/web"},
+			want:  "/code[2](/code/a/b/c[1](0) /code/x[1](1))",
+		},
+		{
+			name:  "paths without a path are left out",
+			paths: []string{"", "/srv/api", ""},
+			want:  "/srv[1](1)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := buildDirTree(tt.paths)
+			if tree == nil {
+				t.Fatal("buildDirTree() = nil")
+			}
+			if got := describeTree(tree); got != tt.want {
+				t.Errorf("buildDirTree() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if buildDirTree([]string{"", ""}) != nil {
+		t.Error("buildDirTree() without paths should be nil")
+	}
+}
+
+// TestBuildDirTreeLabels tests that merged chains are labelled with their
+// joined names
+func TestBuildDirTreeLabels(t *testing.T) {
+	tree := buildDirTree([]string{"/code/a/b/api", "/code/x/web"})
+	var labels []string
+	for _, e := range tree.entries {
+		labels = append(labels, e.node.label)
+	}
+	if !reflect.DeepEqual(labels, []string{"a/b", "x"}) {
+		t.Errorf("labels = %q, want [a/b x]", labels)
+	}
+}
+
+// TestTreeRowsCollapsed tests flattening with a folded directory
+func TestTreeRowsCollapsed(t *testing.T) {
+	projects := []Project{
+		{Name: "api", Path: "/code/go/api"},
+		{Name: "web", Path: "/code/js/web"},
+		{Name: "adhoc", Session: "adhoc"},
+	}
+	rows := treeRows(projects, map[string]bool{"/code/go": true})
+	var got []string
+	for _, r := range rows {
+		if r.header != nil {
+			got = append(got, indent(r.depth)+r.header.Label+"/")
+		} else {
+			got = append(got, indent(r.depth)+projects[r.project].Name)
+		}
+	}
+	want := []string{"/code/", "  go/", "  js/", "    web", "adhoc"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+	if !rows[1].header.Collapsed || rows[1].header.Projects != 1 {
+		t.Errorf("go header = %+v, want collapsed with 1 project", rows[1].header)
+	}
+}
+
+// TestTreeViewKey tests that T switches to the tree and selecting a project
+// row still opens that project
+func TestTreeViewKey(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/code/go/api"},
+		{Name: "web", Session: "web", Path: "/code/js/web"},
+	})
+	model := press(t, *m, "T")
+	if !model.treeView {
+		t.Fatal("T should turn the tree view on")
+	}
+	items := model.list.Items()
+	if len(items) != 5 {
+		t.Fatalf("items = %d, want 3 directories and 2 projects", len(items))
+	}
+	p, ok := items[2].(Project)
+	if !ok || p.Name != "api" || p.depth != 2 || p.pathGroup != "/code/go" {
+		t.Fatalf("items[2] = %+v, want api two levels deep", items[2])
+	}
+	if !strings.HasPrefix(p.Title(), indent(2)) {
+		t.Errorf("Title() = %q, want it indented", p.Title())
+	}
+
+	model.list.Select(1)
+	model = press(t, model, "enter")
+	if len(model.list.Items()) != 4 {
+		t.Errorf("enter on a directory should fold it, items = %d", len(model.list.Items()))
+	}
+
+	model = press(t, model, "T")
+	if model.treeView || len(model.list.Items()) != 2 {
+		t.Errorf("T again should restore the flat list, items = %d", len(model.list.Items()))
+	}
+}
@@ -0,0 +1,57 @@
+package peakypanes
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// undoWindow is how long a kill can be undone.
+const undoWindow = 8 * time.Second
+
+// killUndo remembers the last killed project so it can be recreated.
+type killUndo struct {
+	project Project
+	// seq identifies the kill so an expiry tick from an earlier kill
+	// cannot close a newer undo window.
+	seq int
+}
+
+// undoExpiredMsg closes the undo window of the kill with the same seq.
+type undoExpiredMsg struct {
+	seq int
+}
+
+// offerUndo records p for undo and starts the undo window. Sessions without
+// a configured path cannot be recreated and get no undo.
+func (m *Model) offerUndo(p Project) tea.Cmd {
+	m.undoSeq++
+	if p.Path == "" {
+		m.undo = nil
+		return nil
+	}
+	m.undo = &killUndo{project: p, seq: m.undoSeq}
+	seq := m.undoSeq
+	return tea.Tick(undoWindow, func(time.Time) tea.Msg {
+		return undoExpiredMsg{seq: seq}
+	})
+}
+
+// handleUndoExpired closes the undo window if msg belongs to it.
+func (m Model) handleUndoExpired(msg undoExpiredMsg) (tea.Model, tea.Cmd) {
+	if m.undo != nil && m.undo.seq == msg.seq {
+		m.undo = nil
+	}
+	return m, nil
+}
+
+// undoKill recreates the last killed session in the background. The start
+// command restores the saved layout snapshot when the project keeps one.
+func (m Model) undoKill() (tea.Model, tea.Cmd) {
+	u := m.undo
+	m.undo = nil
+	if u == nil {
+		return m, nil
+	}
+	return m.recreateSession(u.project)
+}
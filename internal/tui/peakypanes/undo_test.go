@@ -0,0 +1,105 @@
+package peakypanes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runCmd executes cmd and any batched commands, collecting their messages.
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, runCmd(c)...)
+	}
+	return msgs
+}
+
+// TestKillRecordsUndo tests that a kill remembers the project for undo
+func TestKillRecordsUndo(t *testing.T) {
+	p := Project{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}
+	m, _ := newTestModel(t, []Project{p})
+
+	next, cmd := m.killSession(p)
+	model := next.(Model)
+	if model.undo == nil || model.undo.project.Session != "api" {
+		t.Fatalf("undo = %+v, want the killed project", model.undo)
+	}
+	if cmd == nil {
+		t.Error("a kill should start the undo window")
+	}
+
+	// Unconfigured sessions cannot be recreated
+	next, _ = model.killSession(Project{Name: "scratchpad", Session: "scratchpad", Status: StatusRunning})
+	if next.(Model).undo != nil {
+		t.Error("a session without a path should not offer undo")
+	}
+}
+
+// TestUndoRecreatesSession tests that u within the window starts the killed
+// session again in the background
+func TestUndoRecreatesSession(t *testing.T) {
+	p := Project{Name: "api", Session: "api", Path: "/srv/api", Layout: "dev-3", Status: StatusRunning}
+	m, _ := newTestModel(t, []Project{p})
+	var started [][]string
+	m.startRunner = func(args ...string) ([]byte, error) {
+		started = append(started, args)
+		return nil, nil
+	}
+	m.list.StatusMessageLifetime = time.Millisecond
+
+	next, _ := m.killSession(p)
+	next, cmd := next.(Model).Update(keyMsg("u"))
+	if next.(Model).undo != nil {
+		t.Error("undo should be used up")
+	}
+
+	var got SessionStartedMsg
+	for _, msg := range runCmd(cmd) {
+		if s, ok := msg.(SessionStartedMsg); ok {
+			got = s
+		}
+	}
+	if got.Session != "api" {
+		t.Errorf("undo did not report a started session: %+v", got)
+	}
+	want := [][]string{{"start", "--session", "api", "--path", "/srv/api", "--layout", "dev-3", "--detach"}}
+	if !reflect.DeepEqual(started, want) {
+		t.Errorf("started = %q, want %q", started, want)
+	}
+}
+
+// TestUndoWindowCloses tests that the undo expires after the timeout or the
+// next action
+func TestUndoWindowCloses(t *testing.T) {
+	p := Project{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}
+	m, _ := newTestModel(t, []Project{p})
+
+	next, _ := m.killSession(p)
+	model := next.(Model)
+	seq := model.undo.seq
+
+	next, _ = model.Update(undoExpiredMsg{seq: seq - 1})
+	if next.(Model).undo == nil {
+		t.Error("a stale expiry should not close a newer undo window")
+	}
+	next, _ = model.Update(undoExpiredMsg{seq: seq})
+	if next.(Model).undo != nil {
+		t.Error("undo should expire after the timeout")
+	}
+
+	next, _ = model.Update(keyMsg("s"))
+	if next.(Model).undo != nil {
+		t.Error("the next action should close the undo window")
+	}
+}
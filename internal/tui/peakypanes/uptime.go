@@ -0,0 +1,59 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// formatUptime renders how long a session has been alive using its two
+// most significant units, e.g. "up 45s", "up 3h 12m" or "up 2d 4h".
+func formatUptime(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	d = d.Truncate(time.Second)
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	seconds := int(d % time.Minute / time.Second)
+
+	switch {
+	case days > 0:
+		if hours == 0 {
+			return fmt.Sprintf("up %dd", days)
+		}
+		return fmt.Sprintf("up %dd %dh", days, hours)
+	case hours > 0:
+		if minutes == 0 {
+			return fmt.Sprintf("up %dh", hours)
+		}
+		return fmt.Sprintf("up %dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("up %dm", minutes)
+	default:
+		return fmt.Sprintf("up %ds", seconds)
+	}
+}
+
+// clock returns the current time, using the injected clock in tests.
+func (m *Model) clock() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+// refreshUptimes records how long each running project's session has been
+// alive. It is best effort: on error the previous values are cleared.
+func (m *Model) refreshUptimes(ctx context.Context) {
+	created, _ := m.tmux.SessionsCreated(ctx)
+	now := m.clock()
+	for i := range m.projects {
+		p := &m.projects[i]
+		p.Uptime = 0
+		if at, ok := created[p.Session]; ok && p.Status.running() {
+			p.Uptime = now.Sub(at)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+// TestFormatUptime tests uptime formatting across seconds, hours and days
+func TestFormatUptime(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, ""},
+		{-time.Minute, ""},
+		{1500 * time.Millisecond, "up 1s"},
+		{45 * time.Second, "up 45s"},
+		{5*time.Minute + 30*time.Second, "up 5m"},
+		{time.Hour, "up 1h"},
+		{3*time.Hour + 12*time.Minute + 9*time.Second, "up 3h 12m"},
+		{24 * time.Hour, "up 1d"},
+		{2*24*time.Hour + 4*time.Hour + 59*time.Minute, "up 2d 4h"},
+		{400 * 24 * time.Hour, "up 400d"},
+	}
+	for _, tt := range tests {
+		if got := formatUptime(tt.d); got != tt.want {
+			t.Errorf("formatUptime(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+// TestRefreshUptimes tests that uptimes are computed from the injected clock
+func TestRefreshUptimes(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning},
+		{Name: "web", Session: "web", Path: "/srv/web", Status: StatusStopped},
+	})
+	created := time.Unix(1700000000, 0)
+	m.now = func() time.Time { return created.Add(52 * time.Hour) }
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "printf", "api\t1700000000\nweb\t1700000000\n")
+	})
+
+	m.refreshUptimes(context.Background())
+	if m.projects[0].Uptime != 52*time.Hour {
+		t.Errorf("api uptime = %v, want 52h", m.projects[0].Uptime)
+	}
+	if m.projects[1].Uptime != 0 {
+		t.Errorf("stopped project uptime = %v, want 0", m.projects[1].Uptime)
+	}
+	if desc := m.projects[0].Description(); desc != "/srv/api · up 2d 4h" {
+		t.Errorf("Description() = %q", desc)
+	}
+}
@@ -0,0 +1,151 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// process is one entry of the process table.
+type process struct {
+	PID  int
+	PPID int
+	// CPU is the share of one core in percent, as reported by ps.
+	CPU float64
+	// RSS is the resident memory in bytes.
+	RSS int64
+}
+
+// processLister lists every process on the system.
+type processLister func(ctx context.Context) ([]process, error)
+
+// psProcesses lists processes with ps, which works on Linux and macOS.
+func psProcesses(ctx context.Context) ([]process, error) {
+	out, err := exec.CommandContext(ctx, "ps", "-A", "-o", "pid=,ppid=,pcpu=,rss=").Output()
+	if err != nil {
+		return nil, fmt.Errorf("ps: %w", err)
+	}
+	return parseProcesses(string(out)), nil
+}
+
+// parseProcesses reads "pid ppid pcpu rss" lines as printed by ps, with rss
+// in KiB. Malformed lines are skipped.
+func parseProcesses(out string) []process {
+	var procs []process
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		cpu, err3 := strconv.ParseFloat(fields[2], 64)
+		rss, err4 := strconv.ParseInt(fields[3], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			continue
+		}
+		procs = append(procs, process{PID: pid, PPID: ppid, CPU: cpu, RSS: rss * 1024})
+	}
+	return procs
+}
+
+// usage is the combined CPU and memory of a session's processes.
+type usage struct {
+	CPU    float64
+	Memory int64
+}
+
+// sessionUsage adds up the pane processes in roots and everything they
+// started. Each process is counted once.
+func sessionUsage(procs []process, roots []int) usage {
+	byPID := make(map[int]process, len(procs))
+	children := make(map[int][]int)
+	for _, p := range procs {
+		byPID[p.PID] = p
+		children[p.PPID] = append(children[p.PPID], p.PID)
+	}
+
+	var u usage
+	seen := make(map[int]bool)
+	queue := append([]int{}, roots...)
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		if seen[pid] {
+			continue
+		}
+		seen[pid] = true
+		if p, ok := byPID[pid]; ok {
+			u.CPU += p.CPU
+			u.Memory += p.RSS
+		}
+		queue = append(queue, children[pid]...)
+	}
+	return u
+}
+
+// formatUsage renders u compactly, e.g. "cpu 12% mem 340MB".
+func formatUsage(u usage) string {
+	return fmt.Sprintf("cpu %.0f%% mem %s", u.CPU, formatMemory(u.Memory))
+}
+
+// formatMemory renders a byte count in MB, or in GB from 1GB up.
+func formatMemory(b int64) string {
+	const mb = 1024 * 1024
+	if b >= 1024*mb {
+		return fmt.Sprintf("%.1fGB", float64(b)/(1024*mb))
+	}
+	return fmt.Sprintf("%dMB", b/mb)
+}
+
+// refreshUsage records the CPU and memory of each running project's
+// session while usage is shown. It is best effort: on error the values are
+// cleared.
+func (m *Model) refreshUsage(ctx context.Context) {
+	for i := range m.projects {
+		m.projects[i].Usage = nil
+	}
+	if !m.showUsage {
+		return
+	}
+	pids, err := m.tmux.PanePIDs(ctx)
+	if err != nil || len(pids) == 0 {
+		return
+	}
+	list := m.processLister
+	if list == nil {
+		list = psProcesses
+	}
+	procs, err := list(ctx)
+	if err != nil {
+		return
+	}
+	for i := range m.projects {
+		p := &m.projects[i]
+		if roots := pids[p.Session]; len(roots) > 0 && p.Status.running() {
+			u := sessionUsage(procs, roots)
+			p.Usage = &u
+		}
+	}
+}
+
+// toggleUsage shows or hides session CPU and memory. Reading them walks the
+// process table, so it is off by default.
+func (m Model) toggleUsage() (tea.Model, tea.Cmd) {
+	m.showUsage = !m.showUsage
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	m.refreshUsage(ctx)
+	index := m.list.Index()
+	m.list.SetItems(m.projectsToItems())
+	m.list.Select(index)
+	if m.showUsage {
+		return m, m.list.NewStatusMessage(FormatStatusInfo("Showing CPU and memory"))
+	}
+	return m, m.list.NewStatusMessage(FormatStatusInfo("Hiding CPU and memory"))
+}
@@ -0,0 +1,116 @@
+package peakypanes
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseProcesses(t *testing.T) {
+	out := "    1     0  0.0  1024\n  101     1 12.5  2048\n\nbroken line\n  x 1 0.0 10\n"
+	got := parseProcesses(out)
+	want := []process{
+		{PID: 1, PPID: 0, CPU: 0, RSS: 1024 * 1024},
+		{PID: 101, PPID: 1, CPU: 12.5, RSS: 2048 * 1024},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseProcesses() = %+v, want %+v", got, want)
+	}
+}
+
+// TestSessionUsage tests pane processes and their descendants are summed
+// once, and other processes are left out
+func TestSessionUsage(t *testing.T) {
+	const mb = 1024 * 1024
+	procs := []process{
+		{PID: 100, PPID: 1, CPU: 0.5, RSS: 5 * mb},    // pane shell
+		{PID: 110, PPID: 100, CPU: 80, RSS: 300 * mb}, // dev server
+		{PID: 111, PPID: 110, CPU: 10, RSS: 35 * mb},  // its worker
+		{PID: 200, PPID: 1, CPU: 1, RSS: 8 * mb},      // second pane
+		{PID: 300, PPID: 1, CPU: 99, RSS: 900 * mb},   // another session
+	}
+
+	tests := []struct {
+		name  string
+		roots []int
+		want  usage
+	}{
+		{name: "tree", roots: []int{100}, want: usage{CPU: 90.5, Memory: 340 * mb}},
+		{name: "two panes", roots: []int{100, 200}, want: usage{CPU: 91.5, Memory: 348 * mb}},
+		{name: "counted once", roots: []int{100, 110}, want: usage{CPU: 90.5, Memory: 340 * mb}},
+		{name: "exited pane", roots: []int{999}, want: usage{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sessionUsage(procs, tt.roots); got != tt.want {
+				t.Errorf("sessionUsage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatUsage(t *testing.T) {
+	const mb = 1024 * 1024
+	tests := []struct {
+		u    usage
+		want string
+	}{
+		{u: usage{CPU: 12.4, Memory: 340 * mb}, want: "cpu 12% mem 340MB"},
+		{u: usage{CPU: 0, Memory: 0}, want: "cpu 0% mem 0MB"},
+		{u: usage{CPU: 150, Memory: 1536 * mb}, want: "cpu 150% mem 1.5GB"},
+	}
+	for _, tt := range tests {
+		if got := formatUsage(tt.u); got != tt.want {
+			t.Errorf("formatUsage(%+v) = %q, want %q", tt.u, got, tt.want)
+		}
+	}
+}
+
+// TestToggleUsage tests usage is only read and shown while toggled on
+func TestToggleUsage(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning},
+		{Name: "web", Session: "web", Path: "/srv/web", Status: StatusStopped},
+	})
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "printf", "api\t100\n")
+	})
+	listed := 0
+	m.processLister = func(ctx context.Context) ([]process, error) {
+		listed++
+		return []process{
+			{PID: 100, PPID: 1, CPU: 2, RSS: 10 * 1024 * 1024},
+			{PID: 101, PPID: 100, CPU: 10, RSS: 330 * 1024 * 1024},
+		}, nil
+	}
+
+	m.refreshUsage(context.Background())
+	if listed != 0 || m.projects[0].Usage != nil {
+		t.Fatalf("usage read while hidden: listed %d, usage %v", listed, m.projects[0].Usage)
+	}
+
+	model := press(t, *m, "U")
+	if listed != 1 {
+		t.Fatalf("process table listed %d times, want 1", listed)
+	}
+	if desc := model.projects[0].Description(); !strings.Contains(desc, "cpu 12% mem 340MB") {
+		t.Errorf("Description() = %q, want usage", desc)
+	}
+	if model.projects[1].Usage != nil {
+		t.Errorf("stopped project usage = %+v, want none", model.projects[1].Usage)
+	}
+
+	model.processLister = func(ctx context.Context) ([]process, error) { return nil, errors.New("no ps") }
+	model.refreshUsage(context.Background())
+	if model.projects[0].Usage != nil {
+		t.Errorf("usage = %+v after a failed read, want none", model.projects[0].Usage)
+	}
+
+	model = press(t, model, "U")
+	if strings.Contains(model.projects[0].Description(), "cpu") {
+		t.Errorf("Description() = %q after toggling off", model.projects[0].Description())
+	}
+}
@@ -0,0 +1,154 @@
+package peakypanes
+
+import (
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// configReloadDelay debounces config changes: editors often write a file
+// several times in a row (truncate, write, rename), and only the last write
+// should trigger a reload.
+const configReloadDelay = 300 * time.Millisecond
+
+// configWatcher reports changes to the config file and projects_dir.
+type configWatcher struct {
+	events <-chan fsnotify.Event
+	errors <-chan error
+	// configPath is the watched config file; projectsDir may be empty.
+	configPath  string
+	projectsDir string
+	// close stops the underlying watcher; nil for test watchers.
+	close func() error
+}
+
+// configChangedMsg is sent for every relevant file event.
+type configChangedMsg struct{}
+
+// configReloadMsg fires once the config has been quiet for
+// configReloadDelay. seq identifies the change it was scheduled for so only
+// the last of a burst reloads.
+type configReloadMsg struct {
+	seq int
+}
+
+// newConfigWatcher watches the directories of configPath and projectsDir.
+// Directories are watched rather than files so editors that replace the
+// file on save keep being followed.
+func newConfigWatcher(configPath, projectsDir string) (*configWatcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Add(filepath.Dir(configPath)); err != nil {
+		w.Close()
+		return nil, err
+	}
+	if projectsDir != "" && pathExists(projectsDir) {
+		if err := w.Add(projectsDir); err != nil {
+			w.Close()
+			return nil, err
+		}
+	}
+	return &configWatcher{
+		events:      w.Events,
+		errors:      w.Errors,
+		configPath:  filepath.Clean(configPath),
+		projectsDir: filepath.Clean(projectsDir),
+		close:       w.Close,
+	}, nil
+}
+
+// relevant reports whether ev changes the config file or a project file.
+func (w *configWatcher) relevant(ev fsnotify.Event) bool {
+	if ev.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Clean(ev.Name)
+	if name == w.configPath {
+		return true
+	}
+	if w.projectsDir == "" || w.projectsDir == "." || filepath.Dir(name) != w.projectsDir {
+		return false
+	}
+	switch filepath.Ext(name) {
+	case ".yml", ".yaml":
+		return true
+	}
+	return false
+}
+
+// wait blocks until the next relevant event. Watcher errors are skipped;
+// a closed watcher ends the wait.
+func (w *configWatcher) wait() tea.Cmd {
+	return func() tea.Msg {
+		for {
+			select {
+			case ev, ok := <-w.events:
+				if !ok {
+					return nil
+				}
+				if w.relevant(ev) {
+					return configChangedMsg{}
+				}
+			case _, ok := <-w.errors:
+				if !ok {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// watchConfig returns the command waiting for the next config change, or
+// nil when watching is off.
+func (m Model) watchConfig() tea.Cmd {
+	if m.watcher == nil {
+		return nil
+	}
+	return m.watcher.wait()
+}
+
+// handleConfigChanged restarts the debounce timer and keeps watching.
+func (m Model) handleConfigChanged() (tea.Model, tea.Cmd) {
+	m.reloadSeq++
+	seq := m.reloadSeq
+	reload := tea.Tick(configReloadDelay, func(time.Time) tea.Msg {
+		return configReloadMsg{seq: seq}
+	})
+	return m, tea.Batch(reload, m.watchConfig())
+}
+
+// handleConfigReload reloads projects after the last change of a burst,
+// keeping the selected project selected. Unsaved edits waiting for
+// confirmation are not overwritten.
+func (m Model) handleConfigReload(msg configReloadMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.reloadSeq || m.state == StateConfirmSave {
+		return m, nil
+	}
+	return m.reloadProjects()
+}
+
+// reloadProjects reloads the config and refreshes the list, keeping the
+// selected project selected.
+func (m Model) reloadProjects() (tea.Model, tea.Cmd) {
+	var selected string
+	if p, ok := m.list.SelectedItem().(Project); ok {
+		selected = p.Session
+	}
+	if err := m.loadConfig(); err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	m.resize() // footer or logo settings may have changed
+	if err := m.refreshStatuses(); err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	m.list.SetItems(m.projectsToItems())
+	m.selectSession(selected)
+	if w := m.configWarning(); w != "" {
+		return m, m.list.NewStatusMessage(FormatStatusWarning(w))
+	}
+	return m, m.list.NewStatusMessage(FormatStatusInfo("Config reloaded"))
+}
@@ -0,0 +1,129 @@
+package peakypanes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// TestConfigWatcherEvents tests that only writes to the config file or
+// project files trigger a change
+func TestConfigWatcherEvents(t *testing.T) {
+	events := make(chan fsnotify.Event, 1)
+	w := &configWatcher{
+		events:      events,
+		configPath:  "/cfg/config.yml",
+		projectsDir: "/cfg/projects",
+	}
+
+	tests := []struct {
+		name string
+		ev   fsnotify.Event
+		want bool
+	}{
+		{name: "config write", ev: fsnotify.Event{Name: "/cfg/config.yml", Op: fsnotify.Write}, want: true},
+		{name: "config replaced", ev: fsnotify.Event{Name: "/cfg/config.yml", Op: fsnotify.Create}, want: true},
+		{name: "config chmod", ev: fsnotify.Event{Name: "/cfg/config.yml", Op: fsnotify.Chmod}},
+		{name: "editor swap file", ev: fsnotify.Event{Name: "/cfg/.config.yml.swp", Op: fsnotify.Write}},
+		{name: "project file", ev: fsnotify.Event{Name: "/cfg/projects/api.yaml", Op: fsnotify.Write}, want: true},
+		{name: "project notes", ev: fsnotify.Event{Name: "/cfg/projects/notes.txt", Op: fsnotify.Write}},
+	}
+	for _, tt := range tests {
+		if got := w.relevant(tt.ev); got != tt.want {
+			t.Errorf("%s: relevant() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	events <- fsnotify.Event{Name: "/cfg/config.yml", Op: fsnotify.Write}
+	if msg := w.wait()(); msg != (configChangedMsg{}) {
+		t.Errorf("wait() = %#v, want configChangedMsg", msg)
+	}
+}
+
+// TestWatchEventTriggersReload tests that a change schedules a reload that
+// picks up the new config and keeps the selection
+func TestWatchEventTriggersReload(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	dir := t.TempDir()
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(m.configPath, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("projects:\n  - name: api\n    path: " + dir + "\n  - name: web\n    path: " + dir + "\n")
+	if err := m.loadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	m.list.SetItems(m.projectsToItems())
+	m.list.Select(1)
+
+	write("projects:\n  - name: docs\n    path: " + dir + "\n  - name: api\n    path: " + dir + "\n  - name: web\n    path: " + dir + "\n")
+	next, cmd := m.handleConfigChanged()
+	if cmd == nil {
+		t.Fatal("a config change should schedule a reload")
+	}
+	m2 := next.(Model)
+	next, _ = m2.Update(configReloadMsg{seq: m2.reloadSeq})
+	m2 = next.(Model)
+
+	if names := projectNames(m2.projects); names != "docs,api,web" {
+		t.Errorf("projects after reload = %s", names)
+	}
+	if p, ok := m2.list.SelectedItem().(Project); !ok || p.Name != "web" {
+		t.Errorf("selected %#v after reload, want web", m2.list.SelectedItem())
+	}
+}
+
+// TestConfigReloadDebounce tests that only the last change of a burst
+// reloads
+func TestConfigReloadDebounce(t *testing.T) {
+	m, _ := newTestModel(t, nil)
+	model := Model(*m)
+	for i := 0; i < 3; i++ {
+		next, _ := model.Update(configChangedMsg{})
+		model = next.(Model)
+	}
+	if model.reloadSeq != 3 {
+		t.Fatalf("reloadSeq = %d, want 3", model.reloadSeq)
+	}
+
+	if err := os.WriteFile(m.configPath, []byte("projects:\n  - name: api\n    path: /tmp\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	next, cmd := model.Update(configReloadMsg{seq: 1})
+	if cmd != nil || len(next.(Model).projects) != 0 {
+		t.Error("a stale reload tick should be ignored")
+	}
+	next, _ = model.Update(configReloadMsg{seq: 3})
+	if len(next.(Model).projects) != 1 {
+		t.Error("the last reload tick should reload the config")
+	}
+}
+
+// TestNewConfigWatcher tests that writing the config file on disk is seen
+func TestNewConfigWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	w, err := newConfigWatcher(path, "")
+	if err != nil {
+		t.Skipf("file watching unavailable: %v", err)
+	}
+	defer w.close()
+
+	done := make(chan any, 1)
+	go func() { done <- w.wait()() }()
+	if err := os.WriteFile(path, []byte("projects: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case msg := <-done:
+		if msg != (configChangedMsg{}) {
+			t.Errorf("wait() = %#v, want configChangedMsg", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("no change seen after writing the config")
+	}
+}
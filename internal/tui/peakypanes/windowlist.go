@@ -0,0 +1,145 @@
+package peakypanes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kregenrek/tmuxman/internal/tmuxctl"
+	"github.com/kregenrek/tmuxman/internal/tui/theme"
+)
+
+// windowList is the drill-down into a running session's windows.
+type windowList struct {
+	project Project
+	windows []tmuxctl.Window
+	cursor  int
+}
+
+// newWindowList starts the cursor on the session's active window.
+func newWindowList(p Project, windows []tmuxctl.Window) *windowList {
+	w := &windowList{project: p, windows: windows}
+	for i, win := range windows {
+		if win.Active {
+			w.cursor = i
+		}
+	}
+	return w
+}
+
+// move moves the cursor by delta, clamped to the list.
+func (w *windowList) move(delta int) {
+	w.cursor += delta
+	if w.cursor >= len(w.windows) {
+		w.cursor = len(w.windows) - 1
+	}
+	if w.cursor < 0 {
+		w.cursor = 0
+	}
+}
+
+// target returns the tmux target of the window under the cursor.
+func (w *windowList) target() string {
+	return w.windows[w.cursor].Target(w.project.Session)
+}
+
+// formatPanes renders a pane count, e.g. "1 pane" or "3 panes".
+func formatPanes(n int) string {
+	if n == 1 {
+		return "1 pane"
+	}
+	return fmt.Sprintf("%d panes", n)
+}
+
+// openWindowList lists the windows of the selected running project.
+func (m Model) openWindowList() (tea.Model, tea.Cmd) {
+	item, ok := m.list.SelectedItem().(Project)
+	if !ok {
+		return m, nil
+	}
+	if !item.Status.running() {
+		return m, m.list.NewStatusMessage(FormatStatusWarning(item.Name + " is not running"))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	windows, err := m.tmux.ListWindows(ctx, item.Session)
+	if err != nil {
+		return m, m.list.NewStatusMessage(FormatStatusError(err))
+	}
+	if len(windows) == 0 {
+		return m, m.list.NewStatusMessage(FormatStatusWarning("No windows in " + item.Session))
+	}
+	m.windowList = newWindowList(item, windows)
+	m.state = StateWindowList
+	return m, nil
+}
+
+// attachWindow makes target the session's current window and attaches to
+// the session, so the attach lands on it in every open mode.
+func (m Model) attachWindow(p Project, target string) tea.Cmd {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := m.tmux.SelectWindow(ctx, target); err != nil {
+		return NewErrorCmd(err, "select window")
+	}
+	return m.attachProject(p)
+}
+
+func (m Model) updateWindowList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	w := m.windowList
+	if w == nil {
+		m.state = StateHome
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc", "q", "left", "h", "backspace":
+		m.windowList = nil
+		m.state = StateHome
+		return m, nil
+	case "up", "k":
+		w.move(-1)
+	case "down", "j":
+		w.move(1)
+	case "enter":
+		m.windowList = nil
+		m.state = StateHome
+		return m, m.attachWindow(w.project, w.target())
+	}
+	return m, nil
+}
+
+func (m Model) viewWindowList() string {
+	w := m.windowList
+	if w == nil {
+		return m.viewHome()
+	}
+
+	var b strings.Builder
+	b.WriteString(dialogTitleStyle.Render("🪟 Windows"))
+	b.WriteString("\n\n")
+	b.WriteString(theme.DialogLabel.Render("Session: "))
+	b.WriteString(theme.DialogValue.Render(w.project.Session))
+	b.WriteString("\n\n")
+	for i, win := range w.windows {
+		line := fmt.Sprintf("%s: %s (%s)", win.Index, win.Name, formatPanes(win.Panes))
+		if win.Active {
+			line += " *"
+		}
+		if i == w.cursor {
+			b.WriteString(theme.DialogChoiceKey.Render("› " + line))
+		} else {
+			b.WriteString(theme.DialogValue.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(theme.DialogChoiceKey.Render("enter"))
+	b.WriteString(theme.DialogChoiceSep.Render(" attach • "))
+	b.WriteString(theme.DialogChoiceKey.Render("esc"))
+	b.WriteString(theme.DialogChoiceSep.Render(" back"))
+
+	return appStyle.Render(dialogStyle.Render(b.String()))
+}
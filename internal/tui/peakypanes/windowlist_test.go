@@ -0,0 +1,82 @@
+package peakypanes
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestWindowList tests W lists the session's windows from list-windows and
+// enter selects the chosen window before attaching
+func TestWindowList(t *testing.T) {
+	m, _ := newTestModel(t, []Project{{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning}})
+	var calls [][]string
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		calls = append(calls, args)
+		if args[0] == "list-windows" {
+			return exec.CommandContext(ctx, "printf", "0\teditor\t2\t0\n1\tserver\t1\t1\n2\tlogs\t3\t0\n")
+		}
+		return exec.CommandContext(ctx, "true")
+	})
+
+	model := press(t, *m, "W")
+	if model.state != StateWindowList || model.windowList == nil {
+		t.Fatalf("state = %d, want StateWindowList", model.state)
+	}
+	w := model.windowList
+	if len(w.windows) != 3 || w.windows[0].Name != "editor" || w.windows[2].Panes != 3 {
+		t.Fatalf("windows = %+v", w.windows)
+	}
+	if w.cursor != 1 {
+		t.Errorf("cursor = %d, want the active window", w.cursor)
+	}
+
+	model = press(t, model, "j")
+	if got := model.windowList.target(); got != "api:2" {
+		t.Errorf("target() = %q, want api:2", got)
+	}
+
+	calls = nil
+	next, cmd := model.Update(keyMsg("enter"))
+	model = next.(Model)
+	if model.state != StateHome || cmd == nil {
+		t.Fatalf("state = %d, cmd = %v; want home and an attach", model.state, cmd)
+	}
+	want := [][]string{{"select-window", "-t", "api:2"}}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
+
+// TestWindowListBack tests esc returns to the project list and stopped
+// projects have no window list
+func TestWindowListBack(t *testing.T) {
+	m, _ := newTestModel(t, []Project{
+		{Name: "api", Session: "api", Path: "/srv/api", Status: StatusRunning},
+		{Name: "web", Session: "web", Path: "/srv/web", Status: StatusStopped},
+	})
+	m.list.StatusMessageLifetime = time.Millisecond
+	m.tmux.WithExec(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "printf", "0\teditor\t1\t1\n")
+	})
+
+	model := press(t, *m, "W", "esc")
+	if model.state != StateHome || model.windowList != nil {
+		t.Errorf("state = %d after esc, want StateHome", model.state)
+	}
+
+	model = press(t, model, "down", "W")
+	if model.state != StateHome {
+		t.Errorf("state = %d for a stopped project, want StateHome", model.state)
+	}
+}
+
+func TestFormatPanes(t *testing.T) {
+	for n, want := range map[int]string{1: "1 pane", 0: "0 panes", 4: "4 panes"} {
+		if got := formatPanes(n); got != want {
+			t.Errorf("formatPanes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
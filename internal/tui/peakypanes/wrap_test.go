@@ -0,0 +1,47 @@
+package peakypanes
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestWrapNavigation tests boundary navigation with wrap_navigation on and
+// off
+func TestWrapNavigation(t *testing.T) {
+	for _, wrap := range []bool{false, true} {
+		m, _ := newTestModel(t, nil)
+		cfg := fmt.Sprintf("wrap_navigation: %v\nprojects:\n  - name: a\n    path: /a\n  - name: b\n    path: /b\n  - name: c\n    path: /c\n", wrap)
+		if err := os.WriteFile(m.configPath, []byte(cfg), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.loadConfig(); err != nil {
+			t.Fatalf("loadConfig() error: %v", err)
+		}
+		m.list.SetItems(m.projectsToItems())
+
+		model := press(t, *m, "up")
+		wantTop := 0
+		if wrap {
+			wantTop = 2
+		}
+		if got := model.list.Index(); got != wantTop {
+			t.Errorf("wrap=%v: up at top selected %d, want %d", wrap, got, wantTop)
+		}
+
+		model.list.Select(2)
+		model = press(t, model, "down")
+		wantBottom := 2
+		if wrap {
+			wantBottom = 0
+		}
+		if got := model.list.Index(); got != wantBottom {
+			t.Errorf("wrap=%v: down at bottom selected %d, want %d", wrap, got, wantBottom)
+		}
+
+		model.list.Select(1)
+		if model = press(t, model, "down"); model.list.Index() != 2 {
+			t.Errorf("wrap=%v: down in the middle selected %d, want 2", wrap, model.list.Index())
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package theme
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is a color palette the styles of this package are built from.
+type Theme struct {
+	Name string
+
+	Primary   lipgloss.Color // titles and selection of the project list
+	Secondary lipgloss.Color // titles and selection of the project picker
+
+	Success lipgloss.AdaptiveColor
+	Warning lipgloss.AdaptiveColor
+	Error   lipgloss.AdaptiveColor
+	Info    lipgloss.AdaptiveColor
+
+	TextPrimary   lipgloss.Color
+	TextSecondary lipgloss.Color
+	TextMuted     lipgloss.Color
+	TextDim       lipgloss.Color
+
+	DialogBorder lipgloss.Color
+	DialogLabel  lipgloss.Color
+	DialogValue  lipgloss.Color
+	DialogChoice lipgloss.Color
+
+	Logo lipgloss.Color
+}
+
+// DefaultPreset is the name of the built-in Peaky Panes palette.
+const DefaultPreset = "default"
+
+// defaultTheme captures the palette the package variables start with.
+var defaultTheme = Theme{
+	Name:          DefaultPreset,
+	Primary:       Primary,
+	Secondary:     Secondary,
+	Success:       Success,
+	Warning:       Warning,
+	Error:         Error,
+	Info:          Info,
+	TextPrimary:   TextPrimary,
+	TextSecondary: TextSecondary,
+	TextMuted:     TextMuted,
+	TextDim:       TextDim,
+	DialogBorder:  DialogBorderColor,
+	DialogLabel:   DialogLabelColor,
+	DialogValue:   DialogValueColor,
+	DialogChoice:  DialogChoiceColor,
+	Logo:          Logo,
+}
+
+// fixed is a status color that looks the same on light and dark terminals.
+func fixed(hex string) lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{Light: hex, Dark: hex}
+}
+
+// presets are the named palettes selectable with --theme or theme.preset.
+var presets = map[string]Theme{
+	DefaultPreset: defaultTheme,
+	"dracula": {
+		Name:          "dracula",
+		Primary:       lipgloss.Color("#BD93F9"),
+		Secondary:     lipgloss.Color("#FF79C6"),
+		Success:       fixed("#50FA7B"),
+		Warning:       fixed("#FFB86C"),
+		Error:         fixed("#FF5555"),
+		Info:          fixed("#8BE9FD"),
+		TextPrimary:   lipgloss.Color("#F8F8F2"),
+		TextSecondary: lipgloss.Color("#C0C0D0"),
+		TextMuted:     lipgloss.Color("#6272A4"),
+		TextDim:       lipgloss.Color("#44475A"),
+		DialogBorder:  lipgloss.Color("#FF79C6"),
+		DialogLabel:   lipgloss.Color("#6272A4"),
+		DialogValue:   lipgloss.Color("#F8F8F2"),
+		DialogChoice:  lipgloss.Color("#50FA7B"),
+		Logo:          lipgloss.Color("#F1FA8C"),
+	},
+	"gruvbox": {
+		Name:          "gruvbox",
+		Primary:       lipgloss.Color("#D65D0E"),
+		Secondary:     lipgloss.Color("#98971A"),
+		Success:       fixed("#B8BB26"),
+		Warning:       fixed("#FABD2F"),
+		Error:         fixed("#FB4934"),
+		Info:          fixed("#83A598"),
+		TextPrimary:   lipgloss.Color("#FBF1C7"),
+		TextSecondary: lipgloss.Color("#D5C4A1"),
+		TextMuted:     lipgloss.Color("#928374"),
+		TextDim:       lipgloss.Color("#504945"),
+		DialogBorder:  lipgloss.Color("#FE8019"),
+		DialogLabel:   lipgloss.Color("#A89984"),
+		DialogValue:   lipgloss.Color("#EBDBB2"),
+		DialogChoice:  lipgloss.Color("#B8BB26"),
+		Logo:          lipgloss.Color("#FABD2F"),
+	},
+	"nord": {
+		Name:          "nord",
+		Primary:       lipgloss.Color("#5E81AC"),
+		Secondary:     lipgloss.Color("#8FBCBB"),
+		Success:       fixed("#A3BE8C"),
+		Warning:       fixed("#EBCB8B"),
+		Error:         fixed("#BF616A"),
+		Info:          fixed("#88C0D0"),
+		TextPrimary:   lipgloss.Color("#ECEFF4"),
+		TextSecondary: lipgloss.Color("#D8DEE9"),
+		TextMuted:     lipgloss.Color("#616E88"),
+		TextDim:       lipgloss.Color("#3B4252"),
+		DialogBorder:  lipgloss.Color("#88C0D0"),
+		DialogLabel:   lipgloss.Color("#81A1C1"),
+		DialogValue:   lipgloss.Color("#E5E9F0"),
+		DialogChoice:  lipgloss.Color("#A3BE8C"),
+		Logo:          lipgloss.Color("#EBCB8B"),
+	},
+}
+
+// Preset returns the named palette. Names are matched case-insensitively
+// and an empty name is the default palette.
+func Preset(name string) (Theme, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = DefaultPreset
+	}
+	t, ok := presets[name]
+	return t, ok
+}
+
+// PresetNames lists the available presets, sorted.
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Apply makes t the palette of every style in this package. Components
+// copy styles when they are set up, so apply a theme before creating them.
+func Apply(t Theme) {
+	Primary, Secondary = t.Primary, t.Secondary
+	Success, Warning, Error, Info = t.Success, t.Warning, t.Error, t.Info
+	TextPrimary, TextSecondary, TextMuted, TextDim = t.TextPrimary, t.TextSecondary, t.TextMuted, t.TextDim
+	DialogBorderColor, DialogLabelColor = t.DialogBorder, t.DialogLabel
+	DialogValueColor, DialogChoiceColor = t.DialogValue, t.DialogChoice
+	Logo = t.Logo
+
+	Title = Title.Foreground(TextPrimary).Background(Primary)
+	TitleAlt = TitleAlt.Foreground(TextPrimary).Background(Secondary)
+
+	StatusMessage = StatusMessage.Foreground(Success)
+	StatusError = StatusError.Foreground(Error)
+	StatusWarning = StatusWarning.Foreground(Warning)
+	StatusDebug = StatusDebug.Foreground(TextMuted)
+
+	Dialog = Dialog.BorderForeground(DialogBorderColor)
+	DialogTitle = DialogTitle.Foreground(DialogBorderColor)
+	DialogLabel = DialogLabel.Foreground(DialogLabelColor)
+	DialogValue = DialogValue.Foreground(DialogValueColor)
+	DialogNote = DialogNote.Foreground(DialogLabelColor)
+	DialogChoiceKey = DialogChoiceKey.Foreground(DialogChoiceColor)
+	DialogChoiceSep = DialogChoiceSep.Foreground(DialogLabelColor)
+
+	ListSelectedTitle = ListSelectedTitle.Foreground(TextPrimary).BorderLeftForeground(Primary)
+	ListSelectedDesc = ListSelectedDesc.Foreground(TextSecondary).BorderLeftForeground(Primary)
+	ListSelectedTitleAlt = ListSelectedTitleAlt.Foreground(TextPrimary).BorderLeftForeground(Secondary)
+	ListSelectedDescAlt = ListSelectedDescAlt.Foreground(TextSecondary).BorderLeftForeground(Secondary)
+
+	ShortcutCategory = ShortcutCategory.Foreground(Primary)
+	ShortcutKey = ShortcutKey.Foreground(DialogChoiceColor)
+	ShortcutDesc = ShortcutDesc.Foreground(DialogValueColor)
+	ShortcutNote = ShortcutNote.Foreground(DialogLabelColor)
+
+	HealthOK = HealthOK.Foreground(Success)
+	HealthFailing = HealthFailing.Foreground(Error)
+
+	EmptyState = EmptyState.BorderForeground(TextMuted).Foreground(TextSecondary)
+	PreviewPanel = PreviewPanel.BorderForeground(TextDim).Foreground(TextSecondary)
+	LogoStyle = LogoStyle.Foreground(Logo)
+
+	ErrorBox = ErrorBox.BorderForeground(Error)
+	ErrorTitle = ErrorTitle.Foreground(Error)
+	ErrorMessage = ErrorMessage.Foreground(DialogValueColor)
+}
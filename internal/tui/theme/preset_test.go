@@ -0,0 +1,72 @@
+package theme
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TestPresetColorsNonEmpty tests that every preset sets every color
+func TestPresetColorsNonEmpty(t *testing.T) {
+	for _, name := range PresetNames() {
+		p, ok := Preset(name)
+		if !ok {
+			t.Fatalf("Preset(%q) not found", name)
+		}
+		v := reflect.ValueOf(p)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).IsZero() {
+				t.Errorf("preset %s: %s is empty", name, v.Type().Field(i).Name)
+			}
+		}
+	}
+}
+
+// TestPresetsDistinct tests that presets produce different styles
+func TestPresetsDistinct(t *testing.T) {
+	t.Cleanup(func() { Apply(defaultTheme) })
+
+	seen := make(map[string]string)
+	for _, name := range PresetNames() {
+		p, _ := Preset(name)
+		Apply(p)
+		if got := Title.GetBackground(); got != p.Primary {
+			t.Errorf("preset %s: title background = %v, want %v", name, got, p.Primary)
+		}
+		if got := StatusError.GetForeground(); got != lipgloss.TerminalColor(p.Error) {
+			t.Errorf("preset %s: error color = %v, want %v", name, got, p.Error)
+		}
+		key := string(p.Primary) + string(p.Secondary) + string(p.DialogBorder) + string(p.Logo)
+		if other, ok := seen[key]; ok {
+			t.Errorf("presets %s and %s share their colors", name, other)
+		}
+		seen[key] = name
+	}
+}
+
+// TestPresetLookup tests name matching and unknown names
+func TestPresetLookup(t *testing.T) {
+	if p, ok := Preset("Nord"); !ok || p.Name != "nord" {
+		t.Errorf("Preset(Nord) = %q, %v; want nord", p.Name, ok)
+	}
+	if p, ok := Preset(""); !ok || p.Name != DefaultPreset {
+		t.Errorf("Preset(\"\") = %q, %v; want the default", p.Name, ok)
+	}
+	if _, ok := Preset("solarized-neon"); ok {
+		t.Error("an unknown preset should not be found")
+	}
+}
+
+// TestApplyDefaultKeepsStyles tests that applying the default palette
+// leaves the styles as they were declared
+func TestApplyDefaultKeepsStyles(t *testing.T) {
+	before := []lipgloss.Style{Title, Dialog, DialogNote, ShortcutKey, ErrorMessage}
+	Apply(defaultTheme)
+	after := []lipgloss.Style{Title, Dialog, DialogNote, ShortcutKey, ErrorMessage}
+	for i := range before {
+		if !reflect.DeepEqual(before[i], after[i]) {
+			t.Errorf("style %d changed by applying the default palette", i)
+		}
+	}
+}
@@ -2,7 +2,12 @@
 // Following best practices: all styles are defined in one place for consistency.
 package theme
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"hash/fnv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Color palette - using adaptive colors for light/dark terminal support
 var (
@@ -81,6 +86,10 @@ var StatusError = lipgloss.NewStyle().
 var StatusWarning = lipgloss.NewStyle().
 	Foreground(Warning)
 
+// StatusDebug for diagnostics shown in debug mode
+var StatusDebug = lipgloss.NewStyle().
+	Foreground(TextMuted)
+
 // ===== Dialog Styles =====
 
 // Dialog is the container for modal dialogs
@@ -143,6 +152,12 @@ var ListDimmed = lipgloss.NewStyle().
 
 // ===== Shortcut/Help Styles =====
 
+// ShortcutCategory for category headers in help views
+var ShortcutCategory = lipgloss.NewStyle().
+	Foreground(Primary).
+	Bold(true).
+	Underline(true)
+
 // ShortcutKey for keyboard shortcut keys
 var ShortcutKey = lipgloss.NewStyle().
 	Foreground(lipgloss.Color("114")).
@@ -162,6 +177,68 @@ var ShortcutNote = lipgloss.NewStyle().
 var ShortcutHint = lipgloss.NewStyle().
 	Foreground(lipgloss.Color("241"))
 
+// ===== Health Indicator Styles =====
+
+// HealthOK for passing project healthchecks
+var HealthOK = lipgloss.NewStyle().
+	Foreground(Success)
+
+// HealthFailing for failing project healthchecks
+var HealthFailing = lipgloss.NewStyle().
+	Foreground(Error)
+
+// ===== Tag Colors =====
+
+// TagPalette holds the colors project tags are hashed onto. Colors are
+// spread around the wheel so neighbouring tags stay distinguishable.
+var TagPalette = []lipgloss.Color{
+	lipgloss.Color("#E06C75"), // red
+	lipgloss.Color("#E5C07B"), // yellow
+	lipgloss.Color("#98C379"), // green
+	lipgloss.Color("#56B6C2"), // cyan
+	lipgloss.Color("#61AFEF"), // blue
+	lipgloss.Color("#C678DD"), // magenta
+	lipgloss.Color("#D19A66"), // orange
+	lipgloss.Color("#FF79C6"), // pink
+}
+
+// TagColor returns the color for tag. Tags are matched case-insensitively;
+// overrides (from the theme config, keyed by tag) win over the hashed
+// palette color, which is stable across runs. An empty tag has no color.
+func TagColor(tag string, overrides map[string]string) lipgloss.Color {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return ""
+	}
+	for name, color := range overrides {
+		if strings.ToLower(strings.TrimSpace(name)) == tag && color != "" {
+			return lipgloss.Color(color)
+		}
+	}
+	h := fnv.New32a()
+	h.Write([]byte(tag))
+	return TagPalette[h.Sum32()%uint32(len(TagPalette))]
+}
+
+// ===== Empty State Style =====
+
+// EmptyState frames the hint shown when no projects are configured
+var EmptyState = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(TextMuted).
+	Foreground(TextSecondary).
+	Padding(1, 2).
+	MarginTop(1)
+
+// ===== Preview Style =====
+
+// PreviewPanel frames the captured pane shown next to the project list
+var PreviewPanel = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(TextDim).
+	Foreground(TextSecondary).
+	Padding(0, 1)
+
 // ===== Logo Style =====
 
 // LogoStyle for ASCII art logo
@@ -69,10 +69,15 @@ func TestStylesNotNil(t *testing.T) {
 		"ListSelectedTitle": ListSelectedTitle,
 		"ListSelectedDesc":  ListSelectedDesc,
 		"ListDimmed":        ListDimmed,
+		"ShortcutCategory":  ShortcutCategory,
 		"ShortcutKey":       ShortcutKey,
 		"ShortcutDesc":      ShortcutDesc,
 		"ShortcutNote":      ShortcutNote,
 		"ShortcutHint":      ShortcutHint,
+		"HealthOK":          HealthOK,
+		"HealthFailing":     HealthFailing,
+		"EmptyState":        EmptyState,
+		"PreviewPanel":      PreviewPanel,
 		"LogoStyle":         LogoStyle,
 		"ErrorBox":          ErrorBox,
 		"ErrorTitle":        ErrorTitle,
@@ -125,3 +130,45 @@ func TestStyleRenderNotPanic(t *testing.T) {
 	_ = LogoStyle.Render("test")
 	_ = ErrorBox.Render("test")
 }
+
+// TestTagColorStable tests that a tag always hashes to the same palette color
+func TestTagColorStable(t *testing.T) {
+	first := TagColor("backend", nil)
+	if first == "" {
+		t.Fatal("TagColor(backend) should not be empty")
+	}
+	for i := 0; i < 10; i++ {
+		if got := TagColor("backend", nil); got != first {
+			t.Fatalf("TagColor(backend) = %q, then %q", first, got)
+		}
+	}
+	if got := TagColor(" Backend ", nil); got != first {
+		t.Errorf("TagColor should ignore case and spaces: %q vs %q", got, first)
+	}
+	found := false
+	for _, c := range TagPalette {
+		if c == first {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("TagColor(backend) = %q, not in TagPalette", first)
+	}
+	if got := TagColor("", nil); got != "" {
+		t.Errorf("TagColor(\"\") = %q, want empty", got)
+	}
+}
+
+// TestTagColorOverride tests that theme overrides replace the hashed color
+func TestTagColorOverride(t *testing.T) {
+	overrides := map[string]string{"Backend": "#123456", "empty": ""}
+	if got := TagColor("backend", overrides); got != "#123456" {
+		t.Errorf("TagColor(backend) = %q, want #123456", got)
+	}
+	if got := TagColor("empty", overrides); got != TagColor("empty", nil) {
+		t.Errorf("empty override should fall back to the palette, got %q", got)
+	}
+	if got := TagColor("frontend", overrides); got != TagColor("frontend", nil) {
+		t.Errorf("unrelated override changed frontend color to %q", got)
+	}
+}
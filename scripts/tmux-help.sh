@@ -1,7 +1,7 @@
 #!/usr/bin/env bash
 
 if command -v tmuxhelp >/dev/null 2>&1; then
-  exec tmuxhelp
+  exec tmuxhelp "$@"
 fi
 
 cat <<'ROWS' | column -t -s $'\t'